@@ -0,0 +1,15 @@
+// Package assets embeds the game's sprites and tiles. Importing it (cmd/game
+// does, for its side effect) mounts them on the vfs search path under
+// "assets", so a single binary runs without the loose files.
+package assets
+
+import (
+	"embed"
+
+	"rp-go/engine/vfs"
+)
+
+//go:embed entities tiles
+var files embed.FS
+
+func init() { vfs.MountEmbedded("assets", files) }
@@ -0,0 +1,183 @@
+// Command assetpack packs assets/ and engine/data/ into a single
+// content-addressed bundle the game mounts at startup (vfs.BundleName):
+//
+//	go run ./cmd/assetpack -out assets.bundle
+//
+// Every sprite referenced by actors.json (and actor packs) must exist, or
+// the build fails. With -check, nothing is written; the command exits
+// non-zero when the existing bundle is stale against the loose files, for
+// use in CI.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"rp-go/engine/data"
+	"rp-go/engine/vfs"
+)
+
+// packedDirs are the game-relative directories a bundle holds.
+var packedDirs = []string{"assets", "engine/data"}
+
+func main() {
+	root := flag.String("root", ".", "game root holding assets/ and engine/data/")
+	out := flag.String("out", vfs.BundleName, "bundle file to write")
+	check := flag.Bool("check", false, "compare the existing -out bundle with the loose files instead of writing")
+	flag.Parse()
+
+	fsys := os.DirFS(*root)
+	names, err := collect(fsys)
+	if err != nil {
+		fail("%v", err)
+	}
+	if problems := checkSprites(fsys, names); len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Fprintln(os.Stderr, p)
+		}
+		fail("%d missing sprite(s)", len(problems))
+	}
+
+	if *check {
+		os.Exit(checkBundle(*out, fsys, names))
+	}
+
+	var buf bytes.Buffer
+	manifest, err := vfs.WriteBundle(&buf, fsys, names)
+	if err != nil {
+		fail("%v", err)
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		fail("%v", err)
+	}
+	blobs := make(map[string]bool)
+	for _, e := range manifest.Files {
+		blobs[e.Hash] = true
+	}
+	fmt.Printf("assetpack: wrote %s (%d files, %d blobs, %d bytes)\n", *out, len(manifest.Files), len(blobs), buf.Len())
+}
+
+// collect lists every regular file under packedDirs.
+func collect(fsys fs.FS) ([]string, error) {
+	var names []string
+	for _, dir := range packedDirs {
+		err := fs.WalkDir(fsys, dir, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.Type().IsRegular() && path.Ext(name) != ".go" {
+				names = append(names, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// checkSprites reports actor templates whose sprite image is not packed.
+func checkSprites(fsys fs.FS, names []string) data.Problems {
+	packed := make(map[string]bool, len(names))
+	for _, name := range names {
+		packed[name] = true
+	}
+
+	patterns := []string{"actors.json", data.ActorPackPattern}
+	var sources []data.Source
+	for _, name := range names {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(path.Join("engine/data", pattern), name); ok {
+				raw, err := fs.ReadFile(fsys, name)
+				if err != nil {
+					return data.Problems{{File: name, Message: err.Error()}}
+				}
+				sources = append(sources, data.Source{Path: name, Data: raw})
+			}
+		}
+	}
+	db, problems := data.ValidateActorPacks(sources, data.LintOptions{})
+	if len(problems) > 0 {
+		return problems
+	}
+
+	var missing data.Problems
+	for _, tpl := range db.Actors {
+		var sprite data.ActorSpriteTemplate
+		if raw, ok := tpl.ComponentSpecs()["Sprite"]; ok {
+			_ = json.Unmarshal(raw, &sprite)
+		}
+		if sprite.Image == "" {
+			continue
+		}
+		if !packed[path.Clean(filepath.ToSlash(sprite.Image))] {
+			missing = append(missing, data.Problem{
+				File:    "engine/data/actors.json",
+				Path:    tpl.Name + ".sprite.image",
+				Message: fmt.Sprintf("sprite %q not found under assets/", sprite.Image),
+			})
+		}
+	}
+	return missing
+}
+
+// checkBundle compares file's manifest with the loose files and returns
+// the exit code: 0 when they match, 1 when the bundle is stale or unreadable.
+func checkBundle(file string, fsys fs.FS, names []string) int {
+	b, err := vfs.OpenBundle(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "assetpack: %v\n", err)
+		return 1
+	}
+	defer b.Close()
+	if err := b.Verify(); err != nil {
+		fmt.Fprintf(os.Stderr, "assetpack: %s: %v\n", file, err)
+		return 1
+	}
+
+	have := make(map[string]vfs.ManifestEntry)
+	for name, e := range b.Manifest().Files {
+		have[name] = e
+	}
+	var stale []string
+	for _, name := range names {
+		raw, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "assetpack: %v\n", err)
+			return 1
+		}
+		switch e, ok := have[name]; {
+		case !ok:
+			stale = append(stale, "added:   "+name)
+		case e.Hash != vfs.HashContent(raw):
+			stale = append(stale, "changed: "+name)
+		}
+		delete(have, name)
+	}
+	for name := range have {
+		stale = append(stale, "removed: "+name)
+	}
+	if len(stale) == 0 {
+		fmt.Printf("assetpack: %s is up to date (%d files)\n", file, len(names))
+		return 0
+	}
+	sort.Strings(stale)
+	for _, line := range stale {
+		fmt.Fprintln(os.Stderr, line)
+	}
+	fmt.Fprintf(os.Stderr, "assetpack: %s is stale (%d file(s) differ); rebuild it\n", file, len(stale))
+	return 1
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "assetpack: "+format+"\n", args...)
+	os.Exit(1)
+}
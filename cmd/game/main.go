@@ -7,17 +7,33 @@ import (
 	"os"
 	"strconv"
 
+	_ "rp-go/assets" // embeds sprites so the binary runs from any directory
 	"rp-go/engine/core"
 	"rp-go/engine/platform"
+	"rp-go/engine/replay"
 )
 
 type Game struct {
 	world     *core.GameWorld
 	offscreen *platform.Image
+
+	recorder *replay.Recorder // set with -record
+	player   *replay.Player   // set with -replay
 }
 
 func (g *Game) Update() error {
+	if g.player != nil {
+		g.player.BeginFrame(g.world.Console.Enqueue)
+	}
 	g.world.Update()
+	if g.recorder != nil {
+		g.recorder.EndFrame(g.world.World)
+	}
+	if g.player != nil {
+		if err := g.player.EndFrame(g.world.World); err != nil {
+			log.Printf("[REPLAY] %v\n", err)
+		}
+	}
 	return nil
 }
 
@@ -73,6 +89,8 @@ func main() {
 
 	headless := flag.Bool("headless", false, "run without opening a window")
 	frames := flag.Int("frames", 120, "number of frames to run in headless mode")
+	recordPath := flag.String("record", "", "record inputs and seeds to this replay file")
+	replayPath := flag.String("replay", "", "play back a replay file headlessly and verify it")
 	flag.Parse()
 
 	// Allow environment variables to override flags
@@ -87,20 +105,96 @@ func main() {
 		}
 	}
 
+	// Replay mode: reproduce a recorded session and check for divergence
+	if *replayPath != "" {
+		code := runReplay(game, *replayPath)
+		gameWorld.Close()
+		os.Exit(code)
+	}
+
+	if *recordPath != "" {
+		game.recorder = startRecording(gameWorld)
+	}
+
 	// Headless simulation mode
 	if *headless {
-		if err := platform.RunHeadless(game, *frames, cfg.Viewport.Width, cfg.Viewport.Height); err != nil {
+		err := platform.RunHeadless(game, *frames, cfg.Viewport.Width, cfg.Viewport.Height)
+		game.saveRecording(*recordPath)
+		gameWorld.Close()
+		if err != nil {
 			log.Fatal(err)
 		}
 		log.Printf("Headless run complete (%d frames)\n", *frames)
 		return
 	}
 
-	// Normal game mode
+	// Normal game mode, with data hot reload
+	gameWorld.WatchData()
 	platform.SetWindowSize(cfg.Window.Width, cfg.Window.Height)
 	platform.SetWindowTitle("rp-go: ECS Camera Prototype")
 
-	if err := platform.RunGame(game); err != nil {
+	err := platform.RunGame(game)
+	game.saveRecording(*recordPath)
+	gameWorld.Close()
+	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+// saveRecording writes the -record file once the session ends.
+func (g *Game) saveRecording(path string) {
+	if g.recorder == nil || path == "" {
+		return
+	}
+	file := g.recorder.File()
+	if err := file.Save(path); err != nil {
+		log.Printf("[REPLAY] Failed to save %s: %v\n", path, err)
+		return
+	}
+	log.Printf("[REPLAY] Recorded %d frames to %s\n", len(file.Frames), path)
+}
+
+// startRecording captures the session's RNG seeds, console commands and
+// console pauses.
+func startRecording(gw *core.GameWorld) *replay.Recorder {
+	rec := replay.NewRecorder()
+	rec.SetSeed(replay.SeedAI, gw.AI.Seed())
+	rec.SetSeed(replay.SeedBackground, gw.Background.Seed())
+	gw.Console.SetCommandObserver(rec.RecordCommand)
+	rec.SetPauseSource(gw.Paused)
+	return rec
+}
+
+// runReplay plays path back through RunHeadless and returns the exit code:
+// 0 when every frame matches its recorded checksum, 1 otherwise.
+func runReplay(game *Game, path string) int {
+	file, err := replay.Load(path)
+	if err != nil {
+		log.Print(err)
+		return 1
+	}
+
+	gw := game.world
+	player := replay.NewPlayer(file)
+	defer player.Close()
+	if seed, ok := player.Seed(replay.SeedAI); ok {
+		gw.AI.Reseed(seed)
+	}
+	if seed, ok := player.Seed(replay.SeedBackground); ok {
+		gw.Background.SetSeed(seed)
+	}
+	gw.Pause = player.Paused // the console never opens during playback
+	game.player = player
+
+	cfg := gw.Config
+	if err := platform.RunHeadless(game, player.Len(), cfg.Viewport.Width, cfg.Viewport.Height); err != nil {
+		log.Print(err)
+		return 1
+	}
+	if div := player.Divergence(); div != nil {
+		log.Printf("[REPLAY] %s: %v\n", path, div)
+		return 1
+	}
+	log.Printf("[REPLAY] %s: %d frames reproduced exactly\n", path, player.Len())
+	return 0
+}
@@ -1,9 +1,13 @@
 package core
 
 import (
+	"time"
+
 	"rp-go/engine/data"
 	"rp-go/engine/ecs"
 	"rp-go/engine/events"
+	"rp-go/engine/gfx"
+	"rp-go/engine/nav"
 	"rp-go/engine/platform"
 
 	"rp-go/engine/scenes/space"
@@ -13,6 +17,7 @@ import (
 	"rp-go/engine/systems/aicomposer"
 	"rp-go/engine/systems/background"
 	"rp-go/engine/systems/camera"
+	"rp-go/engine/systems/collision"
 	dataSys "rp-go/engine/systems/data" // renamed to avoid collision
 	"rp-go/engine/systems/debug"
 	"rp-go/engine/systems/devconsole"
@@ -22,6 +27,8 @@ import (
 	"rp-go/engine/systems/movement"
 	"rp-go/engine/systems/render"
 	"rp-go/engine/systems/scene"
+	"rp-go/engine/systems/spatial"
+	"rp-go/engine/systems/transform"
 	"rp-go/engine/systems/windowmgr"
 	"rp-go/engine/world"
 )
@@ -33,6 +40,20 @@ import (
 type GameWorld struct {
 	World  *ecs.World
 	Config data.RenderConfig
+
+	// Systems exposed for replay seeding and console command injection.
+	AI         *ai.System
+	Background *background.System
+	Console    *devconsole.System
+
+	// Pause is asked once per Update whether the simulation should stay
+	// frozen for that frame. It defaults to the developer console having
+	// focus; replays substitute the recorded value.
+	Pause  func() bool
+	paused bool
+	held   bool // Clock.Paused was set by Pause
+
+	data *dataSys.System
 }
 
 /*───────────────────────────────────────────────*
@@ -45,12 +66,17 @@ func NewGameWorld() *GameWorld {
 	// -------------------------------------------------------------------------
 	w := ecs.NewWorld()
 	w.EventBus = events.NewBus()
+	gw := &GameWorld{World: w, Pause: devconsole.IsOpen}
 
 	// -------------------------------------------------------------------------
-	// Data System (config, actor db, ai.json hot reload)
+	// Data System (config, actor db, ai.json hot reload, mod packs)
 	// -------------------------------------------------------------------------
 	dataSystem := dataSys.NewSystem()
-	w.AddSystem(dataSystem)
+	w.AddSystemTo(ecs.PhasePreUpdate, dataSystem)
+	gfx.SetAssetOpener(dataSystem.Mods().Open) // packs may override sprites
+	if face, err := gfx.LoadFont(gfx.DefaultFontPath, 13); err == nil {
+		platform.SetDefaultFont(face)
+	}
 
 	cfg := dataSystem.Config
 	if cfg.Window.Width == 0 {
@@ -62,10 +88,21 @@ func NewGameWorld() *GameWorld {
 	// -------------------------------------------------------------------------
 	sceneManager := &scene.Manager{}
 	actorSystem := actor.NewSystem()
+	spatialSystem := spatial.NewSystem(spatial.DefaultCellSize)
 
 	// --- AI Layer ------------------------------------------------------------
 	aiSystem := ai.NewSystem(dataSystem.AICatalog)
 	aiSystem.SetActorLookup(actorSystem.Registry())
+	aiSystem.SetNavigator(nav.NewService(nav.Config{})) // scenes supply the map
+	aiSystem.SetSpatialIndex(spatialSystem.Index())
+
+	// Startup and hot-reloaded data are validated against the live
+	// registries, now that the AI system has registered its behaviors.
+	dataSystem.SetLint(data.LintOptions{
+		BehaviorTypes: ai.GlobalBehaviorCatalog.List(),
+		Components:    world.DefaultComponents().Names(),
+		CheckParams:   ai.GlobalBehaviorCatalog.CheckParams,
+	})
 
 	composerSystem := aicomposer.NewSystem(dataSystem, aiSystem)
 
@@ -77,6 +114,7 @@ func NewGameWorld() *GameWorld {
 		ViewportWidth:  cfg.Viewport.Width,
 		ViewportHeight: cfg.Viewport.Height,
 	})
+	consoleSystem.SetMods(dataSystem.Mods())
 
 	debugSystem := debug.NewSystem(debug.Config{
 		Margin:         16,
@@ -87,64 +125,99 @@ func NewGameWorld() *GameWorld {
 
 	entityListSystem := entitylist.NewSystem(actorSystem.Registry())
 
+	// -------------------------------------------------------------------------
+	// PreUpdate Phase — scene transitions and bookkeeping
+	// -------------------------------------------------------------------------
+	preUpdateSystems := []ecs.System{
+		sceneManager,   // scene transitions, loading/unloading
+		actorSystem,    // actor registration
+		composerSystem, // auto-binds AIControllers from refs
+	}
+
 	// -------------------------------------------------------------------------
 	// Simulation Phase — world state and logic
 	// -------------------------------------------------------------------------
+	movementSystem := &movement.System{Index: spatialSystem.Index()}
+	collisionSystem := collision.NewSystem(spatialSystem.Index())
 	simulationSystems := []ecs.System{
-		dataSystem,         // config hot-reload + JSON catalogs
-		sceneManager,       // scene transitions, loading/unloading
-		actorSystem,        // actor registration
-		composerSystem,     // auto-binds AIControllers from refs
-		&input.System{},    // player + input control
-		aiSystem,           // AI decision-making & movement
-		&movement.System{}, // position/velocity propagation
+		&input.System{}, // player + input control
+		aiSystem,        // AI decision-making & movement
+		movementSystem,  // position/velocity propagation
+		collisionSystem, // contacts, triggers and push-out after movement
+	}
+
+	// -------------------------------------------------------------------------
+	// PostUpdate Phase — state derived from the simulation
+	// -------------------------------------------------------------------------
+	hudSystem := hud.NewSystem()
+	postUpdateSystems := []ecs.System{
+		&transform.System{}, // parent → child transforms, before the camera follows
+		spatialSystem,       // refile entities moved outside movement
 		camera.NewSystem(camera.Config{
 			MinScale: cfg.Viewport.MinScale,
 			MaxScale: cfg.Viewport.MaxScale,
 			ZoomStep: cfg.Viewport.ZoomStep,
 			ZoomLerp: cfg.Viewport.ZoomLerp,
 		}),
+		hudSystem, // HUD content from settled positions, beside the camera
 	}
 
 	// -------------------------------------------------------------------------
-	// Rendering Phase — visuals & overlays
+	// Render + Overlay Phases — visuals & overlays
 	// -------------------------------------------------------------------------
-	hudSystem := hud.NewSystem()
 	windowSystem := windowmgr.NewSystem()
+	backgroundSystem := &background.System{}
+	renderSystem := &render.System{}
 
-	windowRenderers := []ecs.System{
+	renderingSystems := []ecs.System{
+		backgroundSystem,         // parallax stars
+		renderSystem,             // world-space drawables, culled to the view
+		&debug.PathOverlay{},     // AI paths, hidden with the debug overlay
+		&debug.ColliderOverlay{}, // collider outlines, hidden with the debug overlay
+		windowSystem,             // modular window overlays
 		render.NewWindowRenderer(ecs.LayerHUD),
 		render.NewWindowRenderer(ecs.LayerDebug),
 		render.NewWindowRenderer(ecs.LayerConsole),
 	}
 
-	renderingSystems := []ecs.System{
-		&background.System{}, // parallax stars
-		&render.System{},     // world-space drawables
-		hudSystem,            // reusable HUD content
-		windowSystem,         // modular window overlays
-	}
-	renderingSystems = append(renderingSystems, windowRenderers...)
-	renderingSystems = append(renderingSystems,
+	overlaySystems := []ecs.System{
 		entityListSystem, // entity info overlay
 		debugSystem,      // debug metrics, including composer window
 		consoleSystem,    // developer console overlay
-	)
+	}
 
 	// -------------------------------------------------------------------------
 	// System Registration
 	// -------------------------------------------------------------------------
-	for _, sys := range simulationSystems {
-		w.AddSystem(sys)
+	phases := []struct {
+		phase   ecs.Phase
+		systems []ecs.System
+	}{
+		{ecs.PhasePreUpdate, preUpdateSystems},
+		{ecs.PhaseSimulation, simulationSystems},
+		{ecs.PhasePostUpdate, postUpdateSystems},
+		{ecs.PhaseRender, renderingSystems},
+		{ecs.PhaseOverlay, overlaySystems},
 	}
-	for _, sys := range renderingSystems {
-		w.AddSystem(sys)
+	for _, p := range phases {
+		for _, sys := range p.systems {
+			w.AddSystemTo(p.phase, sys)
+		}
 	}
 
+	// Freeze the simulation while paused (by default, while the developer
+	// console has focus).
+	w.AddPhaseCondition(ecs.PhaseSimulation, func(*ecs.World) bool {
+		return !gw.paused
+	})
+
 	if sub := dataSystem.Subscriber(); sub != nil {
 		sub.Register("actor_db", consoleSystem.OnDataReload)
 		sub.Register("all", consoleSystem.OnDataReload)
 	}
+	if bus, ok := w.EventBus.(*events.TypedBus); ok {
+		events.Subscribe(bus, consoleSystem.OnDataReloadFailed)
+	}
 
 	// -------------------------------------------------------------------------
 	// Initial Scene Setup
@@ -154,26 +227,58 @@ func NewGameWorld() *GameWorld {
 	// -------------------------------------------------------------------------
 	// Return Assembled World
 	// -------------------------------------------------------------------------
-	return &GameWorld{
-		World:  w,
-		Config: cfg,
-	}
+	gw.Config = cfg
+	gw.data = dataSystem
+	gw.AI = aiSystem
+	gw.Background = backgroundSystem
+	gw.Console = consoleSystem
+	return gw
 }
 
 /*───────────────────────────────────────────────*
 | MAIN LOOP                                     |
 *───────────────────────────────────────────────*/
 
-// Update advances the world simulation by one frame and flushes events.
+// Update advances the world simulation by one frame's worth of time on the
+// fixed-step clock and flushes events. Pause is sampled once, before the
+// frame runs, so the whole frame sees the same answer; while it holds, the
+// clock is paused too, so timers measured in Clock.Elapsed stop counting.
 func (g *GameWorld) Update() {
-	g.World.Update()
+	g.paused = g.Pause != nil && g.Pause()
+	clock := g.World.Clock()
+	switch {
+	case g.paused && !clock.Paused:
+		clock.Paused, g.held = true, true
+	case !g.paused && g.held:
+		clock.Paused, g.held = false, false
+	}
+	g.World.Advance(frameDuration())
 	if bus, ok := g.World.EventBus.(*events.TypedBus); ok && bus != nil {
 		bus.Flush()
 	}
 }
 
+// WatchData hot-reloads data files as they change on disk. It is off by
+// default so tests, tools and replays run on the data they started with.
+func (g *GameWorld) WatchData() { g.data.Watch() }
+
+// Close releases the data file watcher, if WatchData started one.
+func (g *GameWorld) Close() { g.data.Stop() }
+
+// Paused reports whether the simulation was frozen during the last Update.
+func (g *GameWorld) Paused() bool { return g.paused }
+
 // Draw executes all world-space renderers.
 // Overlays (HUD, console, debug) are drawn later in main.go.
 func (g *GameWorld) Draw(screen *platform.Image) {
 	g.World.DrawWorld(screen)
 }
+
+// frameDuration is the real time covered by one platform Update call.
+func frameDuration() time.Duration {
+	tps := platform.TPS()
+	if tps <= 0 {
+		return ecs.DefaultStep
+	}
+	return time.Second / time.Duration(tps)
+}
@@ -11,16 +11,19 @@ func TestNewGameWorldRegistersSystemPasses(t *testing.T) {
 	world := NewGameWorld().World
 
 	simulationTypes := map[string]struct{}{
-		"*scene.Manager":   {},
-		"*input.System":    {},
-		"*ai.System":       {},
-		"*movement.System": {},
-		"*camera.System":   {},
+		"*scene.Manager":    {},
+		"*input.System":     {},
+		"*ai.System":        {},
+		"*movement.System":  {},
+		"*collision.System": {},
+		"*camera.System":    {},
 	}
 	renderingTypes := map[string]struct{}{
-		"*background.System": {},
-		"*render.System":     {},
-		"*debug.System":      {},
+		"*background.System":     {},
+		"*render.System":         {},
+		"*debug.PathOverlay":     {},
+		"*debug.ColliderOverlay": {},
+		"*debug.System":          {},
 	}
 
 	var seenRendering bool
@@ -63,3 +66,61 @@ func containsSystem(systems []ecs.System, typeName string) bool {
 	}
 	return false
 }
+
+func TestPauseFreezesSimulationClock(t *testing.T) {
+	gw := NewGameWorld()
+	paused := false
+	gw.Pause = func() bool { return paused }
+	clock := gw.World.Clock()
+
+	for i := 0; i < 3; i++ {
+		gw.Update()
+	}
+	elapsed, tick := clock.Elapsed(), clock.Tick()
+	if tick == 0 {
+		t.Fatalf("expected the clock to run while unpaused")
+	}
+
+	paused = true
+	for i := 0; i < 10; i++ {
+		gw.Update()
+	}
+	if clock.Elapsed() != elapsed || clock.Tick() != tick {
+		t.Fatalf("expected the clock frozen at %v (tick %d) while paused, got %v (tick %d)", elapsed, tick, clock.Elapsed(), clock.Tick())
+	}
+	if !gw.Paused() {
+		t.Fatalf("expected the world to report the pause")
+	}
+
+	paused = false
+	gw.Update()
+	if clock.Tick() <= tick || clock.Paused {
+		t.Fatalf("expected the clock to resume after the pause, still at tick %d", clock.Tick())
+	}
+}
+
+func TestNewGameWorldSharesStages(t *testing.T) {
+	world := NewGameWorld().World
+
+	stageOf := make(map[string]int)
+	var shared bool
+	for i, stage := range world.Stages() {
+		shared = shared || len(stage) > 1
+		for _, sys := range stage {
+			stageOf[fmt.Sprintf("%T", sys)] = i
+		}
+	}
+	if !shared {
+		t.Fatalf("expected a stage with more than one system, got %q", world.ScheduleSummary())
+	}
+
+	pairs := [][2]string{
+		{"*camera.System", "*hud.System"},
+		{"*background.System", "*render.System"},
+	}
+	for _, p := range pairs {
+		if stageOf[p[0]] != stageOf[p[1]] {
+			t.Errorf("expected %s and %s to share a stage, got %q", p[0], p[1], world.ScheduleSummary())
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/*───────────────────────────────────────────────*
+| TEMPLATE INHERITANCE                          |
+*───────────────────────────────────────────────*/
+
+// Source is one data file's path and contents.
+type Source struct {
+	Path  string
+	Data  []byte
+	Layer string // "" for base data, otherwise the id of the mod pack that supplied it
+}
+
+// ParseActorDatabase decodes an actor database and resolves "extends"
+// chains. A template inherits every field of its base: objects (sprite,
+// velocity, components) are merged key by key, ai_refs are appended after
+// the base's refs, and anything else the child sets replaces the base value.
+func ParseActorDatabase(raw []byte) (ActorDatabase, error) {
+	return ParseActorPacks([]Source{{Data: raw}})
+}
+
+// ParseActorPacks merges several actor files (actors.json plus packs) into
+// one database. Templates may extend templates from any of the files, and a
+// template redeclared in a later layer overrides the earlier one (see
+// overrideEntry).
+func ParseActorPacks(sources []Source) (ActorDatabase, error) {
+	db, _, err := parseActorSources(sources)
+	return db, err
+}
+
+// entryOrigin locates an entry: the source it came from and its index in
+// that file's list.
+type entryOrigin struct {
+	source int
+	index  int
+}
+
+type namedEntry struct {
+	fields map[string]json.RawMessage
+	layer  string
+	origin entryOrigin
+}
+
+// parseActorSources resolves the database and reports, per template, the
+// entry that last defined it.
+func parseActorSources(sources []Source) (ActorDatabase, []entryOrigin, error) {
+	entries, err := collectEntries(sources, "actors")
+	if err != nil {
+		return ActorDatabase{}, nil, err
+	}
+	merged, order, err := layerEntries(entries, "actor template")
+	if err != nil {
+		return ActorDatabase{}, nil, err
+	}
+
+	byName := make(map[string]map[string]json.RawMessage, len(merged))
+	for name, e := range merged {
+		byName[name] = e.fields
+	}
+	resolved := make(map[string]map[string]json.RawMessage, len(byName))
+	db := ActorDatabase{Actors: make([]ActorTemplate, 0, len(order))}
+	origins := make([]entryOrigin, 0, len(order))
+	for _, name := range order {
+		fields, err := resolveActor(name, byName, resolved, nil)
+		if err != nil {
+			return ActorDatabase{}, nil, err
+		}
+		raw, _ := json.Marshal(fields)
+		var tpl ActorTemplate
+		if err := json.Unmarshal(raw, &tpl); err != nil {
+			return ActorDatabase{}, nil, fmt.Errorf("actor %q: %w", name, err)
+		}
+		tpl.Layer = merged[name].layer
+		db.Actors = append(db.Actors, tpl)
+		origins = append(origins, merged[name].origin)
+	}
+	return db, origins, nil
+}
+
+/*───────────────────────────────────────────────*
+| LAYERING                                      |
+*───────────────────────────────────────────────*/
+
+// collectEntries decodes the list under key from every source, in order.
+func collectEntries(sources []Source, key string) ([]namedEntry, error) {
+	var entries []namedEntry
+	for si, src := range sources {
+		var file map[string]json.RawMessage
+		var list []map[string]json.RawMessage
+		err := json.Unmarshal(src.Data, &file)
+		if err == nil && file[key] != nil {
+			err = json.Unmarshal(file[key], &list)
+		}
+		if err != nil {
+			if src.Path != "" {
+				return nil, fmt.Errorf("%s: %w", src.Path, err)
+			}
+			return nil, err
+		}
+		for i, fields := range list {
+			entries = append(entries, namedEntry{fields, src.Layer, entryOrigin{si, i}})
+		}
+	}
+	return entries, nil
+}
+
+// layerEntries folds entries by name. A name repeated within one layer is
+// an error; a later layer overrides the earlier definition in place, so
+// load order decides who wins while declaration order is kept.
+func layerEntries(entries []namedEntry, what string) (map[string]namedEntry, []string, error) {
+	byName := make(map[string]namedEntry, len(entries))
+	order := make([]string, 0, len(entries))
+	for i, entry := range entries {
+		var name string
+		if err := json.Unmarshal(entry.fields["name"], &name); err != nil || name == "" {
+			return nil, nil, fmt.Errorf("%s #%d has no name", what, i)
+		}
+		prev, dup := byName[name]
+		switch {
+		case !dup:
+			order = append(order, name)
+		case prev.layer == entry.layer:
+			return nil, nil, fmt.Errorf("duplicate %s %q", what, name)
+		default:
+			entry.fields = overrideEntry(prev.fields, entry.fields)
+		}
+		byName[name] = entry
+	}
+	return byName, order, nil
+}
+
+// overrideEntry applies a mod's redeclaration of an entry: objects are
+// merged key by key and every other value, lists included, is replaced.
+func overrideEntry(base, override map[string]json.RawMessage) map[string]json.RawMessage {
+	fields := make(map[string]json.RawMessage, len(base)+len(override))
+	for k, v := range base {
+		fields[k] = v
+	}
+	for k, v := range override {
+		fields[k] = mergeJSON(base[k], v)
+	}
+	return fields
+}
+
+// resolveActor returns the fully merged fields of name, memoized in resolved.
+func resolveActor(name string, byName, resolved map[string]map[string]json.RawMessage, chain []string) (map[string]json.RawMessage, error) {
+	if fields, ok := resolved[name]; ok {
+		return fields, nil
+	}
+	for _, seen := range chain {
+		if seen == name {
+			return nil, fmt.Errorf("actor %q: extends cycle %v", name, append(chain, name))
+		}
+	}
+	own, ok := byName[name]
+	if !ok {
+		return nil, fmt.Errorf("actor %q: extends unknown template %q", chain[len(chain)-1], name)
+	}
+
+	var base string
+	if rawBase, ok := own["extends"]; ok {
+		if err := json.Unmarshal(rawBase, &base); err != nil {
+			return nil, fmt.Errorf("actor %q: extends must be a template name", name)
+		}
+	}
+	if base == "" {
+		resolved[name] = own
+		return own, nil
+	}
+
+	parent, err := resolveActor(base, byName, resolved, append(chain, name))
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage, len(parent)+len(own))
+	for k, v := range parent {
+		fields[k] = v
+	}
+	for k, v := range own {
+		switch k {
+		case "ai_refs":
+			fields[k] = appendRefs(parent[k], v)
+		default:
+			fields[k] = mergeJSON(parent[k], v)
+		}
+	}
+	resolved[name] = fields
+	return fields, nil
+}
+
+// mergeJSON deep-merges two JSON objects; for any other value override wins.
+func mergeJSON(base, override json.RawMessage) json.RawMessage {
+	var b, o map[string]json.RawMessage
+	if base == nil || json.Unmarshal(base, &b) != nil || json.Unmarshal(override, &o) != nil || b == nil || o == nil {
+		return override
+	}
+	for k, v := range o {
+		b[k] = mergeJSON(b[k], v)
+	}
+	out, _ := json.Marshal(b)
+	return out
+}
+
+// appendRefs appends the child's refs to the base's, skipping duplicates.
+func appendRefs(base, child json.RawMessage) json.RawMessage {
+	var b, c []string
+	if json.Unmarshal(base, &b) != nil || json.Unmarshal(child, &c) != nil {
+		return child
+	}
+	for _, ref := range c {
+		if !containsString(b, ref) {
+			b = append(b, ref)
+		}
+	}
+	out, _ := json.Marshal(b)
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtendsMergesBaseTemplate(t *testing.T) {
+	db, err := ParseActorDatabase(embeddedActors)
+	if err != nil {
+		t.Fatalf("embedded actors failed to resolve: %v", err)
+	}
+
+	byName := make(map[string]ActorTemplate)
+	for _, tpl := range db.Actors {
+		byName[tpl.Name] = tpl
+	}
+	cmd := byName["dark-elf-ship-commander"]
+	if cmd.Archetype != "enemy" || !cmd.Sprite.PixelPerfect || cmd.Velocity == nil {
+		t.Fatalf("expected inherited archetype, sprite flags and velocity, got %+v", cmd)
+	}
+	if cmd.Sprite.Width != 96 || !strings.HasSuffix(cmd.Sprite.Image, "commander.png") {
+		t.Fatalf("expected sprite override to win, got %+v", cmd.Sprite)
+	}
+	if string(cmd.Components["Health"]) != `{"max":250}` || cmd.Components["Tags"] == nil {
+		t.Fatalf("expected merged components, got %s / %s", cmd.Components["Health"], cmd.Components["Tags"])
+	}
+
+	_, err = ParseActorDatabase([]byte(`{"actors":[{"name":"a","extends":"b"},{"name":"b","extends":"a"}]}`))
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+}
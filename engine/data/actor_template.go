@@ -1,13 +1,47 @@
 package data
 
+import "encoding/json"
+
 // ActorTemplate defines one spawnable actor’s configuration.
 type ActorTemplate struct {
 	Name       string               `json:"name"`
+	Extends    string               `json:"extends,omitempty"` // base template, resolved on load
 	Archetype  string               `json:"archetype"`
 	Persistent bool                 `json:"persistent"`
 	Sprite     ActorSpriteTemplate  `json:"sprite"`
 	Velocity   *ActorVelocityPreset `json:"velocity,omitempty"`
 	AIRefs     []string             `json:"ai_refs,omitempty"` //
+
+	// Components holds extra components by name (Health, PlayerInput,
+	// CameraTarget, Tags, ...), decoded by the spawner's component factories.
+	Components map[string]json.RawMessage `json:"components,omitempty"`
+
+	// Layer names the mod pack that last defined the template ("" for base).
+	Layer string `json:"-"`
+}
+
+// ComponentSpecs returns every component the template declares, keyed by
+// component name: the sprite and velocity blocks as "Sprite" and "Velocity",
+// plus the components map, whose entries win on conflict.
+func (t ActorTemplate) ComponentSpecs() map[string]json.RawMessage {
+	specs := make(map[string]json.RawMessage, len(t.Components)+2)
+	if t.Sprite.Image != "" {
+		specs["Sprite"], _ = json.Marshal(t.Sprite)
+	}
+	if t.Velocity != nil {
+		specs["Velocity"], _ = json.Marshal(t.Velocity)
+	}
+	for name, raw := range t.Components {
+		specs[name] = raw
+	}
+	return specs
+}
+
+// BuiltinComponents lists, sorted, the component names the engine can build
+// from a template. engine/world registers one factory per name; the list
+// lives here so tools can lint actor data without loading the world.
+func BuiltinComponents() []string {
+	return []string{"CameraTarget", "Collider", "Health", "PlayerInput", "Sprite", "Tags", "Velocity"}
 }
 
 // ActorSpriteTemplate defines the sprite for an actor.
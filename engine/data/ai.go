@@ -23,7 +23,7 @@ type ActorAITemplate struct {
 
 // ActorAIFollow defines how an actor maintains position near a moving target.
 type ActorAIFollow struct {
-    Target      string  `json:"target"`        // Target entity (ID, archetype:, or template:)
+    Target      string  `json:"target"`        // Target selector (ID, template, id:, template:, archetype:, tag:, nearest:, weakest:, self.parent)
     OffsetX     float64 `json:"offset_x"`      // Horizontal offset from target
     OffsetY     float64 `json:"offset_y"`      // Vertical offset from target
     MinDistance float64 `json:"min_distance"`  // Stop following if within this distance
@@ -35,7 +35,7 @@ type ActorAIFollow struct {
 
 // ActorAIPursue defines aggressive chase logic toward a target entity.
 type ActorAIPursue struct {
-    Target         string  `json:"target"`          // Target entity ID or query
+    Target         string  `json:"target"`          // Target selector, as for Follow
     EngageDistance float64 `json:"engage_distance"` // Max range to engage pursuit
     Speed          float64 `json:"speed"`           // Optional speed override
 }
@@ -64,7 +64,7 @@ type ActorAITravel struct {
 
 // ActorAIRetreat defines how an actor flees from a target until safe.
 type ActorAIRetreat struct {
-    Target          string  `json:"target"`           // Entity or query to avoid
+    Target          string  `json:"target"`           // Selector of the entity to avoid
     TriggerDistance float64 `json:"trigger_distance"` // Distance that triggers fleeing
     SafeDistance    float64 `json:"safe_distance"`    // Distance that counts as "safe"
     Speed           float64 `json:"speed"`            // Optional speed override
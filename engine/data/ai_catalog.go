@@ -3,9 +3,19 @@ package data
 // AIActionCatalog is the top-level JSON schema for ai.json.
 // It defines reusable named AI behaviors that can be attached to actors.
 type AIActionCatalog struct {
+	Version int                `json:"version,omitempty"` // schema version, see AISchemaVersion
 	Actions []AIActionTemplate `json:"actions"`
 }
 
+// ActionNames lists the names of every action in the catalog.
+func (c AIActionCatalog) ActionNames() []string {
+	names := make([]string, 0, len(c.Actions))
+	for _, a := range c.Actions {
+		names = append(names, a.Name)
+	}
+	return names
+}
+
 // AIActionTemplate defines one reusable AI behavior.
 // It can represent a basic action ("pursue"), a conditional, or a scripted sequence.
 type AIActionTemplate struct {
@@ -4,7 +4,8 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
-	"os"
+
+	"rp-go/engine/vfs"
 )
 
 //go:embed ai.json
@@ -25,7 +26,7 @@ type AIBehaviorTemplate struct {
 
 // LoadAIConfig loads an AI configuration JSON file (with fallback).
 func LoadAIConfig(path string) AIConfigDatabase {
-	data, err := os.ReadFile(path)
+	data, err := vfs.ReadFile(path)
 	if err != nil {
 		fmt.Printf("[DATA] Using embedded ai.json (missing %s)\n", path)
 		data = embeddedAIConfig
@@ -0,0 +1,15 @@
+package data
+
+import (
+	"embed"
+
+	"rp-go/engine/vfs"
+)
+
+// embeddedFiles ships the base data inside the binary; loose files and
+// archives on the search path override it.
+//
+//go:embed *.json
+var embeddedFiles embed.FS
+
+func init() { vfs.MountEmbedded("engine/data", embeddedFiles) }
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+/*───────────────────────────────────────────────*
+| LAYERED AI CATALOG                            |
+*───────────────────────────────────────────────*/
+
+// ParseAILayers merges ai.json layers in load order. An action redeclared by
+// a later layer overrides the earlier one: params and conditions are merged
+// key by key, other fields are replaced.
+// Outdated layers are migrated first.
+func ParseAILayers(sources []Source) (AIActionCatalog, error) {
+	sources, _, err := migrateAISources(sources)
+	if err != nil {
+		return AIActionCatalog{}, err
+	}
+	cat, _, err := parseAISources(sources)
+	return cat, err
+}
+
+func parseAISources(sources []Source) (AIActionCatalog, []entryOrigin, error) {
+	entries, err := collectEntries(sources, "actions")
+	if err != nil {
+		return AIActionCatalog{}, nil, err
+	}
+	merged, order, err := layerEntries(entries, "action")
+	if err != nil {
+		return AIActionCatalog{}, nil, err
+	}
+
+	cat := AIActionCatalog{Version: AISchemaVersion, Actions: make([]AIActionTemplate, 0, len(order))}
+	origins := make([]entryOrigin, 0, len(order))
+	for _, name := range order {
+		raw, _ := json.Marshal(merged[name].fields)
+		var act AIActionTemplate
+		if err := json.Unmarshal(raw, &act); err != nil {
+			return AIActionCatalog{}, nil, fmt.Errorf("action %q: %w", name, err)
+		}
+		cat.Actions = append(cat.Actions, act)
+		origins = append(origins, merged[name].origin)
+	}
+	return cat, origins, nil
+}
@@ -3,7 +3,8 @@ package data
 import (
 	_ "embed"
 	"encoding/json"
-	"os"
+
+	"rp-go/engine/vfs"
 )
 
 //go:embed render_config.json
@@ -39,7 +40,7 @@ type RenderConfig struct {
 
 // LoadRenderConfig reads and parses the JSON config file.
 func LoadRenderConfig(path string) RenderConfig {
-	data, err := os.ReadFile(path)
+	data, err := vfs.ReadFile(path)
 	if err != nil {
 		data = embeddedRenderConfig
 	}
@@ -49,3 +50,14 @@ func LoadRenderConfig(path string) RenderConfig {
 	}
 	return cfg
 }
+
+// ReadRenderConfig loads path and validates it, returning every problem
+// instead of panicking. Used by hot reload.
+func ReadRenderConfig(path string) (RenderConfig, error) {
+	raw, err := vfs.ReadFile(path)
+	if err != nil {
+		return RenderConfig{}, err
+	}
+	cfg, problems := ValidateRenderConfig(path, raw)
+	return cfg, problems.Err()
+}
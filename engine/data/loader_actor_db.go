@@ -2,9 +2,9 @@ package data
 
 import (
 	_ "embed"
-	"encoding/json"
 	"fmt"
-	"os"
+
+	"rp-go/engine/vfs"
 )
 
 //go:embed actors.json
@@ -13,17 +13,28 @@ var embeddedActors []byte
 // LoadActorDatabase loads and parses an actor database JSON file.
 // Falls back to the embedded version if the external file is missing.
 func LoadActorDatabase(path string) ActorDatabase {
-	data, err := os.ReadFile(path)
+	data, err := vfs.ReadFile(path)
 	if err != nil {
 		fmt.Printf("[DATA] Using embedded actor database (missing %s)\n", path)
 		data = embeddedActors
 	}
 
-	var db ActorDatabase
-	if err := json.Unmarshal(data, &db); err != nil {
+	db, err := ParseActorDatabase(data)
+	if err != nil {
 		panic(fmt.Errorf("failed to parse actor database %q: %w", path, err))
 	}
 
 	return db
 }
 
+// ReadActorDatabase loads path and validates it, returning every problem
+// instead of panicking. Used by hot reload.
+func ReadActorDatabase(path string, opts LintOptions) (ActorDatabase, error) {
+	raw, err := vfs.ReadFile(path)
+	if err != nil {
+		return ActorDatabase{}, err
+	}
+	db, problems := ValidateActorDatabase(path, raw, opts)
+	return db, problems.Err()
+}
+
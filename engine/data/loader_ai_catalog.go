@@ -2,9 +2,9 @@ package data
 
 import (
 	_ "embed"
-	"encoding/json"
 	"fmt"
-	"os"
+
+	"rp-go/engine/vfs"
 )
 
 //go:embed ai.json
@@ -12,16 +12,27 @@ var embeddedAI []byte
 
 // LoadAICatalog loads and parses ai.json from disk, or falls back to the embedded version.
 func LoadAICatalog(path string) AIActionCatalog {
-	data, err := os.ReadFile(path)
+	data, err := vfs.ReadFile(path)
 	if err != nil {
 		fmt.Printf("[DATA] Using embedded ai.json (missing %s)\n", path)
 		data = embeddedAI
 	}
-	var catalog AIActionCatalog
-	if err := json.Unmarshal(data, &catalog); err != nil {
+	catalog, err := ParseAILayers([]Source{{Path: path, Data: data}})
+	if err != nil {
 		panic(fmt.Errorf("failed to parse ai.json: %w", err))
 	}
 	fmt.Printf("[DATA] Loaded %d AI actions from %s\n", len(catalog.Actions), path)
 	return catalog
 }
 
+// ReadAICatalog loads path and validates it, returning every problem
+// instead of panicking. Used by hot reload.
+func ReadAICatalog(path string, opts LintOptions) (AIActionCatalog, error) {
+	raw, err := vfs.ReadFile(path)
+	if err != nil {
+		return AIActionCatalog{}, err
+	}
+	catalog, problems := ValidateAICatalog(path, raw, opts)
+	return catalog, problems.Err()
+}
+
@@ -0,0 +1,113 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+/*───────────────────────────────────────────────*
+| AI.JSON SCHEMA MIGRATIONS                     |
+*───────────────────────────────────────────────*/
+
+// AISchemaVersion is the ai.json format this build reads natively. Files
+// without a "version" field are version 1.
+const AISchemaVersion = 2
+
+// aiMigrations[n] upgrades a version n document to version n+1 in place,
+// reporting whether anything changed.
+var aiMigrations = map[int]func(doc map[string]any) (bool, error){
+	1: migrateAIv1,
+}
+
+// MigrateAICatalog upgrades raw to AISchemaVersion. It returns raw untouched
+// (and migrated false) when no migration had anything to change, so
+// problems in such files keep their line numbers.
+func MigrateAICatalog(raw []byte) (out []byte, from int, migrated bool, err error) {
+	var doc map[string]any
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return raw, 0, false, err
+	}
+	from = 1
+	if v, ok := doc["version"].(float64); ok {
+		if v != math.Trunc(v) {
+			return raw, 0, false, fmt.Errorf("invalid ai.json version %v", v)
+		}
+		from = int(v)
+	}
+	switch {
+	case from == AISchemaVersion:
+		return raw, from, false, nil
+	case from > AISchemaVersion:
+		return raw, from, false, fmt.Errorf("ai.json version %d is newer than supported version %d", from, AISchemaVersion)
+	case from < 1:
+		return raw, from, false, fmt.Errorf("invalid ai.json version %d", from)
+	}
+
+	changed := false
+	for v := from; v < AISchemaVersion; v++ {
+		ok, err := aiMigrations[v](doc)
+		if err != nil {
+			return raw, from, false, fmt.Errorf("migrating ai.json v%d to v%d: %w", v, v+1, err)
+		}
+		changed = changed || ok
+	}
+	if !changed {
+		return raw, from, false, nil
+	}
+	doc["version"] = AISchemaVersion
+	out, err = json.MarshalIndent(doc, "", "  ")
+	return out, from, true, err
+}
+
+// migrateAISources migrates every outdated layer, logging each upgrade.
+func migrateAISources(sources []Source) ([]Source, map[string]bool, error) {
+	out := make([]Source, len(sources))
+	migrated := make(map[string]bool)
+	for i, src := range sources {
+		raw, from, ok, err := MigrateAICatalog(src.Data)
+		var syntax *json.SyntaxError
+		var typ *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntax), errors.As(err, &typ):
+			out[i] = src // left for the validator to pinpoint
+			continue
+		case err != nil:
+			return nil, nil, fmt.Errorf("%s: %w", src.Path, err)
+		}
+		out[i] = src
+		if ok {
+			out[i].Data = raw
+			migrated[src.Path] = true
+			fmt.Printf("[DATA] Migrated %s from v%d to v%d\n", src.Path, from, AISchemaVersion)
+		}
+	}
+	return out, migrated, nil
+}
+
+// migrateAIv1 replaces script steps' integer "delay_ms" with a "delay"
+// duration string ("500ms").
+func migrateAIv1(doc map[string]any) (bool, error) {
+	changed := false
+	actions, _ := doc["actions"].([]any)
+	for _, a := range actions {
+		act, _ := a.(map[string]any)
+		params, _ := act["params"].(map[string]any)
+		steps, _ := params["steps"].([]any)
+		for _, s := range steps {
+			step, _ := s.(map[string]any)
+			ms, ok := step["delay_ms"].(float64)
+			if !ok {
+				continue
+			}
+			delete(step, "delay_ms")
+			changed = true
+			if ms > 0 {
+				step["delay"] = time.Duration(ms * float64(time.Millisecond)).String()
+			}
+		}
+	}
+	return changed, nil
+}
@@ -0,0 +1,83 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMigrateAICatalogUpgradesScriptDelays(t *testing.T) {
+	v1 := []byte(`{
+  "actions": [
+    { "name": "combo", "type": "script", "params": { "steps": [
+      { "action": "chase", "delay_ms": 0 },
+      { "action": "flee", "delay_ms": 1500 },
+      { "action": "chase", "delay_ms": 2.5 }
+    ] } }
+  ]
+}`)
+	out, from, migrated, err := MigrateAICatalog(v1)
+	if err != nil || !migrated || from != 1 {
+		t.Fatalf("expected v1 migration, got from=%d migrated=%v err=%v", from, migrated, err)
+	}
+	var cat AIActionCatalog
+	if err := json.Unmarshal(out, &cat); err != nil {
+		t.Fatal(err)
+	}
+	if cat.Version != AISchemaVersion {
+		t.Fatalf("expected version %d, got %d", AISchemaVersion, cat.Version)
+	}
+	steps := cat.Actions[0].Params["steps"].([]any)
+	first, second, third := steps[0].(map[string]any), steps[1].(map[string]any), steps[2].(map[string]any)
+	if _, ok := first["delay"]; ok {
+		t.Fatalf("zero delay should be dropped, got %v", first)
+	}
+	if second["delay"] != "1.5s" || second["delay_ms"] != nil {
+		t.Fatalf("expected delay 1.5s, got %v", second)
+	}
+	if third["delay"] != "2.5ms" {
+		t.Fatalf("expected fractional delay 2.5ms, got %v", third)
+	}
+
+	if _, _, migrated, _ := MigrateAICatalog(out); migrated {
+		t.Fatal("current version migrated again")
+	}
+	if _, _, _, err := MigrateAICatalog([]byte(`{"version": 99}`)); err == nil {
+		t.Fatal("expected error for a newer schema version")
+	}
+	if _, _, _, err := MigrateAICatalog([]byte(`{"version": 1.5}`)); err == nil {
+		t.Fatal("expected error for a non-integral schema version")
+	}
+}
+
+func TestValidationReportsParamErrorsOnTheKey(t *testing.T) {
+	ai := []byte(`{
+  "version": 2,
+  "actions": [
+    { "name": "chase", "type": "pursue", "params": { "engage_dist": 200 } },
+    { "name": "combo", "type": "script", "params": { "steps": [ { "action": "chase", "params": { "speed": "fast" } } ] } }
+  ]
+}`)
+	opts := LintOptions{
+		BehaviorTypes: []string{"pursue", "script"},
+		CheckParams: func(typ string, params map[string]any) error {
+			if _, ok := params["engage_dist"]; ok {
+				return &ParamError{Key: "engage_dist", Message: "unknown key"}
+			}
+			if _, ok := params["speed"].(string); ok && typ == "pursue" {
+				return &ParamError{Key: "speed", Message: "expected float64, got string"}
+			}
+			return nil
+		},
+	}
+	_, problems := ValidateAICatalog("ai.json", ai, opts)
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got:\n%v", problems)
+	}
+	if p := problems[0]; p.Path != "actions[0].params.engage_dist" || p.Line != 4 {
+		t.Fatalf("expected unknown key on line 4, got %v", p)
+	}
+	if p := problems[1]; p.Path != "actions[1].params.steps[0].params.speed" || !strings.Contains(p.Message, "string") {
+		t.Fatalf("expected bad step param, got %v", p)
+	}
+}
@@ -0,0 +1,131 @@
+package data
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+/*───────────────────────────────────────────────*
+| STRING TABLES                                 |
+*───────────────────────────────────────────────*/
+
+// StringsPattern matches the per-locale UI string tables, e.g.
+// strings_en.json. The locale code is taken from the file name.
+const StringsPattern = "strings_*.json"
+
+// StringTable holds one locale's UI text.
+type StringTable struct {
+	Locale  string // locale code, e.g. "en" or "pt_BR"
+	Name    string // display name, e.g. "English"
+	Entries map[string]LocalizedString
+}
+
+// LocalizedString is either a plain text or a set of plural forms keyed by
+// CLDR category ("zero", "one", "two", "few", "many", "other").
+type LocalizedString struct {
+	Text  string
+	Forms map[string]string
+}
+
+// stringsFile is the on-disk schema of strings_<locale>.json. Each entry is
+// a string or an object of plural forms:
+//
+//	{
+//	  "name": "English",
+//	  "strings": {
+//	    "menu.title": "R P G   P R O J E C T",
+//	    "hud.contacts": { "one": "{n} contact", "other": "{n} contacts" }
+//	  }
+//	}
+type stringsFile struct {
+	Name    string                     `json:"name,omitempty"`
+	Strings map[string]json.RawMessage `json:"strings"`
+}
+
+// pluralForms are the categories a plural entry may define.
+var pluralForms = map[string]bool{"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true}
+
+// LocaleOf extracts the locale code from a string table path.
+func LocaleOf(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), ".json")
+	return strings.TrimPrefix(base, "strings_")
+}
+
+// ValidateStringLayers parses string tables strictly and groups them by
+// locale. A key redefined by a later layer (a mod's strings_en.json)
+// replaces the earlier text.
+func ValidateStringLayers(sources []Source) (map[string]StringTable, Problems) {
+	tables := make(map[string]StringTable)
+	var checks []*fileCheck
+	for _, src := range sources {
+		c := newFileCheck(src.Path, src.Data)
+		checks = append(checks, c)
+
+		code := LocaleOf(src.Path)
+		if code == "" {
+			c.add("", "file name has no locale code (expected strings_<locale>.json)")
+			continue
+		}
+		var file stringsFile
+		if !c.decode(&file) {
+			continue
+		}
+
+		table, ok := tables[code]
+		if !ok {
+			table = StringTable{Locale: code, Entries: make(map[string]LocalizedString)}
+		}
+		if file.Name != "" {
+			table.Name = file.Name
+		}
+		keys := make([]string, 0, len(file.Strings))
+		for key := range file.Strings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if entry, ok := c.checkString(joinPath("strings", key), file.Strings[key]); ok {
+				table.Entries[key] = entry
+			}
+		}
+		tables[code] = table
+	}
+
+	problems := collectProblems(checks)
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return tables, nil
+}
+
+// checkString decodes one entry: a string, or plural forms with "other".
+func (c *fileCheck) checkString(path string, raw json.RawMessage) (LocalizedString, bool) {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return LocalizedString{Text: text}, true
+	}
+	var forms map[string]string
+	if err := json.Unmarshal(raw, &forms); err != nil {
+		c.add(path, "expected a string or an object of plural forms")
+		return LocalizedString{}, false
+	}
+	names := make([]string, 0, len(forms))
+	for form := range forms {
+		names = append(names, form)
+	}
+	sort.Strings(names)
+	ok := true
+	for _, form := range names {
+		if !pluralForms[form] {
+			c.add(joinPath(path, form), "unknown plural form %q (want zero, one, two, few, many or other)", form)
+			ok = false
+		}
+	}
+	if _, has := forms["other"]; !has {
+		c.add(path, "plural forms need an \"other\" form")
+		ok = false
+	}
+	return LocalizedString{Forms: forms}, ok
+}
@@ -0,0 +1,190 @@
+package data
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+/*───────────────────────────────────────────────*
+| TERRAIN MAPS                                  |
+*───────────────────────────────────────────────*/
+
+// TerrainPattern matches the tile maps scenes walk on, e.g.
+// terrain_planet.json. The map name is taken from the file name.
+const TerrainPattern = "terrain_*.json"
+
+// Terrain is a rectangular tile map. Tiles are laid out row by row from
+// the origin, TileSize world units apart.
+type Terrain struct {
+	Name             string
+	TileSize         float64
+	OriginX, OriginY float64
+	Cols, Rows       int
+	Tiles            map[string]TerrainTile
+
+	cells []string // tile name per cell, row-major
+}
+
+// TerrainTile describes one kind of tile.
+type TerrainTile struct {
+	Image   string  `json:"image,omitempty"`
+	Cost    float64 `json:"cost,omitempty"`    // movement cost, 1 when unset
+	Blocked bool    `json:"blocked,omitempty"` // impassable (water, mountains)
+}
+
+// Tile returns the tile at column c, row r.
+func (t Terrain) Tile(c, r int) (string, TerrainTile, bool) {
+	if c < 0 || r < 0 || c >= t.Cols || r >= t.Rows {
+		return "", TerrainTile{}, false
+	}
+	name := t.cells[r*t.Cols+c]
+	return name, t.Tiles[name], true
+}
+
+// terrainFile is the on-disk schema of terrain_<name>.json. Each row is a
+// string of legend symbols, one per tile:
+//
+//	{
+//	  "tile_size": 32,
+//	  "origin": { "x": 0, "y": 0 },
+//	  "tiles": {
+//	    "grass": { "image": "assets/tiles/grass.png" },
+//	    "water": { "image": "assets/tiles/water.png", "blocked": true }
+//	  },
+//	  "legend": { ".": "grass", "~": "water" },
+//	  "rows": [ "..~~..", "......" ]
+//	}
+type terrainFile struct {
+	TileSize float64                `json:"tile_size"`
+	Origin   terrainOrigin          `json:"origin"`
+	Tiles    map[string]TerrainTile `json:"tiles"`
+	Legend   map[string]string      `json:"legend"`
+	Rows     []string               `json:"rows"`
+}
+
+type terrainOrigin struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// TerrainNameOf extracts the map name from a terrain file path.
+func TerrainNameOf(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), ".json")
+	return strings.TrimPrefix(base, "terrain_")
+}
+
+// ValidateTerrainLayers parses terrain maps strictly and keys them by name.
+// A map redefined by a later layer (a mod's terrain_planet.json) replaces
+// the earlier one as a whole.
+func ValidateTerrainLayers(sources []Source) (map[string]Terrain, Problems) {
+	maps := make(map[string]Terrain)
+	var checks []*fileCheck
+	for _, src := range sources {
+		c := newFileCheck(src.Path, src.Data)
+		checks = append(checks, c)
+
+		name := TerrainNameOf(src.Path)
+		if name == "" {
+			c.add("", "file name has no map name (expected terrain_<name>.json)")
+			continue
+		}
+		var file terrainFile
+		if !c.decode(&file) {
+			continue
+		}
+		if t, ok := c.checkTerrain(name, file); ok {
+			maps[name] = t
+		}
+	}
+
+	problems := collectProblems(checks)
+	if len(problems) > 0 {
+		return nil, problems
+	}
+	return maps, nil
+}
+
+// checkTerrain validates the tiles, legend and rows of one map.
+func (c *fileCheck) checkTerrain(name string, file terrainFile) (Terrain, bool) {
+	ok := true
+	if file.TileSize <= 0 {
+		c.add("tile_size", "tile_size must be positive")
+		ok = false
+	}
+
+	tiles := make(map[string]TerrainTile, len(file.Tiles))
+	for _, tile := range sortedKeys(file.Tiles) {
+		def := file.Tiles[tile]
+		switch {
+		case def.Cost == 0:
+			def.Cost = 1
+		case def.Cost < 1:
+			c.add(joinPath(joinPath("tiles", tile), "cost"), "cost must be at least 1")
+			ok = false
+		}
+		tiles[tile] = def
+	}
+
+	legend := make(map[rune]string, len(file.Legend))
+	for _, symbol := range sortedKeys(file.Legend) {
+		tile := file.Legend[symbol]
+		path := joinPath("legend", symbol)
+		_, known := tiles[tile]
+		switch {
+		case utf8.RuneCountInString(symbol) != 1:
+			c.add(path, "legend symbols must be a single character")
+			ok = false
+		case !known:
+			c.add(path, "unknown tile %q", tile)
+			ok = false
+		default:
+			r, _ := utf8.DecodeRuneInString(symbol)
+			legend[r] = tile
+		}
+	}
+
+	if len(file.Rows) == 0 {
+		c.add("rows", "terrain needs at least one row")
+		return Terrain{}, false
+	}
+	t := Terrain{
+		Name:     name,
+		TileSize: file.TileSize,
+		OriginX:  file.Origin.X,
+		OriginY:  file.Origin.Y,
+		Cols:     utf8.RuneCountInString(file.Rows[0]),
+		Rows:     len(file.Rows),
+		Tiles:    tiles,
+	}
+	t.cells = make([]string, 0, t.Cols*t.Rows)
+	for i, row := range file.Rows {
+		path := fmt.Sprintf("rows[%d]", i)
+		if n := utf8.RuneCountInString(row); n != t.Cols {
+			c.add(path, "row has %d tiles, want %d like the first row", n, t.Cols)
+			ok = false
+			continue
+		}
+		for col, r := range []rune(row) {
+			tile, known := legend[r]
+			if !known {
+				c.add(path, "symbol %q at column %d is not in the legend", r, col)
+				ok = false
+				break
+			}
+			t.cells = append(t.cells, tile)
+		}
+	}
+	return t, ok
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,688 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+/*───────────────────────────────────────────────*
+| PROBLEM REPORTING                             |
+*───────────────────────────────────────────────*/
+
+// Problem is one validation finding with its location in the source file.
+type Problem struct {
+	File    string
+	Line    int    // 1-based; 0 when unknown
+	Column  int    // 1-based; 0 when unknown
+	Path    string // JSON path, e.g. actors[2].ai_refs[0]
+	Message string
+}
+
+func (p Problem) String() string {
+	var b strings.Builder
+	b.WriteString(p.File)
+	if p.Line > 0 {
+		fmt.Fprintf(&b, ":%d:%d", p.Line, p.Column)
+	}
+	if p.Path != "" {
+		b.WriteString(": " + p.Path)
+	}
+	b.WriteString(": " + p.Message)
+	return b.String()
+}
+
+// Problems collects every finding of a validation pass. It implements error
+// so loaders can return it directly.
+type Problems []Problem
+
+func (ps Problems) Error() string {
+	lines := make([]string, len(ps))
+	for i, p := range ps {
+		lines[i] = p.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Err returns ps as an error, or nil when there are no problems.
+func (ps Problems) Err() error {
+	if len(ps) == 0 {
+		return nil
+	}
+	return ps
+}
+
+// LintOptions supplies the registries data files are cross-checked against.
+// Nil lists skip the corresponding check.
+type LintOptions struct {
+	BehaviorTypes []string // registered AI behavior types (ai.GlobalBehaviorCatalog)
+	Actions       []string // action names defined in ai.json
+	Components    []string // template component factories (world.ComponentRegistry)
+
+	// CheckParams decodes an action's params for its behavior type and
+	// reports unknown keys or bad values (ai.BehaviorCatalog.CheckParams).
+	CheckParams func(behaviorType string, params map[string]any) error
+}
+
+// ParamError pins a params problem to one key.
+type ParamError struct {
+	Key     string
+	Message string
+}
+
+func (e *ParamError) Error() string { return fmt.Sprintf("param %q: %s", e.Key, e.Message) }
+
+/*───────────────────────────────────────────────*
+| FILE VALIDATORS                               |
+*───────────────────────────────────────────────*/
+
+// ValidateRenderConfig parses render_config.json strictly.
+func ValidateRenderConfig(file string, raw []byte) (RenderConfig, Problems) {
+	return ValidateRenderLayers([]Source{{Path: file, Data: raw}})
+}
+
+// ValidateRenderLayers checks every layer strictly, then deep-merges them in
+// order and checks the result. Problems of the merged config are reported
+// against the topmost layer.
+func ValidateRenderLayers(sources []Source) (RenderConfig, Problems) {
+	var (
+		cfg    RenderConfig
+		merged json.RawMessage
+		all    Problems
+		c      *fileCheck
+	)
+	for _, src := range sources {
+		c = newFileCheck(src.Path, src.Data)
+		if !c.decode(&RenderConfig{}) {
+			all = append(all, c.problems...)
+			c = nil
+			continue
+		}
+		all = append(all, c.problems...)
+		merged = mergeJSON(merged, src.Data)
+	}
+	if c == nil || len(all) > 0 {
+		return cfg, all
+	}
+	_ = json.Unmarshal(merged, &cfg)
+	if cfg.Window.Width <= 0 || cfg.Window.Height <= 0 {
+		c.add("window", "width and height must be positive")
+	}
+	if cfg.Viewport.MinScale > 0 && cfg.Viewport.MaxScale > 0 && cfg.Viewport.MinScale > cfg.Viewport.MaxScale {
+		c.add("viewport.min_scale", "min_scale %.2f exceeds max_scale %.2f", cfg.Viewport.MinScale, cfg.Viewport.MaxScale)
+	}
+	return cfg, c.problems
+}
+
+// ValidateAICatalog parses ai.json strictly and checks behavior types and
+// script step references.
+func ValidateAICatalog(file string, raw []byte, opts LintOptions) (AIActionCatalog, Problems) {
+	return ValidateAILayers([]Source{{Path: file, Data: raw}}, opts)
+}
+
+// ValidateAILayers validates several ai.json layers as one catalog. Actions
+// redeclared by a later layer override the earlier definition; problems are
+// reported where the action was last defined.
+func ValidateAILayers(sources []Source, opts LintOptions) (AIActionCatalog, Problems) {
+	sources, migrated, err := migrateAISources(sources)
+	if err != nil {
+		return AIActionCatalog{}, Problems{{Message: err.Error()}}
+	}
+	cat, problems := validateAILayers(sources, opts)
+	// Migrated files were re-encoded, so their offsets no longer match the
+	// file on disk; keep the JSON path only.
+	for i := range problems {
+		if migrated[problems[i].File] {
+			problems[i].Line, problems[i].Column = 0, 0
+		}
+	}
+	return cat, problems
+}
+
+func validateAILayers(sources []Source, opts LintOptions) (AIActionCatalog, Problems) {
+	var (
+		checks []*fileCheck
+		valid  = true
+	)
+	for _, src := range sources {
+		c := newFileCheck(src.Path, src.Data)
+		checks = append(checks, c)
+		var file AIActionCatalog
+		if !c.decode(&file) {
+			valid = false
+			continue
+		}
+		names := make(map[string]bool, len(file.Actions))
+		for i, act := range file.Actions {
+			path := fmt.Sprintf("actions[%d]", i)
+			switch {
+			case act.Name == "":
+				c.add(path, "action has no name")
+				valid = false
+			case names[act.Name]:
+				c.add(path+".name", "duplicate action %q", act.Name)
+				valid = false
+			}
+			names[act.Name] = true
+		}
+	}
+	if !valid || len(checks) == 0 {
+		return AIActionCatalog{}, collectProblems(checks)
+	}
+
+	cat, origins, err := parseAISources(sources)
+	if err != nil {
+		checks[0].add("actions", "%v", err)
+		return AIActionCatalog{}, collectProblems(checks)
+	}
+
+	typeOf := make(map[string]string, len(cat.Actions))
+	for _, act := range cat.Actions {
+		typeOf[act.Name] = act.Type
+	}
+	types := toSet(opts.BehaviorTypes)
+	for i, act := range cat.Actions {
+		c := checks[origins[i].source]
+		path := fmt.Sprintf("actions[%d]", origins[i].index)
+		if types != nil && !types[act.Type] {
+			c.add(path+".type", "unknown behavior type %q (registered: %s)", act.Type, strings.Join(opts.BehaviorTypes, ", "))
+			continue
+		}
+		c.checkParams(path+".params", act.Type, act.Params, opts)
+		switch act.Type {
+		case "script":
+			c.checkScript(path+".params.steps", act, typeOf, types, opts)
+		case "tree":
+			root, _ := act.Params["root"].(map[string]any)
+			c.checkTree(path+".params.root", act.Name, root, typeOf, types, opts)
+		case "fsm":
+			states, _ := act.Params["states"].(map[string]any)
+			c.checkMachine(path+".params.states", act.Name, states, typeOf, types, opts)
+		}
+	}
+	return cat, collectProblems(checks)
+}
+
+// ValidateActorDatabase parses actors.json strictly, resolves inheritance and
+// checks ai_refs and component names.
+func ValidateActorDatabase(file string, raw []byte, opts LintOptions) (ActorDatabase, Problems) {
+	return ValidateActorPacks([]Source{{Path: file, Data: raw}}, opts)
+}
+
+// ValidateActorPacks validates several actor files as one database, so
+// templates may extend templates declared in another pack.
+func ValidateActorPacks(sources []Source, opts LintOptions) (ActorDatabase, Problems) {
+	var (
+		checks []*fileCheck
+		valid  = true
+	)
+	for _, src := range sources {
+		c := newFileCheck(src.Path, src.Data)
+		checks = append(checks, c)
+		if !c.decode(&ActorDatabase{}) {
+			valid = false
+		}
+	}
+	if !valid || len(checks) == 0 {
+		return ActorDatabase{}, collectProblems(checks)
+	}
+
+	db, origins, err := parseActorSources(sources)
+	if err != nil {
+		checks[0].add("actors", "%v", err)
+		return ActorDatabase{}, collectProblems(checks)
+	}
+
+	actions := toSet(opts.Actions)
+	components := toSet(opts.Components)
+	for i, tpl := range db.Actors {
+		c := checks[origins[i].source]
+		path := fmt.Sprintf("actors[%d]", origins[i].index)
+		for j, ref := range tpl.AIRefs {
+			if actions != nil && !actions[ref] {
+				c.add(fmt.Sprintf("%s.ai_refs[%d]", path, j), "ai_ref %q is not an action in ai.json", ref)
+			}
+		}
+		for name := range tpl.Components {
+			if components != nil && !components[name] {
+				c.add(path+".components."+name, "unknown component %q", name)
+			}
+		}
+	}
+	return db, collectProblems(checks)
+}
+
+// ActorPackPattern matches extra actor files merged into actors.json.
+const ActorPackPattern = "actors_*.json"
+
+// Lint validates the render config, AI catalog, actor database (with any
+// actor packs) and string tables in dir, cross-checking ai_refs against
+// the catalog.
+func Lint(dir string, opts LintOptions) Problems {
+	return LintLayers(DirReader(dir), opts)
+}
+
+// SourceReader returns the data files matching patterns, base layer first
+// (e.g. mods.Set.Sources).
+type SourceReader func(patterns ...string) ([]Source, error)
+
+// DirReader reads matching files from dir alone.
+func DirReader(dir string) SourceReader {
+	return func(patterns ...string) ([]Source, error) {
+		var out []Source
+		seen := make(map[string]bool)
+		for _, pattern := range patterns {
+			matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+			for _, path := range matches {
+				if seen[path] {
+					continue
+				}
+				seen[path] = true
+				raw, err := os.ReadFile(path)
+				if err != nil {
+					return nil, err
+				}
+				out = append(out, Source{Path: path, Data: raw})
+			}
+		}
+		return out, nil
+	}
+}
+
+// LintLayers is Lint over every layer read returns, validating each data
+// kind as the merged result the game would load.
+func LintLayers(read SourceReader, opts LintOptions) Problems {
+	var all Problems
+	load := func(patterns ...string) []Source {
+		sources, err := read(patterns...)
+		switch {
+		case err != nil:
+			all = append(all, Problem{Message: err.Error()})
+		case len(sources) == 0:
+			all = append(all, Problem{File: patterns[0], Message: "file not found"})
+		}
+		return sources
+	}
+
+	if sources := load("render_config.json"); len(sources) > 0 {
+		_, ps := ValidateRenderLayers(sources)
+		all = append(all, ps...)
+	}
+	if sources := load("ai.json"); len(sources) > 0 {
+		cat, ps := ValidateAILayers(sources, opts)
+		all = append(all, ps...)
+		if opts.Actions == nil && len(cat.Actions) > 0 {
+			opts.Actions = cat.ActionNames()
+		}
+	}
+	if sources := load("actors.json", ActorPackPattern); len(sources) > 0 {
+		_, ps := ValidateActorPacks(sources, opts)
+		all = append(all, ps...)
+	}
+	// String tables are optional; lookups fall back to the key.
+	if sources, err := read(StringsPattern); err != nil {
+		all = append(all, Problem{Message: err.Error()})
+	} else if len(sources) > 0 {
+		_, ps := ValidateStringLayers(sources)
+		all = append(all, ps...)
+	}
+	// Terrain maps are optional too; scenes without one move freely.
+	if sources, err := read(TerrainPattern); err != nil {
+		all = append(all, Problem{Message: err.Error()})
+	} else if len(sources) > 0 {
+		_, ps := ValidateTerrainLayers(sources)
+		all = append(all, ps...)
+	}
+	return all
+}
+
+// checkScript checks each step's action reference and, once the step's
+// behavior type is known, its param overrides.
+func (c *fileCheck) checkScript(path string, act AIActionTemplate, typeOf map[string]string, types map[string]bool, opts LintOptions) {
+	raw, ok := act.Params["steps"].([]any)
+	if !ok || len(raw) == 0 {
+		c.add(path, "script needs a non-empty steps list")
+		return
+	}
+	for i, step := range raw {
+		stepPath := fmt.Sprintf("%s[%d]", path, i)
+		fields, _ := step.(map[string]any)
+		name, _ := fields["action"].(string)
+		typ, isAction := typeOf[name]
+		if !isAction {
+			typ = name // steps may name a behavior type directly
+		}
+		switch {
+		case name == "":
+			c.add(stepPath, "script step has no action")
+		case name == act.Name:
+			c.add(stepPath+".action", "script step runs its own script")
+		case !isAction && types != nil && !types[name]:
+			c.add(stepPath+".action", "script step references missing action %q", name)
+		case isAction || types != nil:
+			params, _ := fields["params"].(map[string]any)
+			c.checkParams(stepPath+".params", typ, params, opts)
+		}
+	}
+}
+
+// checkTree checks the action leaves of a behavior tree the way checkScript
+// checks steps. The tree's shape is left to opts.CheckParams.
+func (c *fileCheck) checkTree(path, self string, node map[string]any, typeOf map[string]string, types map[string]bool, opts LintOptions) {
+	if node == nil {
+		return
+	}
+	if node["type"] == "action" {
+		c.checkActionRef(path, self, "tree", node, typeOf, types, opts)
+	}
+	child, _ := node["child"].(map[string]any)
+	c.checkTree(path+".child", self, child, typeOf, types, opts)
+	children, _ := node["children"].([]any)
+	for i, ch := range children {
+		m, _ := ch.(map[string]any)
+		c.checkTree(fmt.Sprintf("%s.children[%d]", path, i), self, m, typeOf, types, opts)
+	}
+}
+
+// checkMachine checks the actions and enter/exit hooks of each state of a
+// state machine. Transitions are left to opts.CheckParams.
+func (c *fileCheck) checkMachine(path, self string, states map[string]any, typeOf map[string]string, types map[string]bool, opts LintOptions) {
+	names := make([]string, 0, len(states))
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		state, _ := states[name].(map[string]any)
+		if state == nil {
+			continue
+		}
+		statePath := path + "." + name
+		c.checkActionRef(statePath, self, "state", state, typeOf, types, opts)
+		for _, hook := range []string{"on_enter", "on_exit"} {
+			steps, _ := state[hook].([]any)
+			for i, step := range steps {
+				fields, _ := step.(map[string]any)
+				c.checkActionRef(fmt.Sprintf("%s.%s[%d]", statePath, hook, i), self, "hook", fields, typeOf, types, opts)
+			}
+		}
+	}
+}
+
+// checkActionRef checks the "action" field of a tree leaf, state or hook
+// and its param overrides. Empty names are left to opts.CheckParams.
+func (c *fileCheck) checkActionRef(path, self, what string, fields map[string]any, typeOf map[string]string, types map[string]bool, opts LintOptions) {
+	name, _ := fields["action"].(string)
+	typ, isAction := typeOf[name]
+	if !isAction {
+		typ = name // may name a behavior type directly
+	}
+	switch {
+	case name == "":
+	case name == self:
+		c.add(path+".action", "%s runs its own action %q", what, name)
+	case !isAction && types != nil && !types[name]:
+		c.add(path+".action", "%s references missing action %q", what, name)
+	case isAction || types != nil:
+		params, _ := fields["params"].(map[string]any)
+		c.checkParams(path+".params", typ, params, opts)
+	}
+}
+
+// checkParams runs opts.CheckParams, placing key errors on the key.
+func (c *fileCheck) checkParams(path, behaviorType string, params map[string]any, opts LintOptions) {
+	if opts.CheckParams == nil {
+		return
+	}
+	err := opts.CheckParams(behaviorType, params)
+	if err == nil {
+		return
+	}
+	var pe *ParamError
+	if errors.As(err, &pe) {
+		c.add(path+"."+pe.Key, "%s", pe)
+		return
+	}
+	c.add(path, "%v", err)
+}
+
+/*───────────────────────────────────────────────*
+| STRICT DECODING                               |
+*───────────────────────────────────────────────*/
+
+// fileCheck accumulates problems for one file and resolves JSON paths to
+// line and column.
+type fileCheck struct {
+	file     string
+	raw      []byte
+	offsets  map[string]int64
+	problems Problems
+}
+
+func newFileCheck(file string, raw []byte) *fileCheck {
+	return &fileCheck{file: file, raw: raw}
+}
+
+func (c *fileCheck) add(path, format string, args ...any) {
+	if c.offsets == nil {
+		c.offsets = indexJSON(c.raw)
+	}
+	p := Problem{File: c.file, Path: path, Message: fmt.Sprintf(format, args...)}
+	for lookup := path; ; lookup = parentPath(lookup) {
+		if off, ok := c.offsets[lookup]; ok {
+			p.Line, p.Column = lineCol(c.raw, off)
+			break
+		}
+		if lookup == "" {
+			break
+		}
+	}
+	c.problems = append(c.problems, p)
+}
+
+func (c *fileCheck) addAt(offset int64, format string, args ...any) {
+	p := Problem{File: c.file, Message: fmt.Sprintf(format, args...)}
+	p.Line, p.Column = lineCol(c.raw, offset)
+	c.problems = append(c.problems, p)
+}
+
+// decode reports syntax errors, type mismatches and unknown fields. It
+// returns false when target could not be filled.
+func (c *fileCheck) decode(target any) bool {
+	var generic any
+	if err := json.Unmarshal(c.raw, &generic); err != nil {
+		var syn *json.SyntaxError
+		if errors.As(err, &syn) {
+			c.addAt(syn.Offset, "syntax error: %v", syn)
+		} else {
+			c.add("", "%v", err)
+		}
+		return false
+	}
+	ok := true
+	if err := json.Unmarshal(c.raw, target); err != nil {
+		var typ *json.UnmarshalTypeError
+		if errors.As(err, &typ) {
+			c.addAt(typ.Offset, "%s: expected %s, got %s", typ.Field, typ.Type, typ.Value)
+		} else {
+			c.add("", "%v", err)
+		}
+		ok = false
+	}
+	c.unknownFields(generic, reflect.TypeOf(target), "")
+	return ok
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
+// unknownFields walks a generic JSON value alongside the Go type it decodes
+// into and reports object keys that no struct field accepts.
+func (c *fileCheck) unknownFields(v any, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == rawMessageType {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			field, ok := jsonField(t, key)
+			if !ok {
+				c.add(joinPath(path, key), "unknown field %q", key)
+				continue
+			}
+			c.unknownFields(obj[key], field.Type, joinPath(path, key))
+		}
+	case reflect.Slice, reflect.Array:
+		if list, ok := v.([]any); ok {
+			for i, elem := range list {
+				c.unknownFields(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case reflect.Map:
+		if obj, ok := v.(map[string]any); ok {
+			for key, elem := range obj {
+				c.unknownFields(elem, t.Elem(), joinPath(path, key))
+			}
+		}
+	}
+}
+
+// jsonField finds the struct field encoding/json would decode key into.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	var fold reflect.StructField
+	found := false
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		if name == key {
+			return f, true
+		}
+		if !found && strings.EqualFold(name, key) {
+			fold, found = f, true
+		}
+	}
+	return fold, found
+}
+
+/*───────────────────────────────────────────────*
+| SOURCE POSITIONS                              |
+*───────────────────────────────────────────────*/
+
+// indexJSON maps every JSON path in raw to the byte offset just before its
+// key (object members) or value (array elements).
+func indexJSON(raw []byte) map[string]int64 {
+	offsets := make(map[string]int64)
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	var walk func(path string, at int64) error
+	walk = func(path string, at int64) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		offsets[path] = at
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				keyAt := dec.InputOffset()
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err := walk(joinPath(path, fmt.Sprint(key)), keyAt); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(fmt.Sprintf("%s[%d]", path, i), dec.InputOffset()); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token()
+		}
+		return err
+	}
+	_ = walk("", 0)
+	return offsets
+}
+
+// lineCol converts offset to a 1-based line and column, skipping the
+// separators and whitespace between the previous token and the next one.
+func lineCol(raw []byte, offset int64) (int, int) {
+	if offset > int64(len(raw)) {
+		offset = int64(len(raw))
+	}
+	for offset < int64(len(raw)) && strings.IndexByte(" \t\r\n,:", raw[offset]) >= 0 {
+		offset++
+	}
+	line, col := 1, 1
+	for _, b := range raw[:offset] {
+		if b == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// parentPath strips the last segment: a.b[2] → a.b → a → "".
+func parentPath(path string) string {
+	i := strings.LastIndexAny(path, ".[")
+	if i < 0 {
+		return ""
+	}
+	return path[:i]
+}
+
+func collectProblems(checks []*fileCheck) Problems {
+	var all Problems
+	for _, c := range checks {
+		all = append(all, c.problems...)
+	}
+	return all
+}
+
+func toSet(list []string) map[string]bool {
+	if list == nil {
+		return nil
+	}
+	set := make(map[string]bool, len(list))
+	for _, s := range list {
+		set[s] = true
+	}
+	return set
+}
@@ -0,0 +1,132 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidationReportsEveryProblemWithPosition(t *testing.T) {
+	ai := []byte(`{
+  "actions": [
+    { "name": "chase", "type": "pursue" },
+    { "name": "dance", "type": "tango" },
+    { "name": "combo", "type": "script", "params": { "steps": [ { "action": "chase" }, { "action": "missing" } ] } }
+  ]
+}`)
+	opts := LintOptions{BehaviorTypes: []string{"pursue", "script"}}
+	cat, problems := ValidateAICatalog("ai.json", ai, opts)
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 catalog problems, got:\n%v", problems)
+	}
+	if p := problems[0]; p.Line != 4 || !strings.Contains(p.Message, `"tango"`) {
+		t.Fatalf("expected unknown type on line 4, got %v", p)
+	}
+	if p := problems[1]; p.Path != "actions[2].params.steps[1].action" || !strings.Contains(p.Message, `"missing"`) {
+		t.Fatalf("expected missing script action, got %v", p)
+	}
+
+	actors := []byte(`{
+  "actors": [
+    {
+      "name": "ship",
+      "colour": "red",
+      "ai_refs": ["chase", "flee"]
+    }
+  ]
+}`)
+	opts.Actions = cat.ActionNames()
+	_, problems = ValidateActorDatabase("actors.json", actors, opts)
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 actor problems, got:\n%v", problems)
+	}
+	if p := problems[0]; p.Line != 5 || p.Column != 7 || !strings.Contains(p.Message, `unknown field "colour"`) {
+		t.Fatalf("expected unknown field at 5:7, got %v", p)
+	}
+	if p := problems[1]; p.Line != 6 || !strings.Contains(p.Message, `"flee"`) {
+		t.Fatalf("expected dangling ai_ref on line 6, got %v", p)
+	}
+}
+
+func TestValidationChecksTreeLeaves(t *testing.T) {
+	ai := []byte(`{
+  "version": 2,
+  "actions": [
+    { "name": "chase", "type": "pursue" },
+    { "name": "guard", "type": "tree", "params": { "root": {
+      "type": "selector",
+      "children": [
+        { "type": "cooldown", "duration": "2s", "child": { "type": "action", "action": "chase" } },
+        { "type": "action", "action": "dance" },
+        { "type": "action", "action": "guard" }
+      ]
+    } } }
+  ]
+}`)
+	_, problems := ValidateAICatalog("ai.json", ai, LintOptions{BehaviorTypes: []string{"pursue", "tree"}})
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got:\n%v", problems)
+	}
+	if p := problems[0]; p.Path != "actions[1].params.root.children[1].action" || p.Line != 9 {
+		t.Fatalf("expected missing action on line 9, got %v", p)
+	}
+	if p := problems[1]; p.Path != "actions[1].params.root.children[2].action" || !strings.Contains(p.Message, "its own") {
+		t.Fatalf("expected self reference, got %v", p)
+	}
+}
+
+func TestValidationChecksMachineStates(t *testing.T) {
+	ai := []byte(`{
+  "version": 2,
+  "actions": [
+    { "name": "chase", "type": "pursue" },
+    { "name": "sentry", "type": "fsm", "params": {
+      "initial": "idle",
+      "states": {
+        "idle": { "action": "chase", "on_enter": [ { "action": "wave" } ] },
+        "hunt": { "action": "sentry" }
+      }
+    } }
+  ]
+}`)
+	_, problems := ValidateAICatalog("ai.json", ai, LintOptions{BehaviorTypes: []string{"pursue", "fsm"}})
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 problems, got:\n%v", problems)
+	}
+	if p := problems[0]; p.Path != "actions[1].params.states.hunt.action" || p.Line != 9 {
+		t.Fatalf("expected self reference on line 9, got %v", p)
+	}
+	if p := problems[1]; p.Path != "actions[1].params.states.idle.on_enter[0].action" || !strings.Contains(p.Message, "wave") {
+		t.Fatalf("expected missing hook action, got %v", p)
+	}
+}
+
+func TestValidationChecksTerrainRows(t *testing.T) {
+	src := []byte(`{
+  "tile_size": 32,
+  "tiles": { "grass": {}, "mud": { "cost": 0.5 } },
+  "legend": { ".": "grass", "~": "water" },
+  "rows": [
+    "....",
+    "..",
+    "..#."
+  ]
+}`)
+	_, problems := ValidateTerrainLayers([]Source{{Path: "terrain_test.json", Data: src}})
+	if len(problems) != 4 {
+		t.Fatalf("expected 4 problems, got:\n%v", problems)
+	}
+	want := []struct {
+		path string
+		line int
+	}{
+		{"tiles.mud.cost", 3},
+		{"legend.~", 4},
+		{"rows[1]", 7},
+		{"rows[2]", 8},
+	}
+	for i, w := range want {
+		if p := problems[i]; p.Path != w.path || p.Line != w.line {
+			t.Fatalf("problem %d: expected %s on line %d, got %v", i, w.path, w.line, p)
+		}
+	}
+}
@@ -38,10 +38,16 @@ func (c *AIController) Name() string { return "AIController" }
 
 // AIActionInstance represents a single behavior currently active or queued.
 type AIActionInstance struct {
-	Name     string
-	Type     string
-	Priority int
-	Params   map[string]any
+	Name       string
+	Type       string
+	Priority   int
+	Params     map[string]any
+	Conditions map[string]any // optional preconditions (see ai.ConditionSet)
+
+	// Decoded holds the AI system's runtime form of Params and Conditions,
+	// filled once when the controller is built. It is not saved; loaded
+	// controllers decode again on first use.
+	Decoded any `json:"-"`
 }
 
 /*───────────────────────────────────────────────*
@@ -0,0 +1,150 @@
+package ecs
+
+import "time"
+
+/*───────────────────────────────────────────────*
+| SIMULATION CLOCK                              |
+*───────────────────────────────────────────────*/
+
+// ReferenceRate is the tick rate that per-tick quantities such as Velocity
+// and AI speeds were tuned for. StepScale converts a tick to this unit.
+const ReferenceRate = 60
+
+// DefaultStep is the fixed simulation timestep.
+const DefaultStep = time.Second / ReferenceRate
+
+// maxStepsPerFrame drops backlog after a long stall instead of spiralling.
+const maxStepsPerFrame = 5
+
+// Clock drives the fixed-timestep simulation. World.Advance feeds it real
+// frame time; systems read Delta/StepScale instead of assuming a frame rate.
+type Clock struct {
+	Step      time.Duration // fixed simulation timestep
+	TimeScale float64       // 1 = real time, 0.5 = slow motion
+	Paused    bool          // when true, frames only get zero-Delta idle passes
+
+	delta       time.Duration
+	elapsed     time.Duration
+	accumulator time.Duration
+	tick        uint64
+	frame       uint64
+	alpha       float64
+}
+
+// NewClock returns a clock stepping at DefaultStep in real time.
+func NewClock() *Clock {
+	return &Clock{Step: DefaultStep, TimeScale: 1}
+}
+
+// Delta is the simulated time covered by the current Update. It is Step
+// during a simulation tick and zero during idle passes or while paused.
+func (c *Clock) Delta() time.Duration { return c.delta }
+
+// DeltaSeconds returns Delta in seconds.
+func (c *Clock) DeltaSeconds() float64 { return c.delta.Seconds() }
+
+// StepScale expresses Delta in reference ticks (DefaultStep), so
+// per-tick quantities can be applied as value * StepScale.
+func (c *Clock) StepScale() float64 {
+	return float64(c.delta) / float64(DefaultStep)
+}
+
+// Elapsed is the total simulated time.
+func (c *Clock) Elapsed() time.Duration { return c.elapsed }
+
+// Tick counts simulation steps taken so far.
+func (c *Clock) Tick() uint64 { return c.tick }
+
+// Frame counts calls to World.Advance (rendered frames).
+func (c *Clock) Frame() uint64 { return c.frame }
+
+// Alpha is the fraction of a step left in the accumulator after the last
+// Advance; renderers blend previous and current state by it.
+func (c *Clock) Alpha() float64 { return c.alpha }
+
+// plan consumes frame time and reports how many fixed steps to run.
+func (c *Clock) plan(frame time.Duration) int {
+	c.frame++
+	if c.Step <= 0 {
+		c.Step = DefaultStep
+	}
+	if c.Paused || frame <= 0 {
+		return 0
+	}
+
+	scale := c.TimeScale
+	if scale < 0 {
+		scale = 0
+	}
+	c.accumulator += time.Duration(float64(frame) * scale)
+
+	steps := int(c.accumulator / c.Step)
+	if steps > maxStepsPerFrame {
+		steps = maxStepsPerFrame
+		c.accumulator = c.Step * maxStepsPerFrame
+	}
+	c.accumulator -= c.Step * time.Duration(steps)
+	c.alpha = float64(c.accumulator) / float64(c.Step)
+	return steps
+}
+
+func (c *Clock) beginTick() {
+	c.delta = c.Step
+	c.elapsed += c.Step
+	c.tick++
+}
+
+func (c *Clock) beginIdle() {
+	c.delta = 0
+}
+
+/*───────────────────────────────────────────────*
+| WORLD INTEGRATION                             |
+*───────────────────────────────────────────────*/
+
+// Clock returns the world's simulation clock.
+func (w *World) Clock() *Clock {
+	if w.clock == nil {
+		w.clock = NewClock()
+	}
+	return w.clock
+}
+
+// Advance feeds one rendered frame's worth of real time into the clock and
+// runs World.Update once per fixed step. Frames that owe no step still get a
+// single idle pass with zero Delta so input and UI systems see every frame.
+func (w *World) Advance(frame time.Duration) {
+	clock := w.Clock()
+	if w.profiler != nil {
+		w.profiler.BeginFrame()
+	}
+	steps := clock.plan(frame)
+	if steps == 0 {
+		clock.beginIdle()
+		w.Update()
+		return
+	}
+	for i := 0; i < steps; i++ {
+		w.capturePrevious()
+		clock.beginTick()
+		w.Update()
+	}
+}
+
+// capturePrevious records the pre-step transforms used for interpolation.
+func (w *World) capturePrevious() {
+	if s := w.store("Position"); s != nil {
+		for _, c := range s.dense {
+			if p, ok := c.(*Position); ok {
+				p.capture()
+			}
+		}
+	}
+	if s := w.store("Camera"); s != nil {
+		for _, c := range s.dense {
+			if cam, ok := c.(*Camera); ok {
+				cam.capture()
+			}
+		}
+	}
+}
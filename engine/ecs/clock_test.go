@@ -0,0 +1,72 @@
+package ecs
+
+import (
+	"testing"
+	"time"
+)
+
+type stepMover struct{ passes int }
+
+func (s *stepMover) Update(w *World) {
+	s.passes++
+	scale := w.Clock().StepScale()
+	NewQuery2[*Position, *Velocity](w).Each(func(_ *Entity, p *Position, v *Velocity) {
+		p.X += v.VX * scale
+	})
+}
+
+func simulate(hz int, d time.Duration) (*World, *Position) {
+	w := NewWorld()
+	e := w.NewEntity()
+	pos := &Position{}
+	e.Add(pos)
+	e.Add(&Velocity{VX: 1})
+	w.AddSystem(&stepMover{})
+
+	frame := time.Second / time.Duration(hz)
+	frames := int(d * time.Duration(hz) / time.Second)
+	for i := 0; i < frames; i++ {
+		w.Advance(frame)
+	}
+	return w, pos
+}
+
+func TestFixedStepIsFrameRateIndependent(t *testing.T) {
+	w30, p30 := simulate(30, time.Second)
+	w60, p60 := simulate(60, time.Second)
+	w144, p144 := simulate(144, time.Second)
+
+	if w30.Clock().Tick() != ReferenceRate || w60.Clock().Tick() != ReferenceRate {
+		t.Fatalf("expected %d ticks, got %d at 30Hz and %d at 60Hz", ReferenceRate, w30.Clock().Tick(), w60.Clock().Tick())
+	}
+	if p30.X != p60.X || p60.X != float64(ReferenceRate) {
+		t.Fatalf("expected identical positions, got %.3f at 30Hz and %.3f at 60Hz", p30.X, p60.X)
+	}
+	if ticks := w144.Clock().Tick(); ticks < ReferenceRate-1 || ticks > ReferenceRate {
+		t.Fatalf("expected ~%d ticks at 144Hz, got %d", ReferenceRate, ticks)
+	}
+	if p144.X != float64(w144.Clock().Tick()) {
+		t.Fatalf("expected one unit per tick at 144Hz, got %.3f after %d ticks", p144.X, w144.Clock().Tick())
+	}
+
+	x, _ := p144.Interpolated(w144.Clock().Alpha())
+	if x < p144.X-1 || x > p144.X {
+		t.Fatalf("interpolated position %.3f outside last step [%.3f, %.3f]", x, p144.X-1, p144.X)
+	}
+}
+
+func TestPausedClockRunsIdlePasses(t *testing.T) {
+	w, pos := simulate(60, 0)
+	mover := w.Systems[0].(*stepMover)
+	w.Clock().Paused = true
+
+	for i := 0; i < 10; i++ {
+		w.Advance(DefaultStep)
+	}
+	if mover.passes != 10 {
+		t.Fatalf("expected one idle pass per frame while paused, got %d", mover.passes)
+	}
+	if pos.X != 0 || w.Clock().Tick() != 0 {
+		t.Fatalf("expected no simulation while paused, moved to %.3f over %d ticks", pos.X, w.Clock().Tick())
+	}
+}
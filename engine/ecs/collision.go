@@ -0,0 +1,138 @@
+package ecs
+
+import "math"
+
+/*───────────────────────────────────────────────*
+ | COLLIDERS                                     |
+ *───────────────────────────────────────────────*/
+
+// ColliderShape selects the geometry of a Collider.
+type ColliderShape int
+
+const (
+	ShapeCircle  ColliderShape = iota // Radius
+	ShapeBox                          // HalfW × HalfH, axis-aligned
+	ShapePolygon                      // Points, convex
+)
+
+// CollisionResponse is how a solid collider reacts when it hits another.
+type CollisionResponse int
+
+const (
+	ResponseNone   CollisionResponse = iota // contacts are reported, nothing moves
+	ResponseStop                            // pushed out, velocity cleared
+	ResponseSlide                           // pushed out, velocity into the obstacle removed
+	ResponseBounce                          // pushed out, velocity into the obstacle reflected
+)
+
+// AllLayers is a Mask colliding with every layer.
+const AllLayers = ^uint32(0)
+
+// Vec2 is a point or offset in world units.
+type Vec2 struct{ X, Y float64 }
+
+// Collider gives an entity a collision shape centered on its Position
+// (plus Offset). Two colliders interact when each one's Mask includes the
+// other's Layer; a zero Layer counts as layer 1 and a zero Mask as
+// AllLayers, so plain colliders hit each other.
+//
+// Triggers report overlaps but never block. Static colliders block but
+// are never pushed; the others are pushed out according to Response.
+type Collider struct {
+	Shape        ColliderShape
+	Radius       float64
+	HalfW, HalfH float64
+	Points       []Vec2 // polygon vertices relative to the center, in order
+	Offset       Vec2
+
+	Layer    uint32
+	Mask     uint32
+	Trigger  bool
+	Static   bool
+	Response CollisionResponse
+	Bounce   float64 // share of speed kept by ResponseBounce (0..1)
+}
+
+func (c *Collider) Name() string { return "Collider" }
+
+// Layers returns the collider's layer and mask with defaults applied.
+func (c *Collider) Layers() (layer, mask uint32) {
+	layer, mask = c.Layer, c.Mask
+	if layer == 0 {
+		layer = 1
+	}
+	if mask == 0 {
+		mask = AllLayers
+	}
+	return layer, mask
+}
+
+// Interacts reports whether c and o collide or trigger each other.
+func (c *Collider) Interacts(o *Collider) bool {
+	cl, cm := c.Layers()
+	ol, om := o.Layers()
+	return cm&ol != 0 && om&cl != 0
+}
+
+// Extent returns the distance from the entity's position to the farthest
+// point of the shape.
+func (c *Collider) Extent() float64 {
+	r := c.Radius
+	switch c.Shape {
+	case ShapeBox:
+		r = math.Hypot(c.HalfW, c.HalfH)
+	case ShapePolygon:
+		r = 0
+		for _, p := range c.Points {
+			r = math.Max(r, math.Hypot(p.X, p.Y))
+		}
+	}
+	return r + math.Hypot(c.Offset.X, c.Offset.Y)
+}
+
+// Bounds returns the world-space bounding box of the collider on an
+// entity at (x, y).
+func (c *Collider) Bounds(x, y float64) (minX, minY, maxX, maxY float64) {
+	x, y = x+c.Offset.X, y+c.Offset.Y
+	switch c.Shape {
+	case ShapeBox:
+		return x - c.HalfW, y - c.HalfH, x + c.HalfW, y + c.HalfH
+	case ShapePolygon:
+		if len(c.Points) == 0 {
+			return x, y, x, y
+		}
+		minX, minY = math.Inf(1), math.Inf(1)
+		maxX, maxY = math.Inf(-1), math.Inf(-1)
+		for _, p := range c.Points {
+			minX, maxX = math.Min(minX, x+p.X), math.Max(maxX, x+p.X)
+			minY, maxY = math.Min(minY, y+p.Y), math.Max(maxY, y+p.Y)
+		}
+		return minX, minY, maxX, maxY
+	}
+	return x - c.Radius, y - c.Radius, x + c.Radius, y + c.Radius
+}
+
+// circleSegments is how many vertices approximate a circle in Outline.
+const circleSegments = 24
+
+// Outline appends to dst the world-space vertices of the collider on an
+// entity at (x, y). Circles are approximated by a regular polygon.
+func (c *Collider) Outline(x, y float64, dst []Vec2) []Vec2 {
+	x, y = x+c.Offset.X, y+c.Offset.Y
+	switch c.Shape {
+	case ShapeBox:
+		return append(dst,
+			Vec2{x - c.HalfW, y - c.HalfH}, Vec2{x + c.HalfW, y - c.HalfH},
+			Vec2{x + c.HalfW, y + c.HalfH}, Vec2{x - c.HalfW, y + c.HalfH})
+	case ShapePolygon:
+		for _, p := range c.Points {
+			dst = append(dst, Vec2{x + p.X, y + p.Y})
+		}
+		return dst
+	}
+	for i := 0; i < circleSegments; i++ {
+		a := 2 * math.Pi * float64(i) / circleSegments
+		dst = append(dst, Vec2{x + c.Radius*math.Cos(a), y + c.Radius*math.Sin(a)})
+	}
+	return dst
+}
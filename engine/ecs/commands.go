@@ -0,0 +1,112 @@
+package ecs
+
+import "sync"
+
+/*───────────────────────────────────────────────*
+| COMMAND BUFFER                                |
+*───────────────────────────────────────────────*/
+
+// CommandBuffer records structural changes (spawns, removals, component
+// edits) requested while systems are iterating. The world applies them at
+// the sync point after each system's Update, so iteration never observes a
+// half-mutated entity list.
+type CommandBuffer struct {
+	mu   sync.Mutex
+	cmds []func(*World)
+}
+
+// Spawn queues creation of a new entity; init runs once it exists.
+func (b *CommandBuffer) Spawn(init func(*Entity)) {
+	b.push(func(w *World) {
+		e := w.NewEntity()
+		if init != nil {
+			init(e)
+		}
+	})
+}
+
+// Destroy queues removal of e. The entity reports !Alive() immediately.
+func (b *CommandBuffer) Destroy(e *Entity) {
+	if e == nil || e.destroyed {
+		return
+	}
+	e.destroyed = true
+	b.push(func(w *World) { w.destroyEntity(e) })
+}
+
+// AddComponent queues attaching c to e.
+func (b *CommandBuffer) AddComponent(e *Entity, c Component) {
+	if e == nil || c == nil {
+		return
+	}
+	b.push(func(*World) {
+		if e.Alive() {
+			e.Add(c)
+		}
+	})
+}
+
+// RemoveComponent queues detaching the named component from e.
+func (b *CommandBuffer) RemoveComponent(e *Entity, name string) {
+	if e == nil {
+		return
+	}
+	b.push(func(*World) {
+		if e.Alive() {
+			e.Remove(name)
+		}
+	})
+}
+
+// Len reports how many commands are waiting for the next sync point.
+func (b *CommandBuffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.cmds)
+}
+
+func (b *CommandBuffer) push(cmd func(*World)) {
+	b.mu.Lock()
+	b.cmds = append(b.cmds, cmd)
+	b.mu.Unlock()
+}
+
+func (b *CommandBuffer) take() []func(*World) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	cmds := b.cmds
+	b.cmds = nil
+	return cmds
+}
+
+/*───────────────────────────────────────────────*
+| WORLD SYNC POINT                              |
+*───────────────────────────────────────────────*/
+
+// maxFlushPasses bounds command cascades (commands queueing commands).
+const maxFlushPasses = 8
+
+// Commands returns the world's deferred command buffer.
+func (w *World) Commands() *CommandBuffer {
+	if w.commands == nil {
+		w.commands = &CommandBuffer{}
+	}
+	return w.commands
+}
+
+// Flush applies every queued command. World.Update calls it between
+// systems; callers outside the update loop may call it directly.
+func (w *World) Flush() {
+	if w == nil || w.commands == nil {
+		return
+	}
+	for pass := 0; pass < maxFlushPasses; pass++ {
+		cmds := w.commands.take()
+		if len(cmds) == 0 {
+			return
+		}
+		for _, cmd := range cmds {
+			cmd(w)
+		}
+	}
+}
@@ -15,19 +15,48 @@ type Component interface {
 	Name() string
 }
 
-// Tag is a simple zero-data marker component.
+// Tag is a simple zero-data marker component. Tags are stored under
+// TagName, apart from other components, so a tag named "Health" cannot
+// replace the Health component.
 type Tag string
 
-func (t Tag) Name() string { return string(t) }
+func (t Tag) Name() string { return TagName(string(t)) }
+
+// TagName is the component name the tag called tag is stored under.
+func TagName(tag string) string { return "tag:" + tag }
 
 /*───────────────────────────────────────────────*
  | TRANSFORM COMPONENTS                          |
  *───────────────────────────────────────────────*/
 
-type Position struct{ X, Y float64 }
+type Position struct {
+	X, Y float64
+
+	prevX, prevY float64 // position before the latest simulation step
+	hasPrev      bool
+}
 
 func (p *Position) Name() string { return "Position" }
 
+// Interpolated blends the previous and current step positions by alpha
+// (Clock.Alpha) for smooth rendering between fixed steps.
+func (p *Position) Interpolated(alpha float64) (float64, float64) {
+	if !p.hasPrev {
+		return p.X, p.Y
+	}
+	return lerp(p.prevX, p.X, alpha), lerp(p.prevY, p.Y, alpha)
+}
+
+// Snap discards interpolation history so a teleport renders immediately.
+func (p *Position) Snap() {
+	p.prevX, p.prevY = p.X, p.Y
+}
+
+func (p *Position) capture() {
+	p.prevX, p.prevY, p.hasPrev = p.X, p.Y, true
+}
+
+// Velocity is measured in world units per reference tick (1/ReferenceRate s).
 type Velocity struct{ VX, VY float64 }
 
 func (v *Velocity) Name() string { return "Velocity" }
@@ -38,6 +67,7 @@ func (v *Velocity) Name() string { return "Velocity" }
 
 type Sprite struct {
 	Image          *platform.Image
+	ImagePath      string // source asset; used to re-resolve Image on load
 	Width, Height  int
 	Rotation       float64
 	FlipHorizontal bool
@@ -103,10 +133,29 @@ type Camera struct {
 	MinScale     float64
 	MaxScale     float64
 	DefaultScale float64
+
+	prevX, prevY float64 // camera center before the latest simulation step
+	hasPrev      bool
 }
 
 func (c *Camera) Name() string { return "Camera" }
 
+// Interpolated blends the previous and current step camera center by alpha.
+func (c *Camera) Interpolated(alpha float64) (float64, float64) {
+	if !c.hasPrev {
+		return c.X, c.Y
+	}
+	return lerp(c.prevX, c.X, alpha), lerp(c.prevY, c.Y, alpha)
+}
+
+func (c *Camera) capture() {
+	c.prevX, c.prevY, c.hasPrev = c.X, c.Y, true
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
 type CameraTarget struct{}
 
 func (c *CameraTarget) Name() string { return "CameraTarget" }
@@ -167,11 +216,73 @@ func (h *Health) Heal(amount float64) {
 // ScriptState tracks multi-step scripted AI progress.
 type ScriptState struct {
 	Current int
-	NextAt  time.Time
+	NextAt  time.Duration // Clock.Elapsed at which the next step may run
 }
 
 func (s *ScriptState) Name() string { return "AIScriptState" }
 
+// TreeState tracks behavior tree progress for one entity, keyed by the
+// tree's action name and the node's path in it
+// (e.g. "guard/root.children[1]").
+type TreeState struct {
+	Nodes map[string]TreeNodeState
+}
+
+// TreeNodeState is the memory of one composite or decorator node.
+type TreeNodeState struct {
+	Index int           // running child of a sequence or selector; repeats done; parallel children done (bitmask)
+	Until time.Duration // Clock.Elapsed at which a wait or cooldown ends
+}
+
+func (s *TreeState) Name() string { return "AITreeState" }
+
+// AIStateMachine is the current state of an entity's data-defined state
+// machine. An entity runs one machine at a time.
+type AIStateMachine struct {
+	Machine string        // action name of the machine in ai.json
+	State   string        // current state
+	Since   time.Duration // Clock.Elapsed at which State was entered
+}
+
+func (m *AIStateMachine) Name() string { return "AIStateMachine" }
+
+// NavPath is the path an AI entity is following around blocked terrain.
+// It is runtime-only: paths are searched again after a load.
+type NavPath struct {
+	Points       []AIWaypoint
+	Index        int     // next point to reach
+	GoalX, GoalY float64 // target the path was requested for
+}
+
+func (p *NavPath) Name() string { return "AINavPath" }
+
+// Steering is the state of an entity moved by steering behaviors. The
+// steered velocity is kept here because the AI system clears Velocity
+// every frame; flock mates read it for alignment.
+type Steering struct {
+	VX, VY float64
+	Wander float64 // angle on the wander circle, radians
+	Group  string  // flock the entity belongs to
+}
+
+func (s *Steering) Name() string { return "AISteering" }
+
+// TargetCache remembers what an entity's target selectors resolved to, so
+// "nearest:" and "weakest:" are not searched again every frame. It is
+// runtime-only: selectors are resolved again after a load.
+type TargetCache struct {
+	Entries map[string]TargetEntry // keyed by selector
+}
+
+// TargetEntry is one cached resolution.
+type TargetEntry struct {
+	ID    EntityID
+	Found bool          // false when nothing matched
+	Until time.Duration // Clock.Elapsed after which it is resolved again
+}
+
+func (c *TargetCache) Name() string { return "AITargets" }
+
 /*───────────────────────────────────────────────*
  | ENTITY HELPERS                                |
  *───────────────────────────────────────────────*/
@@ -184,7 +295,7 @@ func (e *Entity) AddNamed(name string, c Component) {
 	if _, exists := e.Components[name]; exists {
 		fmt.Printf("[ECS] Warning: replacing component '%s' on entity %d\n", name, e.ID)
 	}
-	e.Components[name] = c
+	e.set(name, c)
 }
 
 /*───────────────────────────────────────────────*
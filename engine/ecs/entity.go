@@ -1,18 +1,44 @@
 package ecs
 
-type EntityID int
+import "reflect"
+
+// EntityID packs a slot index (low 32 bits) and a generation counter (high
+// 32 bits). Slots are recycled after destruction with a bumped generation,
+// so stale IDs never alias a newer entity.
+type EntityID int64
+
+const entityIndexBits = 32
+
+func makeEntityID(index int, generation uint32) EntityID {
+	return EntityID(uint64(generation)<<entityIndexBits | uint64(uint32(index)))
+}
+
+// Index returns the recycled slot index portion of the ID.
+func (id EntityID) Index() int { return int(uint32(id)) }
+
+// Generation returns how many times the slot has been reused.
+func (id EntityID) Generation() uint32 { return uint32(uint64(id) >> entityIndexBits) }
 
 type Entity struct {
 	ID         EntityID
 	Components map[string]Component
+
+	world     *World // owning world; keeps component stores in sync
+	destroyed bool   // queued for destruction at the next sync point
 }
 
 func NewEntity(id EntityID) *Entity {
 	return &Entity{ID: id, Components: make(map[string]Component)}
 }
 
+// Alive reports whether the entity still belongs to a world and has not
+// been queued for destruction.
+func (e *Entity) Alive() bool {
+	return e != nil && e.world != nil && !e.destroyed
+}
+
 func (e *Entity) Add(c Component) {
-	e.Components[c.Name()] = c
+	e.set(c.Name(), c)
 }
 
 func (e *Entity) Get(name string) Component {
@@ -24,7 +50,47 @@ func (e *Entity) Has(name string) bool {
 	return ok
 }
 
+// HasTag reports whether the entity carries the Tag called tag.
+func (e *Entity) HasTag(tag string) bool {
+	return e.Has(TagName(tag))
+}
+
 func (e *Entity) Remove(name string) {
+	old, existed := e.Components[name]
 	delete(e.Components, name)
+	if e.world != nil {
+		if s := e.world.store(name); s != nil {
+			s.remove(e.ID)
+		}
+		if existed {
+			e.world.notifyRemoved(e, name, old)
+		}
+	}
 }
 
+// set stores c under name in both the entity map and the world store.
+// Replacing a component reports the old instance as removed first.
+func (e *Entity) set(name string, c Component) {
+	old, existed := e.Components[name]
+	if existed && sameComponent(old, c) {
+		return
+	}
+	e.Components[name] = c
+	if e.world != nil {
+		e.world.ensureStore(name).set(e, c)
+		if existed {
+			e.world.notifyRemoved(e, name, old)
+		}
+		e.world.notifyAdded(e, name, c)
+	}
+}
+
+// sameComponent reports whether a and b are the identical instance, without
+// panicking on non-comparable value components.
+func sameComponent(a, b Component) bool {
+	ta := reflect.TypeOf(a)
+	if ta != reflect.TypeOf(b) || !ta.Comparable() {
+		return false
+	}
+	return a == b
+}
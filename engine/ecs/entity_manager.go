@@ -17,23 +17,25 @@ func (m *EntityManager) ForEach(fn func(*Entity)) {
 		return
 	}
 	for _, entity := range m.world.Entities {
-		if entity != nil {
+		if entity != nil && !entity.destroyed {
 			fn(entity)
 		}
 	}
 }
 
 // ForEachComponent executes fn for each entity that has the named component.
+// It walks the component's dense store instead of every entity.
 func (m *EntityManager) ForEachComponent(name string, fn func(*Entity, Component)) {
 	if m == nil || m.world == nil || fn == nil {
 		return
 	}
-	for _, entity := range m.world.Entities {
-		if entity == nil {
-			continue
-		}
-		if component := entity.Get(name); component != nil {
-			fn(entity, component)
+	s := m.world.store(name)
+	if s == nil {
+		return
+	}
+	for i := 0; i < len(s.dense); i++ {
+		if !s.owners[i].destroyed {
+			fn(s.owners[i], s.dense[i])
 		}
 	}
 }
@@ -44,12 +46,13 @@ func (m *EntityManager) FirstComponent(name string) (*Entity, Component) {
 	if m == nil || m.world == nil {
 		return nil, nil
 	}
-	for _, entity := range m.world.Entities {
-		if entity == nil {
-			continue
-		}
-		if component := entity.Get(name); component != nil {
-			return entity, component
+	s := m.world.store(name)
+	if s == nil {
+		return nil, nil
+	}
+	for i := 0; i < len(s.dense); i++ {
+		if !s.owners[i].destroyed {
+			return s.owners[i], s.dense[i]
 		}
 	}
 	return nil, nil
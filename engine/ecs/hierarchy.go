@@ -0,0 +1,218 @@
+package ecs
+
+import "math"
+
+/*───────────────────────────────────────────────*
+| HIERARCHY COMPONENTS                          |
+*───────────────────────────────────────────────*/
+
+// Parent links a child entity to the entity it is attached to.
+type Parent struct{ ID EntityID }
+
+func (p *Parent) Name() string { return "Parent" }
+
+// Children lists the entities attached to a parent, in attach order.
+type Children struct{ IDs []EntityID }
+
+func (c *Children) Name() string { return "Children" }
+
+// LocalTransform places a child relative to its parent. The offset is in
+// the parent's sprite space (art faces up, so -Y is "ahead") and is rotated
+// and scaled by the parent's world transform. A zero Scale counts as 1.
+type LocalTransform struct {
+	X, Y     float64
+	Rotation float64
+	Scale    float64
+}
+
+func (t *LocalTransform) Name() string { return "LocalTransform" }
+
+// WorldTransform is the resolved transform of an entity in a hierarchy,
+// written by PropagateTransforms. Children also get their Position and
+// Sprite.Rotation updated so existing systems see the world values.
+type WorldTransform struct {
+	X, Y     float64
+	Rotation float64
+	Scale    float64
+}
+
+func (t *WorldTransform) Name() string { return "WorldTransform" }
+
+func scaleOrOne(s float64) float64 {
+	if s == 0 {
+		return 1
+	}
+	return s
+}
+
+/*───────────────────────────────────────────────*
+| PARENTING                                     |
+*───────────────────────────────────────────────*/
+
+// SetParent attaches child to parent, detaching it from any previous parent.
+// A LocalTransform with zero offset is added when the child has none, along
+// with the Position and WorldTransform components propagation writes, so
+// propagation itself never changes world structure. It reports false for
+// nil entities, self-parenting and cycles.
+func (w *World) SetParent(child, parent *Entity) bool {
+	if child == nil || parent == nil || child == parent {
+		return false
+	}
+	for p := parent; p != nil; p = w.ParentOf(p) {
+		if p == child {
+			return false
+		}
+	}
+
+	w.ClearParent(child)
+	child.Add(&Parent{ID: parent.ID})
+	kids, _ := parent.Get("Children").(*Children)
+	if kids == nil {
+		kids = &Children{}
+		parent.Add(kids)
+	}
+	kids.IDs = append(kids.IDs, child.ID)
+	if !child.Has("LocalTransform") {
+		child.Add(&LocalTransform{Scale: 1})
+	}
+	if !child.Has("Position") {
+		child.Add(&Position{})
+	}
+	for _, e := range []*Entity{parent, child} {
+		if !e.Has("WorldTransform") {
+			e.Add(&WorldTransform{Scale: 1})
+		}
+	}
+	return true
+}
+
+// ClearParent detaches child from its parent. The child keeps its last
+// world position and becomes a root.
+func (w *World) ClearParent(child *Entity) {
+	if child == nil {
+		return
+	}
+	link, _ := child.Get("Parent").(*Parent)
+	if link == nil {
+		return
+	}
+	if parent := w.GetEntity(link.ID); parent != nil {
+		w.dropChild(parent, child.ID)
+	}
+	child.Remove("Parent")
+}
+
+// ParentOf returns the entity e is attached to, or nil for roots.
+func (w *World) ParentOf(e *Entity) *Entity {
+	if e == nil {
+		return nil
+	}
+	if link, ok := e.Get("Parent").(*Parent); ok {
+		return w.GetEntity(link.ID)
+	}
+	return nil
+}
+
+// ChildrenOf returns the live entities attached to e, in attach order.
+func (w *World) ChildrenOf(e *Entity) []*Entity {
+	if e == nil {
+		return nil
+	}
+	kids, _ := e.Get("Children").(*Children)
+	if kids == nil {
+		return nil
+	}
+	out := make([]*Entity, 0, len(kids.IDs))
+	for _, id := range kids.IDs {
+		if child := w.GetEntity(id); child != nil {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+func (w *World) dropChild(parent *Entity, id EntityID) {
+	kids, _ := parent.Get("Children").(*Children)
+	if kids == nil {
+		return
+	}
+	for i, cid := range kids.IDs {
+		if cid == id {
+			kids.IDs = append(kids.IDs[:i], kids.IDs[i+1:]...)
+			break
+		}
+	}
+	if len(kids.IDs) == 0 {
+		parent.Remove("Children")
+	}
+}
+
+// detachHierarchy runs when target is destroyed: its children are destroyed
+// with it and it is unlinked from its own parent.
+func (w *World) detachHierarchy(target *Entity) {
+	for _, child := range w.ChildrenOf(target) {
+		child.Remove("Parent") // skip dropChild on a parent being torn down
+		w.destroyEntity(child)
+	}
+	if parent := w.ParentOf(target); parent != nil {
+		w.dropChild(parent, target.ID)
+	}
+}
+
+/*───────────────────────────────────────────────*
+| TRANSFORM PROPAGATION                         |
+*───────────────────────────────────────────────*/
+
+// PropagateTransforms walks every hierarchy from its roots and resolves
+// world transforms. A root's transform comes from its Position and Sprite
+// rotation; each child's is its LocalTransform composed with its parent's.
+func (w *World) PropagateTransforms() {
+	NewQuery[*Children](w).Each(func(e *Entity, _ *Children) {
+		if e.Has("Parent") && w.ParentOf(e) != nil {
+			return // resolved from its own root
+		}
+		w.propagate(e, rootTransform(e))
+	})
+}
+
+func rootTransform(e *Entity) WorldTransform {
+	t := WorldTransform{Scale: 1}
+	if pos, ok := e.Get("Position").(*Position); ok {
+		t.X, t.Y = pos.X, pos.Y
+	}
+	if spr, ok := e.Get("Sprite").(*Sprite); ok {
+		t.Rotation = spr.Rotation
+	}
+	if local, ok := e.Get("LocalTransform").(*LocalTransform); ok {
+		t.Scale = scaleOrOne(local.Scale)
+	}
+	return t
+}
+
+func (w *World) propagate(e *Entity, t WorldTransform) {
+	if wt, ok := e.Get("WorldTransform").(*WorldTransform); ok {
+		*wt = t
+	}
+	for _, child := range w.ChildrenOf(e) {
+		local, _ := child.Get("LocalTransform").(*LocalTransform)
+		if local == nil {
+			local = &LocalTransform{}
+		}
+		sin, cos := math.Sincos(t.Rotation)
+		ox, oy := local.X*t.Scale, local.Y*t.Scale
+		ct := WorldTransform{
+			X:        t.X + ox*cos - oy*sin,
+			Y:        t.Y + ox*sin + oy*cos,
+			Rotation: t.Rotation + local.Rotation,
+			Scale:    t.Scale * scaleOrOne(local.Scale),
+		}
+
+		if pos, ok := child.Get("Position").(*Position); ok {
+			pos.X, pos.Y = ct.X, ct.Y
+		}
+		if spr, ok := child.Get("Sprite").(*Sprite); ok {
+			spr.Rotation = ct.Rotation
+		}
+		w.propagate(child, ct)
+	}
+}
@@ -0,0 +1,47 @@
+package ecs
+
+import (
+	"math"
+	"testing"
+)
+
+func TestChildrenFollowParentTransform(t *testing.T) {
+	w := NewWorld()
+	ship := w.NewEntity()
+	ship.Add(&Position{X: 100, Y: 50})
+	ship.Add(&Sprite{Rotation: math.Pi / 2})
+
+	turret := w.NewEntity()
+	turret.Add(&Sprite{})
+	if !w.SetParent(turret, ship) {
+		t.Fatalf("expected turret to attach")
+	}
+	turret.Get("LocalTransform").(*LocalTransform).Y = -10 // 10 units ahead
+
+	glow := w.NewEntity()
+	w.SetParent(glow, turret)
+	glow.Get("LocalTransform").(*LocalTransform).Scale = 0.5
+
+	if w.SetParent(ship, glow) {
+		t.Fatalf("expected cycle to be rejected")
+	}
+
+	w.PropagateTransforms()
+
+	pos := turret.Get("Position").(*Position)
+	if math.Abs(pos.X-110) > 1e-9 || math.Abs(pos.Y-50) > 1e-9 {
+		t.Fatalf("expected turret rotated ahead of ship at (110, 50), got (%.2f, %.2f)", pos.X, pos.Y)
+	}
+	if rot := turret.Get("Sprite").(*Sprite).Rotation; rot != math.Pi/2 {
+		t.Fatalf("expected turret to inherit rotation, got %.2f", rot)
+	}
+	wt := glow.Get("WorldTransform").(*WorldTransform)
+	if wt.X != pos.X || wt.Y != pos.Y || wt.Scale != 0.5 {
+		t.Fatalf("expected grandchild resolved through turret, got %+v", *wt)
+	}
+
+	w.RemoveEntity(ship)
+	if w.IsAlive(turret.ID) || w.IsAlive(glow.ID) || len(w.Entities) != 0 {
+		t.Fatalf("expected children destroyed with their parent")
+	}
+}
@@ -0,0 +1,177 @@
+package ecs
+
+import "sort"
+
+/*───────────────────────────────────────────────*
+| LIFECYCLE OBSERVERS                           |
+*───────────────────────────────────────────────*/
+
+// ComponentHook is notified when a component is attached to or detached
+// from an entity that belongs to a world.
+type ComponentHook func(e *Entity, c Component)
+
+// EntityHook is notified when an entity is destroyed.
+type EntityHook func(e *Entity)
+
+// AnyComponent subscribes a hook to every component name.
+const AnyComponent = ""
+
+type componentObserver struct {
+	id int
+	fn ComponentHook
+}
+
+type entityObserver struct {
+	id int
+	fn EntityHook
+}
+
+type observers struct {
+	nextID    int
+	added     map[string][]componentObserver
+	removed   map[string][]componentObserver
+	destroyed []entityObserver
+}
+
+func (w *World) ensureObservers() *observers {
+	if w.observers == nil {
+		w.observers = &observers{
+			added:   make(map[string][]componentObserver),
+			removed: make(map[string][]componentObserver),
+		}
+	}
+	return w.observers
+}
+
+// OnComponentAdded registers fn for attachments of the named component
+// (or AnyComponent). Current owners are replayed to fn immediately so late
+// observers start from a consistent view. The returned func unsubscribes.
+func (w *World) OnComponentAdded(name string, fn ComponentHook) func() {
+	if w == nil || fn == nil {
+		return func() {}
+	}
+	obs := w.ensureObservers()
+	obs.nextID++
+	id := obs.nextID
+	obs.added[name] = append(obs.added[name], componentObserver{id: id, fn: fn})
+	w.replayComponent(name, fn)
+	return func() { obs.added[name] = dropComponentObserver(obs.added[name], id) }
+}
+
+// OnComponentRemoved registers fn for detachments of the named component
+// (or AnyComponent), including those caused by entity destruction.
+func (w *World) OnComponentRemoved(name string, fn ComponentHook) func() {
+	if w == nil || fn == nil {
+		return func() {}
+	}
+	obs := w.ensureObservers()
+	obs.nextID++
+	id := obs.nextID
+	obs.removed[name] = append(obs.removed[name], componentObserver{id: id, fn: fn})
+	return func() { obs.removed[name] = dropComponentObserver(obs.removed[name], id) }
+}
+
+// OnEntityDestroyed registers fn for entity destruction. It runs after the
+// entity's ComponentRemoved notifications, while components are still readable.
+func (w *World) OnEntityDestroyed(fn EntityHook) func() {
+	if w == nil || fn == nil {
+		return func() {}
+	}
+	obs := w.ensureObservers()
+	obs.nextID++
+	id := obs.nextID
+	obs.destroyed = append(obs.destroyed, entityObserver{id: id, fn: fn})
+	return func() {
+		for i, o := range obs.destroyed {
+			if o.id == id {
+				obs.destroyed = append(obs.destroyed[:i:i], obs.destroyed[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+/*───────────────────────────────────────────────*
+| DISPATCH                                      |
+*───────────────────────────────────────────────*/
+
+func (w *World) notifyAdded(e *Entity, name string, c Component) {
+	if w == nil || w.observers == nil {
+		return
+	}
+	for _, o := range w.observers.added[name] {
+		o.fn(e, c)
+	}
+	if name != AnyComponent {
+		for _, o := range w.observers.added[AnyComponent] {
+			o.fn(e, c)
+		}
+	}
+}
+
+func (w *World) notifyRemoved(e *Entity, name string, c Component) {
+	if w == nil || w.observers == nil {
+		return
+	}
+	for _, o := range w.observers.removed[name] {
+		o.fn(e, c)
+	}
+	if name != AnyComponent {
+		for _, o := range w.observers.removed[AnyComponent] {
+			o.fn(e, c)
+		}
+	}
+}
+
+// notifyDestroyed emits ComponentRemoved for every component (sorted by
+// name for determinism) followed by EntityDestroyed.
+func (w *World) notifyDestroyed(e *Entity) {
+	if w == nil || w.observers == nil {
+		return
+	}
+	names := make([]string, 0, len(e.Components))
+	for name := range e.Components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.notifyRemoved(e, name, e.Components[name])
+	}
+	for _, o := range w.observers.destroyed {
+		o.fn(e)
+	}
+}
+
+func (w *World) replayComponent(name string, fn ComponentHook) {
+	if name == AnyComponent {
+		for _, e := range w.Entities {
+			if e == nil || e.destroyed {
+				continue
+			}
+			for _, c := range e.Components {
+				fn(e, c)
+			}
+		}
+		return
+	}
+	s := w.store(name)
+	if s == nil {
+		return
+	}
+	owners := append([]*Entity(nil), s.owners...)
+	dense := append([]Component(nil), s.dense...)
+	for i, e := range owners {
+		if !e.destroyed {
+			fn(e, dense[i])
+		}
+	}
+}
+
+func dropComponentObserver(list []componentObserver, id int) []componentObserver {
+	for i, o := range list {
+		if o.id == id {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return list
+}
@@ -0,0 +1,163 @@
+package ecs
+
+import "reflect"
+
+/*───────────────────────────────────────────────*
+| SYSTEM PHASES                                 |
+*───────────────────────────────────────────────*/
+
+// Phase groups systems into ordered sections of World.Update. All systems
+// of one phase finish before the next phase starts.
+type Phase int
+
+const (
+	PhasePreUpdate  Phase = iota // data reload, scene transitions, registries
+	PhaseSimulation              // input, AI, movement
+	PhasePostUpdate              // camera follow, derived state
+	PhaseRender                  // world-space renderers and HUD content
+	PhaseOverlay                 // debug overlays and developer console
+	phaseCount
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhasePreUpdate:
+		return "PreUpdate"
+	case PhaseSimulation:
+		return "Simulation"
+	case PhasePostUpdate:
+		return "PostUpdate"
+	case PhaseRender:
+		return "Render"
+	case PhaseOverlay:
+		return "Overlay"
+	}
+	return "Phase?"
+}
+
+// PhasedSystem lets a system choose its phase when added with AddSystem.
+// Systems without it run in PhaseSimulation.
+type PhasedSystem interface {
+	System
+	Phase() Phase
+}
+
+// RunCondition gates a system or phase; it is evaluated before each Update.
+type RunCondition func(w *World) bool
+
+// Not inverts a run condition.
+func Not(cond RunCondition) RunCondition {
+	return func(w *World) bool { return !cond(w) }
+}
+
+// runControl holds the runtime switches for one system or phase.
+type runControl struct {
+	disabled   bool
+	conditions []RunCondition
+}
+
+func (c *runControl) shouldRun(w *World) bool {
+	if c == nil {
+		return true
+	}
+	if c.disabled {
+		return false
+	}
+	for _, cond := range c.conditions {
+		if !cond(w) {
+			return false
+		}
+	}
+	return true
+}
+
+/*───────────────────────────────────────────────*
+| WORLD PHASE CONTROL                           |
+*───────────────────────────────────────────────*/
+
+// AddSystemTo registers s in the given phase, gated by optional conditions.
+func (w *World) AddSystemTo(phase Phase, s System, conds ...RunCondition) {
+	if s == nil {
+		return
+	}
+	w.addSystem(s, phase)
+	for _, cond := range conds {
+		w.AddRunCondition(s, cond)
+	}
+}
+
+// SetSystemEnabled turns a registered system on or off. It reports whether
+// the system was found.
+func (w *World) SetSystemEnabled(s System, enabled bool) bool {
+	entry := w.findEntry(s)
+	if entry == nil {
+		return false
+	}
+	entry.control.disabled = !enabled
+	return true
+}
+
+// SystemEnabled reports whether a registered system is switched on.
+func (w *World) SystemEnabled(s System) bool {
+	entry := w.findEntry(s)
+	return entry != nil && !entry.control.disabled
+}
+
+// AddRunCondition gates a registered system on cond. It reports whether
+// the system was found.
+func (w *World) AddRunCondition(s System, cond RunCondition) bool {
+	entry := w.findEntry(s)
+	if entry == nil || cond == nil {
+		return false
+	}
+	entry.control.conditions = append(entry.control.conditions, cond)
+	return true
+}
+
+// SetPhaseEnabled turns a whole phase on or off.
+func (w *World) SetPhaseEnabled(p Phase, enabled bool) {
+	if p >= 0 && p < phaseCount {
+		w.phases[p].disabled = !enabled
+	}
+}
+
+// PhaseEnabled reports whether a phase is switched on.
+func (w *World) PhaseEnabled(p Phase) bool {
+	return p >= 0 && p < phaseCount && !w.phases[p].disabled
+}
+
+// AddPhaseCondition gates every system of phase p on cond.
+func (w *World) AddPhaseCondition(p Phase, cond RunCondition) {
+	if p >= 0 && p < phaseCount && cond != nil {
+		w.phases[p].conditions = append(w.phases[p].conditions, cond)
+	}
+}
+
+func (w *World) phaseShouldRun(p Phase) bool {
+	if p < 0 || p >= phaseCount {
+		return true
+	}
+	return w.phases[p].shouldRun(w)
+}
+
+// findEntry locates the schedule entry for s without panicking on
+// non-comparable system values.
+func (w *World) findEntry(s System) *systemEntry {
+	if s == nil || !reflect.TypeOf(s).Comparable() {
+		return nil
+	}
+	for i := range w.systemEntries {
+		e := &w.systemEntries[i]
+		if reflect.TypeOf(e.system) == reflect.TypeOf(s) && e.system == s {
+			return e
+		}
+	}
+	return nil
+}
+
+func systemPhase(s System) Phase {
+	if ps, ok := s.(PhasedSystem); ok {
+		return ps.Phase()
+	}
+	return PhaseSimulation
+}
@@ -0,0 +1,289 @@
+package ecs
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"runtime/metrics"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*───────────────────────────────────────────────*
+| PROFILER DATA                                 |
+*───────────────────────────────────────────────*/
+
+// Sample kinds.
+const (
+	SampleUpdate = "update"
+	SampleDraw   = "draw"
+)
+
+// SystemSample is one timed Update or Draw call.
+type SystemSample struct {
+	System   string
+	Kind     string        // SampleUpdate or SampleDraw
+	Phase    Phase         // phase of Update samples
+	Start    time.Duration // offset from the frame start
+	Duration time.Duration
+	Allocs   uint64 // heap objects allocated (approximate in parallel stages)
+	Bytes    uint64 // heap bytes allocated (approximate in parallel stages)
+	Entities int    // world entity count after the call
+	Lane     int    // concurrent slot within the stage; 0 when serial
+}
+
+// FrameProfile holds every sample recorded during one frame.
+type FrameProfile struct {
+	Frame    uint64
+	Start    time.Time
+	Duration time.Duration
+	Samples  []SystemSample
+}
+
+// SystemStat aggregates one system's samples over the frame history.
+type SystemStat struct {
+	System    string
+	Kind      string
+	Calls     int
+	Average   time.Duration
+	Max       time.Duration
+	AvgAllocs float64
+}
+
+/*───────────────────────────────────────────────*
+| PROFILER                                      |
+*───────────────────────────────────────────────*/
+
+// Profiler records per-system timings into a ring buffer of recent frames.
+type Profiler struct {
+	mu      sync.Mutex
+	frames  []FrameProfile // ring buffer
+	next    int
+	count   int
+	current *FrameProfile
+	frameNo uint64
+}
+
+// NewProfiler keeps the last capacity frames.
+func NewProfiler(capacity int) *Profiler {
+	if capacity <= 0 {
+		capacity = 120
+	}
+	return &Profiler{frames: make([]FrameProfile, capacity)}
+}
+
+// BeginFrame closes the frame in progress and starts a new one.
+// World.Advance calls it automatically.
+func (p *Profiler) BeginFrame() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closeFrame()
+	p.openFrame()
+}
+
+// Frames returns completed frames, oldest first.
+func (p *Profiler) Frames() []FrameProfile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]FrameProfile, 0, p.count)
+	start := (p.next - p.count + len(p.frames)) % len(p.frames)
+	for i := 0; i < p.count; i++ {
+		out = append(out, p.frames[(start+i)%len(p.frames)])
+	}
+	return out
+}
+
+// Latest returns the most recent completed frame.
+func (p *Profiler) Latest() (FrameProfile, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.count == 0 {
+		return FrameProfile{}, false
+	}
+	return p.frames[(p.next-1+len(p.frames))%len(p.frames)], true
+}
+
+// Summary aggregates the history per system, slowest average first.
+func (p *Profiler) Summary() []SystemStat {
+	type key struct{ system, kind string }
+	stats := make(map[key]*SystemStat)
+	var total = make(map[key]time.Duration)
+	var allocs = make(map[key]uint64)
+
+	for _, f := range p.Frames() {
+		for _, s := range f.Samples {
+			k := key{s.System, s.Kind}
+			st, ok := stats[k]
+			if !ok {
+				st = &SystemStat{System: s.System, Kind: s.Kind}
+				stats[k] = st
+			}
+			st.Calls++
+			total[k] += s.Duration
+			allocs[k] += s.Allocs
+			if s.Duration > st.Max {
+				st.Max = s.Duration
+			}
+		}
+	}
+
+	out := make([]SystemStat, 0, len(stats))
+	for k, st := range stats {
+		st.Average = total[k] / time.Duration(st.Calls)
+		st.AvgAllocs = float64(allocs[k]) / float64(st.Calls)
+		out = append(out, *st)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Average != out[j].Average {
+			return out[i].Average > out[j].Average
+		}
+		return out[i].System+out[i].Kind < out[j].System+out[j].Kind
+	})
+	return out
+}
+
+func (p *Profiler) openFrame() {
+	p.frameNo++
+	p.current = &FrameProfile{Frame: p.frameNo, Start: time.Now()}
+}
+
+func (p *Profiler) closeFrame() {
+	if p.current == nil {
+		return
+	}
+	p.current.Duration = time.Since(p.current.Start)
+	p.frames[p.next] = *p.current
+	p.next = (p.next + 1) % len(p.frames)
+	if p.count < len(p.frames) {
+		p.count++
+	}
+	p.current = nil
+}
+
+// measure times fn and records it as a sample of the current frame.
+func (p *Profiler) measure(w *World, sample SystemSample, fn func()) {
+	objs0, bytes0 := readAllocs()
+	start := time.Now()
+	fn()
+	sample.Duration = time.Since(start)
+	objs1, bytes1 := readAllocs()
+	sample.Allocs = objs1 - objs0
+	sample.Bytes = bytes1 - bytes0
+	sample.Entities = len(w.Entities)
+
+	p.mu.Lock()
+	if p.current == nil {
+		p.openFrame()
+	}
+	sample.Start = start.Sub(p.current.Start)
+	p.current.Samples = append(p.current.Samples, sample)
+	p.mu.Unlock()
+}
+
+var allocMetrics = []string{"/gc/heap/allocs:objects", "/gc/heap/allocs:bytes"}
+
+// readAllocs reads cumulative heap allocations without stopping the world.
+func readAllocs() (objects, bytes uint64) {
+	samples := [2]metrics.Sample{{Name: allocMetrics[0]}, {Name: allocMetrics[1]}}
+	metrics.Read(samples[:])
+	if samples[0].Value.Kind() == metrics.KindUint64 {
+		objects = samples[0].Value.Uint64()
+	}
+	if samples[1].Value.Kind() == metrics.KindUint64 {
+		bytes = samples[1].Value.Uint64()
+	}
+	return objects, bytes
+}
+
+/*───────────────────────────────────────────────*
+| CHROME TRACE EXPORT                           |
+*───────────────────────────────────────────────*/
+
+type traceEvent struct {
+	Name string         `json:"name"`
+	Cat  string         `json:"cat"`
+	Ph   string         `json:"ph"`
+	Ts   float64        `json:"ts"`
+	Dur  float64        `json:"dur"`
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// WriteChromeTrace writes the frame history in Chrome trace-event format,
+// loadable in chrome://tracing or Perfetto.
+func (p *Profiler) WriteChromeTrace(out io.Writer) error {
+	frames := p.Frames()
+	events := make([]traceEvent, 0, 64)
+	if len(frames) > 0 {
+		origin := frames[0].Start
+		for _, f := range frames {
+			base := f.Start.Sub(origin)
+			events = append(events, traceEvent{
+				Name: "frame",
+				Cat:  "frame",
+				Ph:   "X",
+				Ts:   micros(base),
+				Dur:  micros(f.Duration),
+				Pid:  1,
+				Tid:  0,
+				Args: map[string]any{"frame": f.Frame},
+			})
+			for _, s := range f.Samples {
+				events = append(events, traceEvent{
+					Name: s.System,
+					Cat:  s.Kind,
+					Ph:   "X",
+					Ts:   micros(base + s.Start),
+					Dur:  micros(s.Duration),
+					Pid:  1,
+					Tid:  s.Lane + 1,
+					Args: map[string]any{
+						"phase":    s.Phase.String(),
+						"allocs":   s.Allocs,
+						"bytes":    s.Bytes,
+						"entities": s.Entities,
+					},
+				})
+			}
+		}
+	}
+	return json.NewEncoder(out).Encode(map[string]any{
+		"traceEvents":     events,
+		"displayTimeUnit": "ms",
+	})
+}
+
+// ExportChromeTrace writes WriteChromeTrace output to path.
+func (p *Profiler) ExportChromeTrace(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	buf := bufio.NewWriter(f)
+	if err := p.WriteChromeTrace(buf); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+func micros(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
+
+/*───────────────────────────────────────────────*
+| WORLD INTEGRATION                             |
+*───────────────────────────────────────────────*/
+
+// SetProfiler attaches p (or detaches with nil) to record every system call.
+func (w *World) SetProfiler(p *Profiler) {
+	w.profiler = p
+}
+
+// Profiler returns the attached profiler, or nil when profiling is off.
+func (w *World) Profiler() *Profiler {
+	return w.profiler
+}
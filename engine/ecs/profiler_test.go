@@ -0,0 +1,49 @@
+package ecs
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestProfilerKeepsRecentFramesAndExportsTrace(t *testing.T) {
+	w := NewWorld()
+	w.SetProfiler(NewProfiler(3))
+	var log []string
+	w.AddSystem(&recordingSystem{name: "sim", log: &log})
+
+	for i := 0; i < 5; i++ {
+		w.Advance(DefaultStep)
+	}
+	w.Profiler().BeginFrame() // close the last frame
+
+	frames := w.Profiler().Frames()
+	if len(frames) != 3 || frames[0].Frame != 3 || frames[2].Frame != 5 {
+		t.Fatalf("expected the last 3 of 5 frames, got %d frames", len(frames))
+	}
+	for _, f := range frames {
+		if len(f.Samples) != 1 || f.Samples[0].System != "recordingSystem" || f.Samples[0].Kind != SampleUpdate {
+			t.Fatalf("expected one update sample per frame, got %+v", f.Samples)
+		}
+	}
+	if stats := w.Profiler().Summary(); len(stats) != 1 || stats[0].Calls != 3 {
+		t.Fatalf("expected summary over 3 calls, got %+v", stats)
+	}
+
+	var buf bytes.Buffer
+	if err := w.Profiler().WriteChromeTrace(&buf); err != nil {
+		t.Fatalf("trace export failed: %v", err)
+	}
+	var trace struct {
+		TraceEvents []struct {
+			Name string `json:"name"`
+			Ph   string `json:"ph"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatalf("trace is not valid JSON: %v", err)
+	}
+	if len(trace.TraceEvents) != 6 || trace.TraceEvents[1].Name != "recordingSystem" || trace.TraceEvents[1].Ph != "X" {
+		t.Fatalf("expected frame and system events, got %+v", trace.TraceEvents)
+	}
+}
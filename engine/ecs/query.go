@@ -0,0 +1,175 @@
+package ecs
+
+/*───────────────────────────────────────────────*
+| TYPED QUERIES                                 |
+*───────────────────────────────────────────────*/
+
+// componentName derives the storage key for a component type from the
+// Name() of its zero value, e.g. (*Position)(nil).Name() == "Position".
+func componentName[T Component]() string {
+	var zero T
+	return zero.Name()
+}
+
+// Query iterates every entity owning component A.
+type Query[A Component] struct {
+	world *World
+	a     string
+}
+
+// NewQuery builds a typed single-component query over the world.
+func NewQuery[A Component](w *World) Query[A] {
+	return Query[A]{world: w, a: componentName[A]()}
+}
+
+// Each calls fn for every entity owning A, in component insertion order.
+func (q Query[A]) Each(fn func(*Entity, A)) {
+	s := q.world.store(q.a)
+	if s == nil || fn == nil {
+		return
+	}
+	for i := 0; i < len(s.dense); i++ {
+		if s.owners[i].destroyed {
+			continue
+		}
+		if a, ok := s.dense[i].(A); ok {
+			fn(s.owners[i], a)
+		}
+	}
+}
+
+// First returns the first entity owning A along with the component.
+func (q Query[A]) First() (*Entity, A) {
+	var zero A
+	s := q.world.store(q.a)
+	if s == nil {
+		return nil, zero
+	}
+	for i := range s.dense {
+		if s.owners[i].destroyed {
+			continue
+		}
+		if a, ok := s.dense[i].(A); ok {
+			return s.owners[i], a
+		}
+	}
+	return nil, zero
+}
+
+// Count reports how many entities own A.
+func (q Query[A]) Count() int {
+	n := 0
+	q.Each(func(*Entity, A) { n++ })
+	return n
+}
+
+// Query2 iterates every entity owning both A and B.
+type Query2[A, B Component] struct {
+	world *World
+	a, b  string
+}
+
+// NewQuery2 builds a typed two-component query over the world.
+func NewQuery2[A, B Component](w *World) Query2[A, B] {
+	return Query2[A, B]{world: w, a: componentName[A](), b: componentName[B]()}
+}
+
+// Each calls fn for every entity owning A and B. The smaller store drives
+// iteration and the other is probed through its sparse index.
+func (q Query2[A, B]) Each(fn func(*Entity, A, B)) {
+	sa, sb := q.world.store(q.a), q.world.store(q.b)
+	if sa == nil || sb == nil || fn == nil {
+		return
+	}
+	if sb.len() < sa.len() {
+		for i := 0; i < len(sb.dense); i++ {
+			b, ok := sb.dense[i].(B)
+			if !ok {
+				continue
+			}
+			e := sb.owners[i]
+			if e.destroyed {
+				continue
+			}
+			ca, found := sa.get(e.ID)
+			if !found {
+				continue
+			}
+			if a, ok := ca.(A); ok {
+				fn(e, a, b)
+			}
+		}
+		return
+	}
+	for i := 0; i < len(sa.dense); i++ {
+		a, ok := sa.dense[i].(A)
+		if !ok {
+			continue
+		}
+		e := sa.owners[i]
+		if e.destroyed {
+			continue
+		}
+		cb, found := sb.get(e.ID)
+		if !found {
+			continue
+		}
+		if b, ok := cb.(B); ok {
+			fn(e, a, b)
+		}
+	}
+}
+
+// Count reports how many entities own both A and B.
+func (q Query2[A, B]) Count() int {
+	n := 0
+	q.Each(func(*Entity, A, B) { n++ })
+	return n
+}
+
+// Query3 iterates every entity owning A, B and C.
+type Query3[A, B, C Component] struct {
+	world   *World
+	a, b, c string
+}
+
+// NewQuery3 builds a typed three-component query over the world.
+func NewQuery3[A, B, C Component](w *World) Query3[A, B, C] {
+	return Query3[A, B, C]{
+		world: w,
+		a:     componentName[A](),
+		b:     componentName[B](),
+		c:     componentName[C](),
+	}
+}
+
+// Each calls fn for every entity owning A, B and C, driven by the A store.
+func (q Query3[A, B, C]) Each(fn func(*Entity, A, B, C)) {
+	sa, sb, sc := q.world.store(q.a), q.world.store(q.b), q.world.store(q.c)
+	if sa == nil || sb == nil || sc == nil || fn == nil {
+		return
+	}
+	for i := 0; i < len(sa.dense); i++ {
+		a, ok := sa.dense[i].(A)
+		if !ok {
+			continue
+		}
+		e := sa.owners[i]
+		if e.destroyed {
+			continue
+		}
+		cb, found := sb.get(e.ID)
+		if !found {
+			continue
+		}
+		cc, found := sc.get(e.ID)
+		if !found {
+			continue
+		}
+		b, okB := cb.(B)
+		c, okC := cc.(C)
+		if okB && okC {
+			fn(e, a, b, c)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package ecs
+
+import "testing"
+
+func TestQuery2VisitsOnlyMatchingEntities(t *testing.T) {
+	w := NewWorld()
+
+	mover := w.NewEntity()
+	mover.Add(&Position{X: 1, Y: 2})
+	mover.Add(&Velocity{VX: 3, VY: 4})
+
+	static := w.NewEntity()
+	static.Add(&Position{X: 5, Y: 6})
+
+	drifter := w.NewEntity()
+	drifter.Add(&Velocity{VX: 1})
+
+	var visited []EntityID
+	NewQuery2[*Position, *Velocity](w).Each(func(e *Entity, pos *Position, vel *Velocity) {
+		visited = append(visited, e.ID)
+		if pos != mover.Get("Position") || vel != mover.Get("Velocity") {
+			t.Fatalf("query returned components not owned by entity %d", e.ID)
+		}
+	})
+	if len(visited) != 1 || visited[0] != mover.ID {
+		t.Fatalf("expected only entity %d, got %v", mover.ID, visited)
+	}
+
+	if got := NewQuery[*Position](w).Count(); got != 2 {
+		t.Fatalf("expected 2 positions, got %d", got)
+	}
+	if got := GetTyped[*Position](static, "Position"); got == nil || got.X != 5 {
+		t.Fatalf("expected GetTyped compatibility path to keep working, got %+v", got)
+	}
+}
+
+func TestComponentStoresTrackRemoval(t *testing.T) {
+	w := NewWorld()
+
+	a := w.NewEntity()
+	a.Add(&Position{X: 1})
+	b := w.NewEntity()
+	b.Add(&Position{X: 2})
+	c := w.NewEntity()
+	c.Add(&Position{X: 3})
+
+	b.Remove("Position")
+	w.RemoveEntity(a)
+
+	var xs []float64
+	NewQuery[*Position](w).Each(func(_ *Entity, p *Position) {
+		xs = append(xs, p.X)
+	})
+	if len(xs) != 1 || xs[0] != 3 {
+		t.Fatalf("expected only entity c to remain, got %v", xs)
+	}
+
+	replacement := &Position{X: 9}
+	c.Add(replacement)
+	if _, p := NewQuery[*Position](w).First(); p != replacement {
+		t.Fatalf("expected replaced component to be visible to queries")
+	}
+	if w.ComponentCount("Position") != 1 {
+		t.Fatalf("expected replacement not to duplicate store entries")
+	}
+}
+
+func TestQueriesSkipDestroyedEntities(t *testing.T) {
+	w := NewWorld()
+
+	doomed := w.NewEntity()
+	doomed.Add(&Position{X: 1})
+	kept := w.NewEntity()
+	kept.Add(&Position{X: 2})
+
+	w.Commands().Destroy(doomed)
+
+	if e, _ := NewQuery[*Position](w).First(); e != kept {
+		t.Fatalf("expected First to skip the destroyed entity, got %v", e)
+	}
+	if got := NewQuery[*Position](w).Count(); got != 1 {
+		t.Fatalf("expected 1 live position, got %d", got)
+	}
+	if e, _ := w.EntitiesManager().FirstComponent("Position"); e != kept {
+		t.Fatalf("expected FirstComponent to skip the destroyed entity, got %v", e)
+	}
+
+	w.Commands().Destroy(kept)
+	if e, p := NewQuery[*Position](w).First(); e != nil || p != nil {
+		t.Fatalf("expected no live position, got %v", e)
+	}
+	if e, c := w.EntitiesManager().FirstComponent("Position"); e != nil || c != nil {
+		t.Fatalf("expected no live position component, got %v", e)
+	}
+}
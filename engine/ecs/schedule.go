@@ -0,0 +1,215 @@
+package ecs
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*───────────────────────────────────────────────*
+| COMPONENT ACCESS DECLARATIONS                 |
+*───────────────────────────────────────────────*/
+
+// Access lists the component names a system reads and writes during Update.
+type Access struct {
+	Reads  []string
+	Writes []string
+}
+
+// AccessSystem is implemented by systems that declare their component
+// access. Declared systems whose sets don't conflict may run concurrently,
+// so they must not mutate world structure directly (use World.Commands).
+// Systems without a declaration run alone.
+type AccessSystem interface {
+	System
+	Access() Access
+}
+
+// conflicts reports whether two access sets touch a shared component with
+// at least one writer.
+func (a Access) conflicts(b Access) bool {
+	return overlaps(a.Writes, b.Writes) || overlaps(a.Writes, b.Reads) || overlaps(a.Reads, b.Writes)
+}
+
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+/*───────────────────────────────────────────────*
+| STAGE PLANNING                                |
+*───────────────────────────────────────────────*/
+
+// stage is a set of systems that may run concurrently.
+type stage struct {
+	phase     Phase
+	entries   []systemEntry
+	exclusive bool // holds an undeclared system; nothing may join it
+}
+
+// buildStages places each system, in phase and priority order, one stage
+// after the latest stage holding a conflicting system. Undeclared systems
+// conflict with everything and act as barriers, as do phase boundaries.
+func buildStages(entries []systemEntry) []stage {
+	var stages []stage
+	barrier := -1 // index of the last exclusive stage
+	for i, entry := range entries {
+		if i > 0 && entry.phase != entries[i-1].phase {
+			barrier = len(stages) - 1
+		}
+		as, declared := entry.system.(AccessSystem)
+		if !declared {
+			stages = append(stages, stage{phase: entry.phase, entries: []systemEntry{entry}, exclusive: true})
+			barrier = len(stages) - 1
+			continue
+		}
+
+		access := as.Access()
+		target := barrier + 1
+		for j := len(stages) - 1; j > barrier; j-- {
+			if stageConflicts(stages[j], access) {
+				target = j + 1
+				break
+			}
+		}
+		if target == len(stages) {
+			stages = append(stages, stage{phase: entry.phase})
+		}
+		stages[target].entries = append(stages[target].entries, entry)
+	}
+	return stages
+}
+
+func stageConflicts(st stage, access Access) bool {
+	for _, e := range st.entries {
+		if other, ok := e.system.(AccessSystem); ok && other.Access().conflicts(access) {
+			return true
+		}
+	}
+	return false
+}
+
+/*───────────────────────────────────────────────*
+| WORLD SCHEDULER                               |
+*───────────────────────────────────────────────*/
+
+// SetWorkers bounds how many systems of one stage run at once. Values
+// below 1 use GOMAXPROCS; 1 runs every system serially.
+func (w *World) SetWorkers(n int) {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	w.workers = n
+}
+
+// Stages returns the computed execution stages, including those of
+// currently disabled systems and phases. Systems within a stage may
+// run concurrently; stages run in order with a sync point between them.
+func (w *World) Stages() [][]System {
+	stages := w.schedule()
+	out := make([][]System, len(stages))
+	for i, st := range stages {
+		for _, e := range st.entries {
+			out[i] = append(out[i], e.system)
+		}
+	}
+	return out
+}
+
+// ScheduleSummary renders the stages as one line each for debug overlays.
+func (w *World) ScheduleSummary() []string {
+	stages := w.schedule()
+	lines := make([]string, 0, len(stages))
+	for i, st := range stages {
+		names := make([]string, len(st.entries))
+		for j, e := range st.entries {
+			names[j] = SystemName(e.system)
+			if e.control.disabled {
+				names[j] += " (off)"
+			}
+		}
+		mode := ""
+		if len(st.entries) > 1 {
+			mode = " ∥"
+		}
+		phase := st.phase.String()
+		if !w.PhaseEnabled(st.phase) {
+			phase += " (off)"
+		}
+		lines = append(lines, fmt.Sprintf("S%d %s%s %s", i+1, phase, mode, strings.Join(names, ", ")))
+	}
+	return lines
+}
+
+func (w *World) schedule() []stage {
+	if w.stages == nil {
+		w.stages = buildStages(w.systemEntries)
+	}
+	return w.stages
+}
+
+func (w *World) runStage(st stage) {
+	// Evaluate run conditions up front, serially.
+	active := make([]systemEntry, 0, len(st.entries))
+	for _, e := range st.entries {
+		if e.control.shouldRun(w) {
+			active = append(active, e)
+		}
+	}
+	st.entries = active
+
+	if len(st.entries) <= 1 || w.workers == 1 {
+		for _, e := range st.entries {
+			w.runSystem(e, 0)
+		}
+		return
+	}
+
+	workers := w.workers
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, e := range st.entries {
+		entry, lane := e, i%workers
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			w.runSystem(entry, lane)
+		}()
+	}
+	wg.Wait()
+}
+
+// runSystem updates one system; lane is its concurrent slot in the stage,
+// used to lay out profiler traces.
+func (w *World) runSystem(e systemEntry, lane int) {
+	s := e.system
+	update := func() { s.Update(w) }
+	if w.profiler != nil {
+		sample := SystemSample{System: SystemName(s), Kind: SampleUpdate, Phase: e.phase, Lane: lane}
+		update = func() { w.profiler.measure(w, sample, func() { s.Update(w) }) }
+	}
+	if !EnableProfiling {
+		update()
+		return
+	}
+	start := time.Now()
+	update()
+	if elapsed := time.Since(start); elapsed > 2*time.Millisecond {
+		fmt.Printf("[ECS] %s took %v\n", SystemName(s), elapsed)
+	}
+}
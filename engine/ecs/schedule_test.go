@@ -0,0 +1,106 @@
+package ecs
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+type declaredSystem struct {
+	access Access
+	wait   *sync.WaitGroup // when set, blocks until every peer has started
+	ran    bool
+}
+
+func (s *declaredSystem) Access() Access { return s.access }
+
+func (s *declaredSystem) Update(*World) {
+	if s.wait != nil {
+		s.wait.Done()
+		s.wait.Wait()
+	}
+	s.ran = true
+}
+
+type plainSystem struct{}
+
+func (plainSystem) Update(*World) {}
+
+func TestStagesGroupNonConflictingSystems(t *testing.T) {
+	w := NewWorld()
+	ai := &declaredSystem{access: Access{Reads: []string{"Position"}, Writes: []string{"Velocity"}}}
+	hud := &declaredSystem{access: Access{Reads: []string{"Position", "Actor"}, Writes: []string{"Window"}}}
+	move := &declaredSystem{access: Access{Reads: []string{"Velocity"}, Writes: []string{"Position"}}}
+	barrier := plainSystem{}
+	late := &declaredSystem{access: Access{Reads: []string{"Actor"}}}
+
+	for _, s := range []System{ai, hud, move, barrier, late} {
+		w.AddSystem(s)
+	}
+
+	stages := w.Stages()
+	if len(stages) != 4 {
+		t.Fatalf("expected 4 stages, got %v", w.ScheduleSummary())
+	}
+	if len(stages[0]) != 2 || stages[0][0] != System(ai) || stages[0][1] != System(hud) {
+		t.Fatalf("expected ai and hud to share the first stage, got %v", w.ScheduleSummary())
+	}
+	if stages[1][0] != System(move) || stages[2][0] != System(barrier) || stages[3][0] != System(late) {
+		t.Fatalf("expected conflicting and undeclared systems to stay ordered, got %v", w.ScheduleSummary())
+	}
+}
+
+func TestParallelStageRunsSystemsConcurrently(t *testing.T) {
+	w := NewWorld()
+	w.SetWorkers(2)
+
+	var started sync.WaitGroup
+	started.Add(2)
+	a := &declaredSystem{access: Access{Writes: []string{"A"}}, wait: &started}
+	b := &declaredSystem{access: Access{Writes: []string{"B"}}, wait: &started}
+	w.AddSystem(a)
+	w.AddSystem(b)
+
+	// Each system blocks until both have started, so this only returns if
+	// the stage really ran them at the same time.
+	w.Update()
+	if !a.ran || !b.ran {
+		t.Fatalf("expected both systems to run")
+	}
+}
+
+type recordingSystem struct {
+	name string
+	log  *[]string
+}
+
+func (s *recordingSystem) Update(*World) { *s.log = append(*s.log, s.name) }
+
+func TestPhasesOrderAndGateSystems(t *testing.T) {
+	w := NewWorld()
+	var log []string
+	overlay := &recordingSystem{name: "overlay", log: &log}
+	sim := &recordingSystem{name: "sim", log: &log}
+	pre := &recordingSystem{name: "pre", log: &log}
+	gated := &recordingSystem{name: "gated", log: &log}
+
+	w.AddSystemTo(PhaseOverlay, overlay)
+	w.AddSystem(sim) // defaults to PhaseSimulation
+	w.AddSystemTo(PhasePreUpdate, pre)
+	open := false
+	w.AddSystemTo(PhasePostUpdate, gated, func(*World) bool { return open })
+
+	w.Update()
+	if got := strings.Join(log, ","); got != "pre,sim,overlay" {
+		t.Fatalf("expected phase order with gated system skipped, got %s", got)
+	}
+
+	log = nil
+	open = true
+	w.SetPhaseEnabled(PhaseSimulation, false)
+	w.SetSystemEnabled(overlay, false)
+	w.Update()
+	if got := strings.Join(log, ","); got != "pre,gated" {
+		t.Fatalf("expected disabled phase and system to be skipped, got %s", got)
+	}
+}
@@ -0,0 +1,117 @@
+package ecs
+
+/*───────────────────────────────────────────────*
+| COMPONENT STORAGE                             |
+*───────────────────────────────────────────────*/
+
+// componentStore is a sparse set holding every instance of one component
+// name in a dense slice. Typed queries walk the dense slice directly so
+// systems only visit entities that actually own the component.
+type componentStore struct {
+	name   string
+	dense  []Component
+	owners []*Entity
+	sparse map[EntityID]int
+}
+
+func newComponentStore(name string) *componentStore {
+	return &componentStore{
+		name:   name,
+		dense:  make([]Component, 0, 64),
+		owners: make([]*Entity, 0, 64),
+		sparse: make(map[EntityID]int, 64),
+	}
+}
+
+// set inserts or replaces the component owned by e.
+func (s *componentStore) set(e *Entity, c Component) {
+	if idx, ok := s.sparse[e.ID]; ok {
+		s.dense[idx] = c
+		s.owners[idx] = e
+		return
+	}
+	s.sparse[e.ID] = len(s.dense)
+	s.dense = append(s.dense, c)
+	s.owners = append(s.owners, e)
+}
+
+// get returns the component owned by the entity ID, if any.
+func (s *componentStore) get(id EntityID) (Component, bool) {
+	idx, ok := s.sparse[id]
+	if !ok {
+		return nil, false
+	}
+	return s.dense[idx], true
+}
+
+// remove drops the component owned by the entity ID. The tail is shifted
+// down rather than swapped so iteration keeps insertion (draw) order.
+func (s *componentStore) remove(id EntityID) {
+	idx, ok := s.sparse[id]
+	if !ok {
+		return
+	}
+	delete(s.sparse, id)
+
+	copy(s.dense[idx:], s.dense[idx+1:])
+	s.dense[len(s.dense)-1] = nil
+	s.dense = s.dense[:len(s.dense)-1]
+
+	copy(s.owners[idx:], s.owners[idx+1:])
+	s.owners[len(s.owners)-1] = nil
+	s.owners = s.owners[:len(s.owners)-1]
+
+	for i := idx; i < len(s.owners); i++ {
+		s.sparse[s.owners[i].ID] = i
+	}
+}
+
+// len reports how many entities own this component.
+func (s *componentStore) len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.dense)
+}
+
+/*───────────────────────────────────────────────*
+| WORLD STORE ACCESS                            |
+*───────────────────────────────────────────────*/
+
+// store returns the component store for name, or nil when none exists yet.
+func (w *World) store(name string) *componentStore {
+	if w == nil || w.stores == nil {
+		return nil
+	}
+	return w.stores[name]
+}
+
+// ensureStore returns the component store for name, creating it on demand.
+func (w *World) ensureStore(name string) *componentStore {
+	if w.stores == nil {
+		w.stores = make(map[string]*componentStore, 16)
+	}
+	s, ok := w.stores[name]
+	if !ok {
+		s = newComponentStore(name)
+		w.stores[name] = s
+	}
+	return s
+}
+
+// detachComponents removes every component of e from the world stores.
+func (w *World) detachComponents(e *Entity) {
+	if w == nil || e == nil {
+		return
+	}
+	for name := range e.Components {
+		if s := w.store(name); s != nil {
+			s.remove(e.ID)
+		}
+	}
+}
+
+// ComponentCount reports how many live entities own the named component.
+func (w *World) ComponentCount(name string) int {
+	return w.store(name).len()
+}
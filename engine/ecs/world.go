@@ -3,7 +3,6 @@ package ecs
 import (
 	"fmt"
 	"sort"
-	"time"
 
 	"rp-go/engine/platform"
 )
@@ -14,14 +13,25 @@ import (
 
 // World owns all entities, systems, and draw layers.
 type World struct {
-	nextID        EntityID
 	Entities      []*Entity
 	entitiesByID  map[EntityID]*Entity
 	Systems       []System
 	EventBus      any
 	entityManager *EntityManager
+	stores        map[string]*componentStore
+	commands      *CommandBuffer
+	observers     *observers
+	clock         *Clock
+	profiler      *Profiler // optional per-system timing history
+
+	generations []uint32 // current generation per entity slot
+	freeSlots   []int    // destroyed slots awaiting reuse (FIFO)
+	updating    bool     // true while systems run; removals are deferred
 
 	systemEntries []systemEntry
+	stages        []stage // cached schedule; rebuilt after AddSystem
+	workers       int     // max concurrent systems per stage
+	phases        [phaseCount]runControl
 	drawBuckets   map[DrawLayer][]drawEntry
 	worldLayers   []DrawLayer
 	overlayLayers []DrawLayer
@@ -30,8 +40,10 @@ type World struct {
 
 type systemEntry struct {
 	system   System
+	phase    Phase
 	priority int
 	order    int
+	control  *runControl
 }
 
 type drawEntry struct {
@@ -53,7 +65,11 @@ func NewWorld() *World {
 		Entities:      make([]*Entity, 0, 256),
 	}
 	w.entitiesByID = make(map[EntityID]*Entity, 256)
+	w.stores = make(map[string]*componentStore, 16)
 	w.entityManager = newEntityManager(w)
+	w.commands = &CommandBuffer{}
+	w.clock = NewClock()
+	w.SetWorkers(0)
 	return w
 }
 
@@ -62,8 +78,8 @@ func NewWorld() *World {
 *───────────────────────────────────────────────*/
 
 func (w *World) NewEntity() *Entity {
-	e := NewEntity(w.nextID)
-	w.nextID++
+	e := NewEntity(w.allocID())
+	e.world = w
 	w.Entities = append(w.Entities, e)
 	if w.entitiesByID == nil {
 		w.entitiesByID = make(map[EntityID]*Entity, len(w.Entities))
@@ -72,19 +88,18 @@ func (w *World) NewEntity() *Entity {
 	return e
 }
 
+// RemoveEntity destroys target. While systems are updating the removal is
+// queued on the command buffer and applied at the next sync point, so it is
+// safe to call from inside EntityManager.ForEach.
 func (w *World) RemoveEntity(target *Entity) {
 	if w == nil || target == nil {
 		return
 	}
-	for i, e := range w.Entities {
-		if e == target {
-			w.Entities = append(w.Entities[:i], w.Entities[i+1:]...)
-			if w.entitiesByID != nil {
-				delete(w.entitiesByID, target.ID)
-			}
-			break
-		}
+	if w.updating {
+		w.Commands().Destroy(target)
+		return
 	}
+	w.destroyEntity(target)
 }
 
 func (w *World) RemoveEntityByID(id EntityID) {
@@ -107,6 +122,64 @@ func (w *World) GetEntity(id EntityID) *Entity {
 	return w.entitiesByID[id]
 }
 
+// IsAlive reports whether id refers to a live entity of the current
+// generation that has not been queued for destruction.
+func (w *World) IsAlive(id EntityID) bool {
+	if w == nil {
+		return false
+	}
+	idx := id.Index()
+	if idx < 0 || idx >= len(w.generations) || w.generations[idx] != id.Generation() {
+		return false
+	}
+	e := w.entitiesByID[id]
+	return e != nil && !e.destroyed
+}
+
+// destroyEntity splices target out of the world and recycles its slot.
+func (w *World) destroyEntity(target *Entity) {
+	if target.world != w {
+		return
+	}
+	w.detachHierarchy(target)
+	w.notifyDestroyed(target)
+	for i, e := range w.Entities {
+		if e == target {
+			w.Entities = append(w.Entities[:i], w.Entities[i+1:]...)
+			break
+		}
+	}
+	if w.entitiesByID != nil {
+		delete(w.entitiesByID, target.ID)
+	}
+	w.detachComponents(target)
+	w.releaseID(target.ID)
+	target.world = nil
+	target.destroyed = true
+}
+
+// allocID hands out a fresh slot, reusing destroyed ones with a new generation.
+func (w *World) allocID() EntityID {
+	if len(w.freeSlots) > 0 {
+		idx := w.freeSlots[0]
+		w.freeSlots = w.freeSlots[1:]
+		return makeEntityID(idx, w.generations[idx])
+	}
+	idx := len(w.generations)
+	w.generations = append(w.generations, 0)
+	return makeEntityID(idx, 0)
+}
+
+// releaseID bumps the slot generation so outstanding IDs become stale.
+func (w *World) releaseID(id EntityID) {
+	idx := id.Index()
+	if idx < 0 || idx >= len(w.generations) || w.generations[idx] != id.Generation() {
+		return
+	}
+	w.generations[idx]++
+	w.freeSlots = append(w.freeSlots, idx)
+}
+
 // EntitiesManager returns the entity manager for iteration utilities.
 func (w *World) EntitiesManager() *EntityManager {
 	if w == nil {
@@ -122,19 +195,27 @@ func (w *World) EntitiesManager() *EntityManager {
 | SYSTEM MANAGEMENT                            |
 *───────────────────────────────────────────────*/
 
-// AddSystem registers a new system into the ECS world.
+// AddSystem registers a new system into the ECS world, in the phase it
+// reports via PhasedSystem (PhaseSimulation otherwise).
 func (w *World) AddSystem(s System) {
 	if s == nil {
 		return
 	}
+	w.addSystem(s, systemPhase(s))
+}
+
+func (w *World) addSystem(s System, phase Phase) {
 	entry := systemEntry{
 		system:   s,
+		phase:    phase,
 		priority: systemPriority(s),
 		order:    w.nextOrder,
+		control:  &runControl{},
 	}
 	w.nextOrder++
 	w.systemEntries = append(w.systemEntries, entry)
 	stableSortSystems(w.systemEntries)
+	w.stages = nil
 
 	// Register drawable system if applicable
 	if drawable, ok := s.(DrawableSystem); ok {
@@ -187,18 +268,21 @@ func sameType(a, b any) bool {
 
 var EnableProfiling bool // Toggle profiling per system
 
+// Update runs every system in phase and priority order, grouped into
+// stages by their declared Access. Disabled systems and phases, and those
+// whose run conditions fail, are skipped. Structural changes queued during a stage are
+// applied before the next stage runs. Use Advance to drive Update from real
+// frame time via the fixed-step Clock.
 func (w *World) Update() {
-	for _, entry := range w.systemEntries {
-		if EnableProfiling {
-			start := time.Now()
-			entry.system.Update(w)
-			elapsed := time.Since(start)
-			if elapsed > 2*time.Millisecond {
-				fmt.Printf("[ECS] %s took %v\n", SystemName(entry.system), elapsed)
-			}
-		} else {
-			entry.system.Update(w)
+	w.updating = true
+	defer func() { w.updating = false }()
+
+	for _, st := range w.schedule() {
+		if !w.phaseShouldRun(st.phase) {
+			continue
 		}
+		w.runStage(st)
+		w.Flush()
 	}
 }
 
@@ -229,9 +313,16 @@ func (w *World) drawLayerGroup(screen *platform.Image, layers []DrawLayer) {
 	for _, layer := range layers {
 		if entries, ok := w.drawBuckets[layer]; ok {
 			for _, entry := range entries {
-				if entry.system != nil {
+				if entry.system == nil {
+					continue
+				}
+				if w.profiler == nil {
 					entry.system.Draw(w, screen)
+					continue
 				}
+				sys := entry.system
+				sample := SystemSample{System: SystemName(sys), Kind: SampleDraw, Phase: PhaseRender}
+				w.profiler.measure(w, sample, func() { sys.Draw(w, screen) })
 			}
 		}
 	}
@@ -280,6 +371,9 @@ func resolveLayer(s System) DrawLayer {
 
 func stableSortSystems(entries []systemEntry) {
 	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].phase != entries[j].phase {
+			return entries[i].phase < entries[j].phase
+		}
 		if entries[i].priority == entries[j].priority {
 			return entries[i].order < entries[j].order
 		}
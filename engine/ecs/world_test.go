@@ -0,0 +1,128 @@
+package ecs
+
+import "testing"
+
+type removeAllSystem struct{}
+
+func (removeAllSystem) Update(w *World) {
+	w.EntitiesManager().ForEach(func(e *Entity) {
+		w.RemoveEntity(e)
+	})
+}
+
+type countingSystem struct{ seen int }
+
+func (s *countingSystem) Update(w *World) {
+	s.seen = w.EntitiesManager().Count()
+}
+
+func TestRemoveEntityDuringUpdateIsDeferred(t *testing.T) {
+	w := NewWorld()
+	for i := 0; i < 4; i++ {
+		w.NewEntity().Add(&Position{X: float64(i)})
+	}
+
+	counter := &countingSystem{}
+	w.AddSystem(removeAllSystem{})
+	w.AddSystem(counter)
+	w.Update()
+
+	if counter.seen != 0 {
+		t.Fatalf("expected removals to be applied before the next system, saw %d entities", counter.seen)
+	}
+	if got := w.ComponentCount("Position"); got != 0 {
+		t.Fatalf("expected component stores to be emptied, got %d", got)
+	}
+}
+
+func TestGenerationalIDsInvalidateStaleReferences(t *testing.T) {
+	w := NewWorld()
+
+	first := w.NewEntity()
+	staleID := first.ID
+	w.RemoveEntity(first)
+
+	if w.IsAlive(staleID) || first.Alive() {
+		t.Fatalf("expected removed entity to be dead")
+	}
+
+	reused := w.NewEntity()
+	if reused.ID.Index() != staleID.Index() {
+		t.Fatalf("expected slot %d to be recycled, got %d", staleID.Index(), reused.ID.Index())
+	}
+	if reused.ID == staleID || reused.ID.Generation() != staleID.Generation()+1 {
+		t.Fatalf("expected bumped generation, got %d after %d", reused.ID.Generation(), staleID.Generation())
+	}
+	if w.IsAlive(staleID) {
+		t.Fatalf("stale ID must not resolve to the recycled entity")
+	}
+	if !w.IsAlive(reused.ID) {
+		t.Fatalf("expected recycled entity to be alive")
+	}
+}
+
+func TestCommandBufferSpawnAppliesAtSyncPoint(t *testing.T) {
+	w := NewWorld()
+
+	var spawned *Entity
+	w.Commands().Spawn(func(e *Entity) {
+		e.Add(&Position{X: 7})
+		spawned = e
+	})
+	if spawned != nil || w.EntitiesManager().Count() != 0 {
+		t.Fatalf("expected spawn to wait for Flush")
+	}
+
+	w.Flush()
+	if spawned == nil || !spawned.Alive() {
+		t.Fatalf("expected queued spawn to be applied")
+	}
+	if _, p := NewQuery[*Position](w).First(); p == nil || p.X != 7 {
+		t.Fatalf("expected spawned components to be queryable")
+	}
+}
+
+func TestLifecycleObserversReceiveAddRemoveAndDestroy(t *testing.T) {
+	w := NewWorld()
+	existing := w.NewEntity()
+	existing.Add(&Health{Current: 1, Max: 1})
+
+	var added, removed []EntityID
+	var destroyed []EntityID
+	w.OnComponentAdded("Health", func(e *Entity, _ Component) { added = append(added, e.ID) })
+	w.OnComponentRemoved("Health", func(e *Entity, _ Component) { removed = append(removed, e.ID) })
+	w.OnEntityDestroyed(func(e *Entity) { destroyed = append(destroyed, e.ID) })
+
+	if len(added) != 1 || added[0] != existing.ID {
+		t.Fatalf("expected existing owner to be replayed, got %v", added)
+	}
+
+	fresh := w.NewEntity()
+	fresh.Add(&Health{Current: 2, Max: 2})
+	fresh.Remove("Health")
+	w.RemoveEntity(existing)
+
+	if len(added) != 2 || added[1] != fresh.ID {
+		t.Fatalf("expected add notification for new owner, got %v", added)
+	}
+	if len(removed) != 2 || removed[0] != fresh.ID || removed[1] != existing.ID {
+		t.Fatalf("expected remove notifications for detach and destroy, got %v", removed)
+	}
+	if len(destroyed) != 1 || destroyed[0] != existing.ID {
+		t.Fatalf("expected destroy notification, got %v", destroyed)
+	}
+}
+
+func TestTagsDoNotShadowComponents(t *testing.T) {
+	w := NewWorld()
+	e := w.NewEntity()
+	e.Add(&Health{Current: 5, Max: 10})
+	e.Add(Tag("Health"))
+
+	if _, ok := e.Get("Health").(*Health); !ok {
+		t.Fatalf("expected a tag named Health to leave the Health component alone")
+	}
+	if !e.HasTag("Health") || e.HasTag("Position") {
+		t.Fatalf("expected HasTag to see only tags")
+	}
+}
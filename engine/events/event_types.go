@@ -11,6 +11,43 @@ type EntitySpawnedEvent struct {
 	EntityID int
 }
 
+// AIStateChangedEvent is queued when an entity's AI state machine changes
+// state. From is empty when the machine starts.
+type AIStateChangedEvent struct {
+	EntityID int
+	Machine  string // action name of the machine in ai.json
+	From     string
+	To       string
+}
+
+// CollisionEvent is queued when two solid colliders start touching. The
+// normal points from A towards B; Depth is how far they overlapped before
+// any response. A has the lower entity ID.
+type CollisionEvent struct {
+	A, B             int
+	NormalX, NormalY float64
+	Depth            float64
+}
+
+// CollisionEndedEvent is queued when two solid colliders stop touching.
+type CollisionEndedEvent struct {
+	A, B int
+}
+
+// TriggerEnteredEvent is queued when an entity starts overlapping a
+// trigger collider.
+type TriggerEnteredEvent struct {
+	Trigger int
+	Other   int
+}
+
+// TriggerExitedEvent is queued when an entity stops overlapping a trigger
+// collider.
+type TriggerExitedEvent struct {
+	Trigger int
+	Other   int
+}
+
 type CameraZoomEvent struct {
 	NewScale float64
 }
@@ -20,6 +57,12 @@ type DebugToggleEvent struct {
 	Enabled bool
 }
 
+// DebugToggleWindowEvent shows or hides one debug window by its window ID
+// ("debug.stats", "debug.profiler", ...). Queued by the debug toolbar.
+type DebugToggleWindowEvent struct {
+	ID string
+}
+
 // SceneChangeEvent requests a transition to another scene.
 type SceneChangeEvent struct {
 	Target string // e.g. "space" or "planet"
@@ -60,3 +103,11 @@ type DataReloaded struct {
 	Type string // logical type: render_config, actor_db, etc.
 }
 
+
+// DataReloadFailed is emitted when a changed data file fails validation.
+// The previous data stays active.
+type DataReloadFailed struct {
+	Path     string   // full path of the rejected file
+	Type     string   // logical type: render_config, actor_db, etc.
+	Problems []string // one line per problem, with file:line:col context
+}
@@ -2,7 +2,9 @@ package gfx
 
 import (
 	"encoding/json"
-	"os"
+	"fmt"
+
+	"rp-go/engine/vfs"
 )
 
 type RenderConfig struct {
@@ -10,15 +12,16 @@ type RenderConfig struct {
 	Scale    float64 `json:"scale"`
 }
 
+// LoadRenderConfig reads path from the vfs search path. A missing or broken
+// file logs and yields the zero config instead of panicking.
 func LoadRenderConfig(path string) RenderConfig {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		panic(err)
-	}
 	var cfg RenderConfig
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		panic(err)
+	data, err := vfs.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, &cfg)
+	}
+	if err != nil {
+		fmt.Printf("[GFX] Cannot load render config %s: %v\n", path, err)
 	}
 	return cfg
 }
-
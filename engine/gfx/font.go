@@ -0,0 +1,25 @@
+package gfx
+
+import (
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+
+	"rp-go/engine/vfs"
+)
+
+// DefaultFontPath is the optional UI font the game looks for at startup.
+const DefaultFontPath = "assets/fonts/default.ttf"
+
+// LoadFont reads a TrueType/OpenType font from the vfs search path and
+// returns a face at size points (72 DPI, so points equal pixels).
+func LoadFont(path string, size float64) (font.Face, error) {
+	raw, err := vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := opentype.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
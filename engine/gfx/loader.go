@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"image"
 	_ "image/png"
-	"os"
+	"io"
 	"sync"
 
 	"rp-go/engine/platform"
+	"rp-go/engine/vfs"
 )
 
 // cachedImage wraps a lazily-loaded image resource with one-time initialization.
@@ -20,6 +21,26 @@ type cachedImage struct {
 // imageCache maps image file paths to their cached image objects.
 var imageCache sync.Map // map[string]*cachedImage
 
+// openAsset resolves asset paths on the vfs search path; SetAssetOpener
+// makes it pack-aware.
+var openAsset = openVFS
+
+func openVFS(path string) (io.ReadCloser, error) { return vfs.Open(path) }
+
+// SetAssetOpener routes image loads through open (e.g. mods.Set.Open so
+// packs can override sprites) and drops cached images. Nil restores the
+// vfs search path. Call it at startup, before anything is drawn.
+func SetAssetOpener(open func(path string) (io.ReadCloser, error)) {
+	if open == nil {
+		open = openVFS
+	}
+	openAsset = open
+	imageCache.Range(func(key, _ any) bool {
+		imageCache.Delete(key)
+		return true
+	})
+}
+
 // LoadImage returns an Ebiten-compatible image, caching the decoded result.
 // Repeated calls with the same path reuse the same GPU resource.
 func LoadImage(path string) *platform.Image {
@@ -56,10 +77,10 @@ func PreloadImages(paths ...string) {
 	wg.Wait()
 }
 
-// decodeImage decodes a PNG (or other supported formats) from disk and wraps
-// it in a platform.Image for rendering.
+// decodeImage decodes a PNG (or other supported formats) through openAsset
+// and wraps it in a platform.Image for rendering.
 func decodeImage(path string) (*platform.Image, error) {
-	file, err := os.Open(path)
+	file, err := openAsset(path)
 	if err != nil {
 		return nil, err
 	}
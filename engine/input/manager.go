@@ -9,10 +9,15 @@ import (
 // Manager centralizes polling game input devices and exposes normalized
 // movement vectors for systems to consume.
 type Manager struct {
-	moveX float64
-	moveY float64
+	moveX  float64
+	moveY  float64
+	source Source
 }
 
+// Source supplies a movement vector in place of device polling, e.g. when
+// a replay drives the game.
+type Source func() (float64, float64)
+
 var defaultManager = &Manager{}
 
 // ManagerInstance returns the shared input manager used by the engine.
@@ -20,11 +25,24 @@ func ManagerInstance() *Manager {
 	return defaultManager
 }
 
+// SetSource routes Poll through src instead of the platform devices.
+// Passing nil restores device polling.
+func (m *Manager) SetSource(src Source) {
+	if m == nil {
+		return
+	}
+	m.source = src
+}
+
 // Poll refreshes the cached input state for the current frame.
 func (m *Manager) Poll() {
 	if m == nil {
 		return
 	}
+	if m.source != nil {
+		m.moveX, m.moveY = m.source()
+		return
+	}
 
 	vx, vy := 0.0, 0.0
 
@@ -0,0 +1,244 @@
+package locale
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"rp-go/engine/data"
+)
+
+/*───────────────────────────────────────────────*
+| CATALOG                                       |
+*───────────────────────────────────────────────*/
+
+// LocaleEnv selects the locale at startup, e.g. RPGO_LOCALE=de.
+const LocaleEnv = "RPGO_LOCALE"
+
+// FallbackLocale is consulted for keys the active locale does not define.
+const FallbackLocale = "en"
+
+// Catalog holds every loaded string table and the active locale. Lookups
+// are safe from any goroutine.
+type Catalog struct {
+	mu       sync.RWMutex
+	tables   map[string]data.StringTable
+	active   string
+	fallback string
+}
+
+// Default is the catalog UI code reads through T and N. The data system
+// fills it from strings_<locale>.json.
+var Default = NewCatalog(FallbackLocale)
+
+// NewCatalog returns an empty catalog with locale active.
+func NewCatalog(locale string) *Catalog {
+	return &Catalog{active: locale, fallback: FallbackLocale}
+}
+
+// SetTables replaces every table, e.g. after a hot reload. The active
+// locale is kept even when it has no table any more; lookups fall back.
+func (c *Catalog) SetTables(tables map[string]data.StringTable) {
+	c.mu.Lock()
+	c.tables = tables
+	c.mu.Unlock()
+}
+
+// SetLocale switches the active locale. Unknown codes are rejected.
+func (c *Catalog) SetLocale(code string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.tables[code]; !ok {
+		return fmt.Errorf("unknown locale %q", code)
+	}
+	c.active = code
+	return nil
+}
+
+// Locale returns the active locale code.
+func (c *Catalog) Locale() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.active
+}
+
+// Locales lists the loaded locale codes with their display names.
+func (c *Catalog) Locales() []data.StringTable {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]data.StringTable, 0, len(c.tables))
+	for _, t := range c.tables {
+		out = append(out, data.StringTable{Locale: t.Locale, Name: t.Name})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Locale < out[j].Locale })
+	return out
+}
+
+// Has reports whether key is defined in the active or fallback locale.
+func (c *Catalog) Has(key string) bool {
+	_, _, ok := c.lookup(key)
+	return ok
+}
+
+// lookup finds key in the active locale, then the fallback, returning the
+// locale it was found in.
+func (c *Catalog) lookup(key string) (data.LocalizedString, string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, code := range []string{c.active, c.fallback} {
+		if s, ok := c.tables[code].Entries[key]; ok {
+			return s, code, true
+		}
+	}
+	return data.LocalizedString{}, "", false
+}
+
+/*───────────────────────────────────────────────*
+| LOOKUP                                        |
+*───────────────────────────────────────────────*/
+
+// T returns the text for key with {0}, {1}… replaced by args. Plural
+// entries use their "other" form. A missing key returns the key itself so
+// untranslated text stays visible.
+func (c *Catalog) T(key string, args ...any) string {
+	s, _, ok := c.lookup(key)
+	if !ok {
+		return key
+	}
+	text := s.Text
+	if s.Forms != nil {
+		text = s.Forms["other"]
+	}
+	return format(text, 0, args)
+}
+
+// N returns the plural form of key matching n in the locale it was found
+// in, with {n} replaced by n and {0}, {1}… by args. A "zero" form, when
+// given, wins for 0 in every language. Plain entries are used as is.
+func (c *Catalog) N(key string, n int, args ...any) string {
+	s, code, ok := c.lookup(key)
+	if !ok {
+		return key
+	}
+	text := s.Text
+	if s.Forms != nil {
+		text = s.Forms["other"]
+		if form, ok := s.Forms[pluralCategory(code, n)]; ok {
+			text = form
+		}
+		if form, ok := s.Forms["zero"]; ok && n == 0 {
+			text = form
+		}
+	}
+	return format(text, n, args)
+}
+
+// T looks key up in Default.
+func T(key string, args ...any) string { return Default.T(key, args...) }
+
+// N looks the plural key up in Default.
+func N(key string, n int, args ...any) string { return Default.N(key, n, args...) }
+
+/*───────────────────────────────────────────────*
+| FORMATTING                                    |
+*───────────────────────────────────────────────*/
+
+// format expands placeholders: {n} is the plural count, {i} the i-th arg
+// and {i:.1f} the i-th arg through a fmt verb ("%.1f"). "{{" writes a
+// literal brace; unknown placeholders are left as written.
+func format(text string, n int, args []any) string {
+	if !strings.Contains(text, "{") {
+		return text
+	}
+	var b strings.Builder
+	for {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		b.WriteString(text[:open])
+		text = text[open:]
+		if strings.HasPrefix(text, "{{") {
+			b.WriteByte('{')
+			text = text[2:]
+			continue
+		}
+		end := strings.IndexByte(text, '}')
+		if end < 0 {
+			b.WriteString(text)
+			return b.String()
+		}
+		if s, ok := expand(text[1:end], n, args); ok {
+			b.WriteString(s)
+		} else {
+			b.WriteString(text[:end+1])
+		}
+		text = text[end+1:]
+	}
+}
+
+func expand(name string, n int, args []any) (string, bool) {
+	name, verb, hasVerb := strings.Cut(name, ":")
+	var v any
+	if name == "n" {
+		v = n
+	} else {
+		i, err := strconv.Atoi(name)
+		if err != nil || i < 0 || i >= len(args) {
+			return "", false
+		}
+		v = args[i]
+	}
+	if !hasVerb {
+		return fmt.Sprint(v), true
+	}
+	return fmt.Sprintf("%"+verb, v), true
+}
+
+/*───────────────────────────────────────────────*
+| PLURAL RULES                                  |
+*───────────────────────────────────────────────*/
+
+// pluralCategory picks the CLDR plural category of n for the locale's
+// language. Only integer counts are supported.
+func pluralCategory(locale string, n int) string {
+	lang, _, _ := strings.Cut(strings.ToLower(locale), "_")
+	lang, _, _ = strings.Cut(lang, "-")
+	if n < 0 {
+		n = -n
+	}
+	switch lang {
+	case "ja", "ko", "zh", "th", "vi", "id":
+		return "other"
+	case "fr", "pt":
+		if n <= 1 {
+			return "one"
+		}
+	case "ru", "uk", "be":
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "pl":
+		switch {
+		case n == 1:
+			return "one"
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	default:
+		if n == 1 {
+			return "one"
+		}
+	}
+	return "other"
+}
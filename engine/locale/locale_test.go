@@ -0,0 +1,83 @@
+package locale
+
+import (
+	"strings"
+	"testing"
+
+	"rp-go/engine/data"
+)
+
+func TestLookupPluralsArgsAndFallback(t *testing.T) {
+	tables, problems := data.ValidateStringLayers([]data.Source{
+		{Path: "engine/data/strings_en.json", Data: []byte(`{
+  "strings": {
+    "hud.position": "Position: ({0:.0f}, {1:.0f})",
+    "hud.contacts": { "zero": "No contacts", "one": "{n} contact", "other": "{n} contacts" },
+    "menu.start": "Press Enter"
+  }
+}`)},
+		{Path: "engine/data/strings_ru.json", Data: []byte(`{
+  "strings": {
+    "hud.contacts": { "one": "{n} контакт", "few": "{n} контакта", "many": "{n} контактов", "other": "{n} контакта" }
+  }
+}`)},
+		{Path: "mods/extra/data/strings_en.json", Data: []byte(`{ "strings": { "menu.start": "Press Enter to Start" } }`)},
+	})
+	if len(problems) > 0 {
+		t.Fatalf("unexpected problems:\n%v", problems)
+	}
+
+	c := NewCatalog("en")
+	c.SetTables(tables)
+	if got := c.T("hud.position", 12.4, -3.6); got != "Position: (12, -4)" {
+		t.Fatalf("formatted lookup: %q", got)
+	}
+	if got := c.T("menu.start"); got != "Press Enter to Start" {
+		t.Fatalf("mod layer should override key, got %q", got)
+	}
+	for n, want := range map[int]string{0: "No contacts", 1: "1 contact", 5: "5 contacts"} {
+		if got := c.N("hud.contacts", n); got != want {
+			t.Fatalf("en N(%d) = %q, want %q", n, got, want)
+		}
+	}
+
+	if err := c.SetLocale("ru"); err != nil {
+		t.Fatal(err)
+	}
+	for n, want := range map[int]string{1: "1 контакт", 3: "3 контакта", 11: "11 контактов", 21: "21 контакт"} {
+		if got := c.N("hud.contacts", n); got != want {
+			t.Fatalf("ru N(%d) = %q, want %q", n, got, want)
+		}
+	}
+	if got := c.T("hud.position", 1.0, 2.0); got != "Position: (1, 2)" {
+		t.Fatalf("missing ru key should fall back to en, got %q", got)
+	}
+	if got := c.T("no.such.key"); got != "no.such.key" {
+		t.Fatalf("missing key should return itself, got %q", got)
+	}
+	if err := c.SetLocale("xx"); err == nil || c.Locale() != "ru" {
+		t.Fatalf("unknown locale should be rejected, got %v (active %s)", err, c.Locale())
+	}
+}
+
+func TestStringTableProblems(t *testing.T) {
+	_, problems := data.ValidateStringLayers([]data.Source{{Path: "strings_fr.json", Data: []byte(`{
+  "strings": {
+    "a": { "one": "un" },
+    "b": { "other": "x", "plenty": "y" },
+    "c": 3
+  }
+}`)}})
+	if len(problems) != 3 {
+		t.Fatalf("expected 3 problems, got:\n%v", problems)
+	}
+	if p := problems[0]; p.Line != 3 || !strings.Contains(p.Message, `"other"`) {
+		t.Fatalf("expected missing other form on line 3, got %v", p)
+	}
+	if p := problems[1]; p.Path != "strings.b.plenty" || p.Line != 4 {
+		t.Fatalf("expected unknown form on line 4, got %v", p)
+	}
+	if p := problems[2]; p.Line != 5 {
+		t.Fatalf("expected bad entry on line 5, got %v", p)
+	}
+}
@@ -0,0 +1,407 @@
+// Package mods discovers content packs and layers them over the base game
+// data. A pack is a directory or .zip archive under the mods root:
+//
+//	mods/
+//	  load_order.json         optional: {"order": ["a", "b"], "disabled": ["c"]}
+//	  hardmode/
+//	    mod.json              {"id": "hardmode", "name": "...", "version": "1.0", "requires": []}
+//	    data/actors.json      layered over engine/data/actors.json (also ai.json, render_config.json, actors_*.json)
+//	    assets/entities/x.png overrides or adds assets by their game-relative path
+//	  pirates.zip             same layout at the archive root
+//
+// Packs load in load_order.json order, then alphabetically by id; a pack
+// always loads after the packs it requires. Later packs override earlier
+// ones: data entries merge by name (see data.ParseActorPacks and
+// data.ParseAILayers) and assets are looked up topmost pack first.
+package mods
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"rp-go/engine/data"
+	"rp-go/engine/vfs"
+)
+
+// DefaultRoot is where the game looks for packs, relative to the working
+// directory.
+const DefaultRoot = "mods"
+
+/*───────────────────────────────────────────────*
+| PACKS                                         |
+*───────────────────────────────────────────────*/
+
+// Manifest is a pack's mod.json.
+type Manifest struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Version     string   `json:"version"`
+	Description string   `json:"description,omitempty"`
+	Requires    []string `json:"requires,omitempty"`
+}
+
+// Pack is one discovered content pack.
+type Pack struct {
+	Manifest
+	Path string // directory or archive on disk
+	FS   fs.FS  // pack contents, rooted at the pack
+
+	closer io.Closer
+}
+
+// Dir returns the pack directory, or "" for archives (which are not watched
+// for hot reload).
+func (p *Pack) Dir() string {
+	if p.closer != nil {
+		return ""
+	}
+	return p.Path
+}
+
+// Disabled records a pack that was found but not loaded.
+type Disabled struct {
+	ID     string
+	Path   string
+	Reason string
+}
+
+// Set is the ordered list of active packs. A nil *Set behaves as "no mods".
+type Set struct {
+	Root string
+	// Base holds the base game files packs are layered over; nil means the
+	// vfs.Default search path.
+	Base fs.FS
+
+	packs    []*Pack
+	disabled []Disabled
+}
+
+// Active returns the loaded packs, lowest priority first.
+func (s *Set) Active() []*Pack {
+	if s == nil {
+		return nil
+	}
+	return s.packs
+}
+
+// Disabled returns the packs that were skipped and why.
+func (s *Set) Disabled() []Disabled {
+	if s == nil {
+		return nil
+	}
+	return s.disabled
+}
+
+// Close releases open archives.
+func (s *Set) Close() error {
+	if s == nil {
+		return nil
+	}
+	var errs []error
+	for _, p := range s.packs {
+		if p.closer != nil {
+			errs = append(errs, p.closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+/*───────────────────────────────────────────────*
+| DISCOVERY                                     |
+*───────────────────────────────────────────────*/
+
+// loadOrder is the optional mods/load_order.json.
+type loadOrder struct {
+	Order    []string `json:"order"`
+	Disabled []string `json:"disabled"`
+}
+
+// Discover opens every pack under root and resolves the load order. A
+// missing root yields an empty set. Broken packs are listed in Disabled
+// rather than failing the whole set.
+func Discover(root string) (*Set, error) {
+	set := &Set{Root: root}
+	entries, err := os.ReadDir(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return set, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var order loadOrder
+	if raw, err := os.ReadFile(filepath.Join(root, "load_order.json")); err == nil {
+		if err := json.Unmarshal(raw, &order); err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Join(root, "load_order.json"), err)
+		}
+	}
+	off := make(map[string]bool, len(order.Disabled))
+	for _, id := range order.Disabled {
+		off[id] = true
+	}
+
+	found := make(map[string]*Pack)
+	for _, e := range entries {
+		p := filepath.Join(root, e.Name())
+		var pack *Pack
+		switch {
+		case e.IsDir():
+			pack, err = openDir(p)
+		case strings.EqualFold(filepath.Ext(e.Name()), ".zip"):
+			pack, err = openZip(p)
+		default:
+			continue
+		}
+		if err != nil {
+			set.disabled = append(set.disabled, Disabled{Path: p, Reason: err.Error()})
+			continue
+		}
+		switch {
+		case found[pack.ID] != nil:
+			set.disabled = append(set.disabled, Disabled{pack.ID, p, "duplicate id (also " + found[pack.ID].Path + ")"})
+			closePack(pack)
+		case off[pack.ID]:
+			set.disabled = append(set.disabled, Disabled{pack.ID, p, "disabled in load_order.json"})
+			closePack(pack)
+		default:
+			found[pack.ID] = pack
+		}
+	}
+
+	// Listed packs first, then the rest alphabetically.
+	var ids []string
+	listed := make(map[string]bool)
+	for _, id := range order.Order {
+		if found[id] != nil && !listed[id] {
+			ids = append(ids, id)
+			listed[id] = true
+		}
+	}
+	var rest []string
+	for id := range found {
+		if !listed[id] {
+			rest = append(rest, id)
+		}
+	}
+	sort.Strings(rest)
+	ids = append(ids, rest...)
+
+	set.resolve(ids, found)
+	return set, nil
+}
+
+// resolve appends packs in ids order, pulling each pack's requirements in
+// ahead of it. Packs with missing or cyclic requirements are disabled.
+func (s *Set) resolve(ids []string, found map[string]*Pack) {
+	const (
+		visiting = iota + 1
+		placed
+		failed
+	)
+	state := make(map[string]int, len(found))
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case placed:
+			return nil
+		case failed:
+			return fmt.Errorf("requires disabled pack %q", id)
+		case visiting:
+			return fmt.Errorf("requirement cycle through %q", id)
+		}
+		pack := found[id]
+		if pack == nil {
+			return fmt.Errorf("requires missing pack %q", id)
+		}
+		state[id] = visiting
+		for _, dep := range pack.Requires {
+			if err := visit(dep); err != nil {
+				state[id] = failed
+				s.disabled = append(s.disabled, Disabled{id, pack.Path, err.Error()})
+				closePack(pack)
+				return fmt.Errorf("requires disabled pack %q", id)
+			}
+		}
+		state[id] = placed
+		s.packs = append(s.packs, pack)
+		return nil
+	}
+	for _, id := range ids {
+		_ = visit(id)
+	}
+}
+
+func openDir(dir string) (*Pack, error) {
+	return newPack(dir, os.DirFS(dir), nil, filepath.Base(dir))
+}
+
+func openZip(file string) (*Pack, error) {
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return nil, err
+	}
+	pack, err := newPack(file, zr, zr, strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+	if err != nil {
+		zr.Close()
+	}
+	return pack, err
+}
+
+func newPack(p string, fsys fs.FS, closer io.Closer, fallbackID string) (*Pack, error) {
+	raw, err := fs.ReadFile(fsys, "mod.json")
+	if err != nil {
+		return nil, fmt.Errorf("no mod.json: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("mod.json: %w", err)
+	}
+	if m.ID == "" {
+		m.ID = fallbackID
+	}
+	if m.Name == "" {
+		m.Name = m.ID
+	}
+	return &Pack{Manifest: m, Path: p, FS: fsys, closer: closer}, nil
+}
+
+func closePack(p *Pack) {
+	if p.closer != nil {
+		p.closer.Close()
+	}
+}
+
+/*───────────────────────────────────────────────*
+| LAYERED ACCESS                                |
+*───────────────────────────────────────────────*/
+
+// Open returns name (a game-relative path such as
+// "assets/entities/ship.png") from the topmost pack that has it, falling
+// back to the base files.
+func (s *Set) Open(name string) (io.ReadCloser, error) {
+	if rel, ok := packPath(name); ok {
+		packs := s.Active()
+		for i := len(packs) - 1; i >= 0; i-- {
+			if f, err := packs[i].FS.Open(rel); err == nil {
+				return f, nil
+			}
+		}
+	}
+	rel, ok := packPath(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	return s.base().Open(rel)
+}
+
+// Origin names the pack Open would read name from ("" for the base game).
+func (s *Set) Origin(name string) string {
+	if rel, ok := packPath(name); ok {
+		packs := s.Active()
+		for i := len(packs) - 1; i >= 0; i-- {
+			if _, err := fs.Stat(packs[i].FS, rel); err == nil {
+				return packs[i].ID
+			}
+		}
+	}
+	return ""
+}
+
+// Sources reads the data files matching patterns: first from baseDir of the
+// base files (the base layer), then from each pack's data/ directory in
+// load order.
+func (s *Set) Sources(baseDir string, patterns ...string) ([]data.Source, error) {
+	var out []data.Source
+	base := s.base()
+	for _, name := range globAll(patterns, func(pattern string) ([]string, error) {
+		return fs.Glob(base, path.Join(baseDir, pattern))
+	}) {
+		raw, err := fs.ReadFile(base, name)
+		if err != nil {
+			return nil, err
+		}
+		src := data.Source{Path: name, Data: raw}
+		if p, ok := diskPath(base, name); ok {
+			src.Path = p
+		}
+		out = append(out, src)
+	}
+	for _, pack := range s.Active() {
+		for _, name := range globAll(patterns, func(pattern string) ([]string, error) {
+			return fs.Glob(pack.FS, path.Join("data", pattern))
+		}) {
+			raw, err := fs.ReadFile(pack.FS, name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", pack.ID, err)
+			}
+			out = append(out, data.Source{Path: filepath.Join(pack.Path, filepath.FromSlash(name)), Data: raw, Layer: pack.ID})
+		}
+	}
+	return out, nil
+}
+
+// WatchPatterns returns filesystem globs covering patterns in baseDir (when
+// it is on disk) and in every directory pack, for hot reload.
+func (s *Set) WatchPatterns(baseDir string, patterns ...string) []string {
+	var out []string
+	if dir, ok := diskPath(s.base(), baseDir); ok {
+		for _, pattern := range patterns {
+			out = append(out, filepath.Join(dir, pattern))
+		}
+	}
+	for _, pack := range s.Active() {
+		if dir := pack.Dir(); dir != "" {
+			for _, pattern := range patterns {
+				out = append(out, filepath.Join(dir, "data", pattern))
+			}
+		}
+	}
+	return out
+}
+
+func (s *Set) base() fs.FS {
+	if s == nil || s.Base == nil {
+		return vfs.Default
+	}
+	return s.Base
+}
+
+// diskPath reports where the base keeps name on disk, if it does (see
+// vfs.FS.DiskPath).
+func diskPath(base fs.FS, name string) (string, bool) {
+	if v, ok := base.(interface{ DiskPath(string) (string, bool) }); ok {
+		return v.DiskPath(name)
+	}
+	return "", false
+}
+
+// packPath converts a game-relative path to an fs.FS path.
+func packPath(name string) (string, bool) {
+	rel := path.Clean(filepath.ToSlash(name))
+	return rel, fs.ValidPath(rel) && rel != "."
+}
+
+// globAll expands patterns in order, skipping duplicates.
+func globAll(patterns []string, glob func(string) ([]string, error)) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, pattern := range patterns {
+		matches, _ := glob(pattern)
+		for _, m := range matches {
+			if !seen[m] {
+				seen[m] = true
+				out = append(out, m)
+			}
+		}
+	}
+	return out
+}
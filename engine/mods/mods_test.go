@@ -0,0 +1,90 @@
+package mods
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"rp-go/engine/data"
+	"rp-go/engine/vfs"
+)
+
+func TestPacksLayerInLoadOrder(t *testing.T) {
+	root := t.TempDir()
+	write := func(rel, body string) {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("base/actors.json", `{"actors":[{"name":"ship","archetype":"enemy","sprite":{"image":"a.png","width":32}}]}`)
+	write("mods/load_order.json", `{"order":["hard"]}`)
+	write("mods/hard/mod.json", `{"id":"hard","requires":["art"]}`)
+	write("mods/hard/data/actors.json", `{"actors":[{"name":"ship","sprite":{"width":64}},{"name":"boss","extends":"ship"}]}`)
+	write("mods/broken/mod.json", `{"id":"broken","requires":["nope"]}`)
+
+	// "art" ships as an archive and must load before "hard", which needs it.
+	zf, err := os.Create(filepath.Join(root, "mods", "art.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(zf)
+	for name, body := range map[string]string{"mod.json": `{"id":"art","version":"2"}`, "assets/a.png": "from-art"} {
+		f, _ := zw.Create(name)
+		io.WriteString(f, body)
+	}
+	zw.Close()
+	zf.Close()
+
+	set, err := Discover(filepath.Join(root, "mods"))
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	defer set.Close()
+	base := vfs.New()
+	base.MountDir(root)
+	set.Base = base
+
+	var ids []string
+	for _, p := range set.Active() {
+		ids = append(ids, p.ID)
+	}
+	if strings.Join(ids, ",") != "art,hard" {
+		t.Fatalf("expected art before hard, got %v", ids)
+	}
+	if d := set.Disabled(); len(d) != 1 || d[0].ID != "broken" || !strings.Contains(d[0].Reason, `"nope"`) {
+		t.Fatalf("expected broken pack skipped for missing requirement, got %+v", d)
+	}
+
+	sources, err := set.Sources("base", "actors.json", data.ActorPackPattern)
+	if err != nil {
+		t.Fatalf("sources: %v", err)
+	}
+	db, err := data.ParseActorPacks(sources)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	ship, boss := db.Actors[0], db.Actors[1]
+	if ship.Sprite.Width != 64 || ship.Sprite.Image != "a.png" || ship.Archetype != "enemy" || ship.Layer != "hard" {
+		t.Fatalf("expected pack override merged over base ship, got %+v", ship)
+	}
+	if boss.Name != "boss" || boss.Sprite.Width != 64 {
+		t.Fatalf("expected pack template extending overridden base, got %+v", boss)
+	}
+
+	f, err := set.Open("assets/a.png")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	body, _ := io.ReadAll(f)
+	f.Close()
+	if string(body) != "from-art" || set.Origin("assets/a.png") != "art" {
+		t.Fatalf("expected asset from art pack, got %q", body)
+	}
+}
@@ -0,0 +1,276 @@
+package nav
+
+import (
+	"container/heap"
+	"math"
+
+	"rp-go/engine/data"
+)
+
+/*───────────────────────────────────────────────*
+| TILE GRID                                     |
+*───────────────────────────────────────────────*/
+
+// Grid is a tile map searched with A*. Each cell has a movement cost of at
+// least 1, or 0 when blocked. Everything outside the grid is blocked.
+type Grid struct {
+	Cols, Rows       int
+	CellSize         float64
+	OriginX, OriginY float64 // world position of the top-left corner
+
+	cost []float64 // row-major
+}
+
+// NewGrid returns a grid of open cells with cost 1.
+func NewGrid(cols, rows int, cellSize float64) *Grid {
+	g := &Grid{Cols: cols, Rows: rows, CellSize: cellSize, cost: make([]float64, cols*rows)}
+	for i := range g.cost {
+		g.cost[i] = 1
+	}
+	return g
+}
+
+// GridFromTerrain builds a grid from a terrain map: blocked tiles become
+// blocked cells, the others keep their tile cost.
+func GridFromTerrain(t data.Terrain) *Grid {
+	g := NewGrid(t.Cols, t.Rows, t.TileSize)
+	g.OriginX, g.OriginY = t.OriginX, t.OriginY
+	for r := 0; r < t.Rows; r++ {
+		for c := 0; c < t.Cols; c++ {
+			_, tile, _ := t.Tile(c, r)
+			if tile.Blocked {
+				g.SetCost(c, r, 0)
+			} else {
+				g.SetCost(c, r, tile.Cost)
+			}
+		}
+	}
+	return g
+}
+
+// SetCost sets a cell's movement cost; 0 blocks it. Costs below 1 count
+// as 1 so the search heuristic stays exact.
+func (g *Grid) SetCost(c, r int, cost float64) {
+	if !g.inside(c, r) {
+		return
+	}
+	if cost > 0 && cost < 1 {
+		cost = 1
+	}
+	g.cost[r*g.Cols+c] = cost
+}
+
+// Cost returns a cell's movement cost, 0 when blocked or outside the grid.
+func (g *Grid) Cost(c, r int) float64 {
+	if !g.inside(c, r) {
+		return 0
+	}
+	return g.cost[r*g.Cols+c]
+}
+
+// Cell returns the column and row containing p.
+func (g *Grid) Cell(p Point) (int, int) {
+	return int(math.Floor((p.X - g.OriginX) / g.CellSize)), int(math.Floor((p.Y - g.OriginY) / g.CellSize))
+}
+
+// Center returns the world position of a cell's center.
+func (g *Grid) Center(c, r int) Point {
+	return Point{g.OriginX + (float64(c)+0.5)*g.CellSize, g.OriginY + (float64(r)+0.5)*g.CellSize}
+}
+
+// Walkable implements Pathfinder.
+func (g *Grid) Walkable(p Point) bool {
+	return g.Cost(g.Cell(p)) > 0
+}
+
+// LineOfSight implements Pathfinder. The segment may not touch a blocked
+// cell, nor squeeze between two diagonal ones.
+func (g *Grid) LineOfSight(a, b Point) bool {
+	return g.trace(a, b, math.Inf(1))
+}
+
+func (g *Grid) inside(c, r int) bool {
+	return c >= 0 && r >= 0 && c < g.Cols && r < g.Rows
+}
+
+// trace walks the cells the segment a–b crosses (Amanatides & Woo) and
+// reports whether each after the first is open with a cost up to maxCost.
+func (g *Grid) trace(a, b Point, maxCost float64) bool {
+	ok := func(c, r int) bool {
+		cost := g.Cost(c, r)
+		return cost > 0 && cost <= maxCost
+	}
+	c, r := g.Cell(a)
+	endC, endR := g.Cell(b)
+	ax, ay := (a.X-g.OriginX)/g.CellSize, (a.Y-g.OriginY)/g.CellSize
+	bx, by := (b.X-g.OriginX)/g.CellSize, (b.Y-g.OriginY)/g.CellSize
+
+	stepC, tMaxX, tDeltaX := traceAxis(ax, bx)
+	stepR, tMaxY, tDeltaY := traceAxis(ay, by)
+	for n := abs(endC-c) + abs(endR-r); n > 0 && (c != endC || r != endR); n-- {
+		switch {
+		case tMaxX < tMaxY:
+			c += stepC
+			tMaxX += tDeltaX
+		case tMaxY < tMaxX:
+			r += stepR
+			tMaxY += tDeltaY
+		default: // through a corner
+			if !ok(c+stepC, r) || !ok(c, r+stepR) {
+				return false
+			}
+			c += stepC
+			r += stepR
+			tMaxX += tDeltaX
+			tMaxY += tDeltaY
+			n--
+		}
+		if !ok(c, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// traceAxis returns the step direction along one axis, the segment
+// parameter at which the first cell boundary is crossed, and the parameter
+// distance between boundaries.
+func traceAxis(from, to float64) (step int, tMax, tDelta float64) {
+	d := to - from
+	switch {
+	case d > 0:
+		return 1, (math.Floor(from) + 1 - from) / d, 1 / d
+	case d < 0:
+		return -1, (from - math.Floor(from)) / -d, 1 / -d
+	}
+	return 0, math.Inf(1), math.Inf(1)
+}
+
+/*───────────────────────────────────────────────*
+| A* SEARCH                                     |
+*───────────────────────────────────────────────*/
+
+// neighbors are the eight moves; diagonals come last.
+var neighbors = [8][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+
+// FindPath implements Pathfinder. Diagonal moves may not cut blocked
+// corners. The cell path is smoothed by line of sight; a shortcut is only
+// taken across cells no costlier than its ends, so costly tiles are still
+// avoided.
+func (g *Grid) FindPath(from, to Point) ([]Point, bool) {
+	sc, sr := g.Cell(from)
+	gc, gr := g.Cell(to)
+	if !g.inside(sc, sr) || g.Cost(gc, gr) == 0 {
+		return nil, false
+	}
+	if sc == gc && sr == gr {
+		return []Point{to}, true
+	}
+
+	start, goal := sr*g.Cols+sc, gr*g.Cols+gc
+	gScore := make([]float64, len(g.cost))
+	parent := make([]int, len(g.cost))
+	closed := make([]bool, len(g.cost))
+	for i := range gScore {
+		gScore[i] = math.Inf(1)
+		parent[i] = -1
+	}
+	gScore[start] = 0
+	open := &nodeHeap{{index: start, f: g.heuristic(sc, sr, gc, gr)}}
+
+	found := false
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(node)
+		if closed[cur.index] {
+			continue
+		}
+		if cur.index == goal {
+			found = true
+			break
+		}
+		closed[cur.index] = true
+		c, r := cur.index%g.Cols, cur.index/g.Cols
+		for _, d := range neighbors {
+			nc, nr := c+d[0], r+d[1]
+			cost := g.Cost(nc, nr)
+			if cost == 0 {
+				continue
+			}
+			step := 1.0
+			if d[0] != 0 && d[1] != 0 {
+				if g.Cost(c+d[0], r) == 0 || g.Cost(c, r+d[1]) == 0 {
+					continue // would cut a corner
+				}
+				step = math.Sqrt2
+			}
+			next := nr*g.Cols + nc
+			score := gScore[cur.index] + step*g.CellSize*cost
+			if score < gScore[next] {
+				gScore[next] = score
+				parent[next] = cur.index
+				h := g.heuristic(nc, nr, gc, gr)
+				heap.Push(open, node{index: next, f: score + h, h: h})
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	var cells []int
+	for i := goal; i != start; i = parent[i] {
+		cells = append(cells, i)
+	}
+	path := make([]Point, 0, len(cells)+1)
+	path = append(path, from)
+	for i := len(cells) - 1; i > 0; i-- {
+		path = append(path, g.Center(cells[i]%g.Cols, cells[i]/g.Cols))
+	}
+	path = append(path, to)
+
+	path = Smooth(path, func(a, b Point) bool {
+		return g.trace(a, b, math.Max(g.Cost(g.Cell(a)), g.Cost(g.Cell(b))))
+	})
+	return path[1:], true
+}
+
+// heuristic is the octile distance, exact on open ground of cost 1.
+func (g *Grid) heuristic(c, r, gc, gr int) float64 {
+	dx, dy := float64(abs(gc-c)), float64(abs(gr-r))
+	return g.CellSize * (math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy))
+}
+
+type node struct {
+	index int
+	f, h  float64
+}
+
+// nodeHeap orders by f, then by h so ties favor nodes nearer the goal, then
+// by index so searches are deterministic.
+type nodeHeap []node
+
+func (h nodeHeap) Len() int { return len(h) }
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].f != h[j].f {
+		return h[i].f < h[j].f
+	}
+	if h[i].h != h[j].h {
+		return h[i].h < h[j].h
+	}
+	return h[i].index < h[j].index
+}
+func (h nodeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x any)   { *h = append(*h, x.(node)) }
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,60 @@
+// Package nav finds paths around blocked terrain for AI movement. A Grid
+// searches tile maps with A*; a NavMesh searches convex walkable polygons.
+// Either plugs into a Service, which answers path requests on worker
+// goroutines and caches the results:
+//
+//	svc := nav.NewService(nav.Config{})
+//	svc.SetFinder(nav.GridFromTerrain(terrain))
+//	...
+//	svc.Sync() // once per frame, before requests
+//	if path, ready := svc.Request(from, to); ready && path != nil {
+//		// walk path
+//	}
+package nav
+
+import "math"
+
+// Point is a position in world units.
+type Point struct {
+	X, Y float64
+}
+
+// Dist returns the distance between p and q.
+func (p Point) Dist(q Point) float64 {
+	return math.Hypot(q.X-p.X, q.Y-p.Y)
+}
+
+// Pathfinder is a searchable map.
+type Pathfinder interface {
+	// FindPath returns the waypoints leading from from to to, ending at to
+	// and excluding from, or false when to cannot be reached.
+	FindPath(from, to Point) ([]Point, bool)
+	// Walkable reports whether p lies on passable ground.
+	Walkable(p Point) bool
+	// LineOfSight reports whether the straight segment a–b stays on
+	// passable ground.
+	LineOfSight(a, b Point) bool
+}
+
+// Smooth drops waypoints that can be skipped: each kept point is the
+// farthest one still in line of sight of the previous kept point. path
+// starts at the mover's position.
+func Smooth(path []Point, los func(a, b Point) bool) []Point {
+	if len(path) < 3 {
+		return path
+	}
+	out := []Point{path[0]}
+	anchor := 0
+	for anchor < len(path)-1 {
+		next := anchor + 1
+		for i := len(path) - 1; i > next; i-- {
+			if los(path[anchor], path[i]) {
+				next = i
+				break
+			}
+		}
+		out = append(out, path[next])
+		anchor = next
+	}
+	return out
+}
@@ -0,0 +1,189 @@
+package nav
+
+import (
+	"math"
+	"testing"
+
+	"rp-go/engine/data"
+)
+
+func testGrid(t *testing.T, rows ...string) *Grid {
+	t.Helper()
+	g := NewGrid(len(rows[0]), len(rows), 10)
+	for r, row := range rows {
+		for c, ch := range row {
+			switch ch {
+			case '#':
+				g.SetCost(c, r, 0)
+			case ':':
+				g.SetCost(c, r, 3)
+			}
+		}
+	}
+	return g
+}
+
+func TestGridPathAvoidsWallsAndSmooths(t *testing.T) {
+	g := testGrid(t,
+		"..........",
+		"..........",
+		"#########.",
+		"..........",
+		"..........",
+	)
+	from, to := g.Center(0, 0), g.Center(0, 4)
+	path, ok := g.FindPath(from, to)
+	if !ok {
+		t.Fatal("expected a path around the wall")
+	}
+	if path[len(path)-1] != to {
+		t.Fatalf("path should end at the target, got %v", path)
+	}
+	prev := from
+	for _, p := range path {
+		if !g.LineOfSight(prev, p) {
+			t.Fatalf("segment %v-%v crosses the wall", prev, p)
+		}
+		prev = p
+	}
+	if len(path) > 3 {
+		t.Fatalf("expected smoothing to leave at most 3 waypoints, got %d: %v", len(path), path)
+	}
+	if g.LineOfSight(from, to) {
+		t.Fatal("line of sight should be blocked by the wall")
+	}
+
+	g.SetCost(9, 2, 0)
+	if _, ok := g.FindPath(from, to); ok {
+		t.Fatal("expected no path once the gap is closed")
+	}
+}
+
+func TestGridPathPrefersCheapTiles(t *testing.T) {
+	g := testGrid(t,
+		"..........",
+		"..::::::..",
+		"..::::::..",
+		"..::::::..",
+		"..........",
+	)
+	path, ok := g.FindPath(g.Center(0, 2), g.Center(9, 2))
+	if !ok {
+		t.Fatal("expected a path")
+	}
+	prev := g.Center(0, 2)
+	for _, p := range path {
+		// Sample between cell corners; diagonal steps may touch them.
+		for i := 0.05; i < 1; i += 0.1 {
+			c, r := g.Cell(Point{prev.X + (p.X-prev.X)*i, prev.Y + (p.Y-prev.Y)*i})
+			if g.Cost(c, r) > 1 {
+				t.Fatalf("path %v crosses costly cell (%d,%d)", path, c, r)
+			}
+		}
+		prev = p
+	}
+}
+
+func TestGridDoesNotCutCorners(t *testing.T) {
+	g := testGrid(t,
+		".#",
+		"#.",
+	)
+	if g.LineOfSight(g.Center(0, 0), g.Center(1, 1)) {
+		t.Fatal("diagonal between two blocked cells should be blocked")
+	}
+	if _, ok := g.FindPath(g.Center(0, 0), g.Center(1, 1)); ok {
+		t.Fatal("expected no path through a blocked corner")
+	}
+}
+
+func TestNavMeshPath(t *testing.T) {
+	// An L-shaped corridor: bottom strip, then a column up the right side.
+	m := NewNavMesh([]Polygon{
+		{{0, 0}, {80, 0}, {80, 20}, {0, 20}},
+		{{80, 0}, {100, 0}, {100, 20}, {80, 20}},
+		{{80, 20}, {100, 20}, {100, 100}, {80, 100}},
+	})
+	from, to := Point{10, 10}, Point{90, 90}
+	if m.LineOfSight(from, to) {
+		t.Fatal("line of sight should leave the mesh")
+	}
+	path, ok := m.FindPath(from, to)
+	if !ok || path[len(path)-1] != to {
+		t.Fatalf("expected a path ending at the target, got %v %v", path, ok)
+	}
+	prev := from
+	for _, p := range path {
+		if !m.LineOfSight(prev, p) {
+			t.Fatalf("segment %v-%v leaves the mesh", prev, p)
+		}
+		prev = p
+	}
+	if _, ok := m.FindPath(from, Point{50, 90}); ok {
+		t.Fatal("expected no path to a point off the mesh")
+	}
+}
+
+func TestServicePublishesOnSyncAndCaches(t *testing.T) {
+	g := testGrid(t,
+		"..........",
+		"####.#####",
+		"..........",
+	)
+	svc := NewService(Config{Quantum: 10})
+	from, to := g.Center(0, 0), g.Center(0, 2)
+	if _, ready := svc.Request(from, to); ready {
+		t.Fatal("no map set: request should not be ready")
+	}
+	svc.SetFinder(g)
+	if _, ready := svc.Request(from, to); ready {
+		t.Fatal("request should be pending until Sync")
+	}
+	svc.Sync()
+	path, ready := svc.Request(from, to)
+	if !ready || len(path) == 0 {
+		t.Fatalf("expected a path after Sync, got %v %v", path, ready)
+	}
+	// A nearby start in the same quantum square shares the cached path.
+	if p, ready := svc.Request(Point{from.X + 2, from.Y + 2}, to); !ready || len(p) != len(path) {
+		t.Fatalf("expected the cached path, got %v %v", p, ready)
+	}
+
+	blocked := g.Center(0, 1)
+	svc.Request(from, blocked)
+	svc.Sync()
+	if p, ready := svc.Request(from, blocked); !ready || p != nil {
+		t.Fatalf("unreachable target should be ready with no path, got %v %v", p, ready)
+	}
+
+	svc.SetFinder(nil)
+	if _, ready := svc.Request(from, to); ready {
+		t.Fatal("clearing the map should drop cached paths")
+	}
+}
+
+func TestGridFromTerrain(t *testing.T) {
+	maps, problems := data.ValidateTerrainLayers([]data.Source{{Path: "terrain_test.json", Data: []byte(`{
+  "tile_size": 32,
+  "origin": { "x": -64, "y": 0 },
+  "tiles": { "grass": {}, "sand": { "cost": 2 }, "water": { "blocked": true } },
+  "legend": { ".": "grass", ":": "sand", "~": "water" },
+  "rows": [ ".:~", "..." ]
+}`)}})
+	if len(problems) > 0 {
+		t.Fatalf("unexpected problems:\n%v", problems)
+	}
+	g := GridFromTerrain(maps["test"])
+	if g.Cols != 3 || g.Rows != 2 || g.Cost(0, 0) != 1 || g.Cost(1, 0) != 2 || g.Cost(2, 0) != 0 {
+		t.Fatalf("unexpected grid %+v", g)
+	}
+	if c, r := g.Cell(Point{-60, 40}); c != 0 || r != 1 {
+		t.Fatalf("origin not applied: cell (%d,%d)", c, r)
+	}
+	if g.Walkable(Point{16, 16}) || !g.Walkable(Point{-48, 16}) {
+		t.Fatal("walkability does not follow the tiles")
+	}
+	if p := g.Center(1, 1); math.Abs(p.X+16) > 1e-9 || math.Abs(p.Y-48) > 1e-9 {
+		t.Fatalf("unexpected center %v", p)
+	}
+}
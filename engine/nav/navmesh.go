@@ -0,0 +1,191 @@
+package nav
+
+import (
+	"container/heap"
+	"math"
+)
+
+/*───────────────────────────────────────────────*
+| NAVIGATION MESH                               |
+*───────────────────────────────────────────────*/
+
+// Polygon is a convex walkable area, its vertices in either winding order.
+type Polygon []Point
+
+// contains reports whether p lies inside or on the edge of the polygon.
+func (poly Polygon) contains(p Point) bool {
+	sign := 0.0
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		cross := (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+		if math.Abs(cross) < epsilon {
+			continue
+		}
+		if sign == 0 {
+			sign = cross
+		} else if (cross > 0) != (sign > 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// NavMesh is a set of convex polygons; two polygons connect where they
+// share an edge. Paths run through the midpoints of those shared edges
+// and are then smoothed.
+type NavMesh struct {
+	polys []Polygon
+	links [][]portal
+}
+
+// portal is an edge shared with polygon to.
+type portal struct {
+	to   int
+	a, b Point
+}
+
+const epsilon = 1e-6
+
+// NewNavMesh links the polygons that share an edge.
+func NewNavMesh(polys []Polygon) *NavMesh {
+	m := &NavMesh{polys: polys, links: make([][]portal, len(polys))}
+	for i, p := range polys {
+		for j := i + 1; j < len(polys); j++ {
+			if a, b, ok := sharedEdge(p, polys[j]); ok {
+				m.links[i] = append(m.links[i], portal{to: j, a: a, b: b})
+				m.links[j] = append(m.links[j], portal{to: i, a: a, b: b})
+			}
+		}
+	}
+	return m
+}
+
+func sharedEdge(p, q Polygon) (Point, Point, bool) {
+	same := func(a, b Point) bool { return math.Abs(a.X-b.X) < epsilon && math.Abs(a.Y-b.Y) < epsilon }
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		for j, c := range q {
+			d := q[(j+1)%len(q)]
+			if (same(a, c) && same(b, d)) || (same(a, d) && same(b, c)) {
+				return a, b, true
+			}
+		}
+	}
+	return Point{}, Point{}, false
+}
+
+// Locate returns the index of the polygon containing p, or -1.
+func (m *NavMesh) Locate(p Point) int {
+	for i, poly := range m.polys {
+		if poly.contains(p) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Walkable implements Pathfinder.
+func (m *NavMesh) Walkable(p Point) bool {
+	return m.Locate(p) >= 0
+}
+
+// LineOfSight implements Pathfinder: the segment walks from polygon to
+// polygon through shared edges until it reaches b's polygon.
+func (m *NavMesh) LineOfSight(a, b Point) bool {
+	cur, prev := m.Locate(a), -1
+	if cur < 0 {
+		return false
+	}
+	for range m.polys {
+		if m.polys[cur].contains(b) {
+			return true
+		}
+		next := -1
+		for _, link := range m.links[cur] {
+			if link.to != prev && segmentsCross(a, b, link.a, link.b) {
+				next = link.to
+				break
+			}
+		}
+		if next < 0 {
+			return false
+		}
+		prev, cur = cur, next
+	}
+	return false
+}
+
+// segmentsCross reports whether segments p1–p2 and q1–q2 intersect,
+// endpoints included.
+func segmentsCross(p1, p2, q1, q2 Point) bool {
+	orient := func(a, b, c Point) float64 {
+		return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+	}
+	d1, d2 := orient(q1, q2, p1), orient(q1, q2, p2)
+	d3, d4 := orient(p1, p2, q1), orient(p1, p2, q2)
+	return d1*d2 <= 0 && d3*d4 <= 0
+}
+
+// FindPath implements Pathfinder with A* over the polygon graph, entering
+// each polygon at the midpoint of the shared edge.
+func (m *NavMesh) FindPath(from, to Point) ([]Point, bool) {
+	start, goal := m.Locate(from), m.Locate(to)
+	if start < 0 || goal < 0 {
+		return nil, false
+	}
+	if start == goal {
+		return []Point{to}, true
+	}
+
+	n := len(m.polys)
+	gScore := make([]float64, n)
+	entry := make([]Point, n)
+	parent := make([]int, n)
+	closed := make([]bool, n)
+	for i := range gScore {
+		gScore[i] = math.Inf(1)
+		parent[i] = -1
+	}
+	gScore[start] = 0
+	entry[start] = from
+	open := &nodeHeap{{index: start, f: from.Dist(to)}}
+
+	found := false
+	for open.Len() > 0 {
+		cur := heap.Pop(open).(node)
+		if closed[cur.index] {
+			continue
+		}
+		if cur.index == goal {
+			found = true
+			break
+		}
+		closed[cur.index] = true
+		for _, link := range m.links[cur.index] {
+			mid := Point{(link.a.X + link.b.X) / 2, (link.a.Y + link.b.Y) / 2}
+			score := gScore[cur.index] + entry[cur.index].Dist(mid)
+			if score < gScore[link.to] {
+				gScore[link.to] = score
+				entry[link.to] = mid
+				parent[link.to] = cur.index
+				h := mid.Dist(to)
+				heap.Push(open, node{index: link.to, f: score + h, h: h})
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	var mids []Point
+	for i := goal; i != start; i = parent[i] {
+		mids = append(mids, entry[i])
+	}
+	path := make([]Point, 0, len(mids)+2)
+	path = append(path, from)
+	for i := len(mids) - 1; i >= 0; i-- {
+		path = append(path, mids[i])
+	}
+	path = append(path, to)
+	return Smooth(path, m.LineOfSight)[1:], true
+}
@@ -0,0 +1,180 @@
+package nav
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+/*───────────────────────────────────────────────*
+| PATH SERVICE                                  |
+*───────────────────────────────────────────────*/
+
+// Config tunes a Service.
+type Config struct {
+	Workers   int     // searches running at once
+	CacheSize int     // paths kept; the oldest are dropped first
+	Quantum   float64 // endpoints within the same Quantum-sized square share a cached path
+}
+
+// normalize fills zero-valued fields with sensible defaults.
+func (c *Config) normalize() {
+	if c.Workers <= 0 {
+		c.Workers = 2
+	}
+	if c.CacheSize <= 0 {
+		c.CacheSize = 256
+	}
+	if c.Quantum <= 0 {
+		c.Quantum = 16
+	}
+}
+
+// Service answers path requests asynchronously. Requests made during a
+// frame are searched on worker goroutines; Sync, called at the start of the
+// next frame, waits for them and publishes the results. Paths therefore
+// always arrive exactly one frame later, which keeps replays deterministic.
+// Request and Sync belong to the simulation goroutine.
+type Service struct {
+	cfg Config
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	mu      sync.Mutex
+	finder  Pathfinder
+	gen     int // bumped by SetFinder; results of older finders are dropped
+	cache   map[pathKey]cached
+	order   []pathKey // cache insertion order, oldest first
+	pending map[pathKey]bool
+	done    []result
+}
+
+type pathKey struct{ fx, fy, tx, ty int }
+
+type cached struct {
+	path []Point
+	ok   bool
+}
+
+type result struct {
+	key pathKey
+	gen int
+	cached
+}
+
+// NewService returns a service without a map; Request reports nothing
+// until SetFinder is called.
+func NewService(cfg Config) *Service {
+	cfg.normalize()
+	return &Service{
+		cfg:     cfg,
+		sem:     make(chan struct{}, cfg.Workers),
+		cache:   make(map[pathKey]cached),
+		pending: make(map[pathKey]bool),
+	}
+}
+
+// SetFinder switches the map searched, e.g. on a scene change or terrain
+// reload, and drops every cached path. nil disables pathfinding.
+func (s *Service) SetFinder(f Pathfinder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finder = f
+	s.gen++
+	s.cache = make(map[pathKey]cached)
+	s.order = nil
+	s.pending = make(map[pathKey]bool)
+}
+
+// Finder returns the map searched, or nil.
+func (s *Service) Finder() Pathfinder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.finder
+}
+
+// LineOfSight reports whether a–b is clear on the current map. Without a
+// map everything is clear.
+func (s *Service) LineOfSight(a, b Point) bool {
+	f := s.Finder()
+	return f == nil || f.LineOfSight(a, b)
+}
+
+// Request returns the path from from to to once it has been searched.
+// ready is false while the search is pending (or there is no map); a ready
+// nil path means to is unreachable. Requests whose endpoints fall in the
+// same Quantum squares share one path, ending at the first requester's
+// target, so callers should finish the last stretch directly.
+func (s *Service) Request(from, to Point) (path []Point, ready bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finder == nil {
+		return nil, false
+	}
+	key := pathKey{s.snap(from.X), s.snap(from.Y), s.snap(to.X), s.snap(to.Y)}
+	if c, ok := s.cache[key]; ok {
+		if !c.ok {
+			return nil, true
+		}
+		return c.path, true
+	}
+	if !s.pending[key] {
+		s.pending[key] = true
+		s.wg.Add(1)
+		go s.search(s.finder, s.gen, key, from, to)
+	}
+	return nil, false
+}
+
+func (s *Service) snap(v float64) int {
+	return int(math.Floor(v / s.cfg.Quantum))
+}
+
+func (s *Service) search(f Pathfinder, gen int, key pathKey, from, to Point) {
+	defer s.wg.Done()
+	s.sem <- struct{}{}
+	path, ok := f.FindPath(from, to)
+	<-s.sem
+
+	s.mu.Lock()
+	s.done = append(s.done, result{key: key, gen: gen, cached: cached{path: path, ok: ok}})
+	s.mu.Unlock()
+}
+
+// Sync waits for the searches requested since the last Sync and publishes
+// their results.
+func (s *Service) Sync() {
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	done := s.done
+	s.done = nil
+	// Workers finish in any order; publish in key order so cache eviction
+	// is the same on every run.
+	sort.Slice(done, func(i, j int) bool { return done[i].key.less(done[j].key) })
+	for _, r := range done {
+		if r.gen != s.gen {
+			continue
+		}
+		delete(s.pending, r.key)
+		s.cache[r.key] = r.cached
+		s.order = append(s.order, r.key)
+	}
+	for len(s.order) > s.cfg.CacheSize {
+		delete(s.cache, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+func (k pathKey) less(o pathKey) bool {
+	switch {
+	case k.fx != o.fx:
+		return k.fx < o.fx
+	case k.fy != o.fy:
+		return k.fy < o.fy
+	case k.tx != o.tx:
+		return k.tx < o.tx
+	}
+	return k.ty < o.ty
+}
@@ -5,9 +5,19 @@ import (
 	"golang.org/x/image/font/basicfont"
 )
 
+var defaultFont font.Face = basicfont.Face7x13
+
 // DefaultFont returns a globally available fallback font
 // for all UI and debug text rendering.
 func DefaultFont() font.Face {
-	return basicfont.Face7x13
+	return defaultFont
 }
 
+// SetDefaultFont replaces the face DefaultFont returns; nil restores the
+// built-in basicfont.
+func SetDefaultFont(face font.Face) {
+	if face == nil {
+		face = basicfont.Face7x13
+	}
+	defaultFont = face
+}
@@ -78,6 +78,7 @@ var (
 	SetWindowSize  = platform_desktop.SetWindowSize
 	SetWindowTitle = platform_desktop.SetWindowTitle
 	ActualFPS      = platform_desktop.ActualFPS
+	TPS            = platform_desktop.TPS
 	Wheel          = platform_desktop.Wheel
 )
 
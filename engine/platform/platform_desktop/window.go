@@ -10,6 +10,9 @@ func Wheel() (float64, float64) { return ebiten.Wheel() }
 // ActualFPS returns the current rendering FPS as reported by Ebiten.
 func ActualFPS() float64 { return ebiten.ActualFPS() }
 
+// TPS returns how many times per second Update is called.
+func TPS() int { return ebiten.TPS() }
+
 // SetWindowSize changes the window dimensions.
 func SetWindowSize(w, h int) { ebiten.SetWindowSize(w, h) }
 
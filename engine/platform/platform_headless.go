@@ -189,6 +189,7 @@ func IsGamepadDown(GamepadID) bool                                         { ret
 func Wheel() (float64, float64) { return 0, 0 }
 
 func ActualFPS() float64 { return 60 }
+func TPS() int           { return 60 }
 
 func SetWindowSize(int, int) {}
 func SetWindowTitle(string)  {}
@@ -0,0 +1,261 @@
+package replay
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"sync"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/input"
+)
+
+/*───────────────────────────────────────────────*
+| REPLAY FILE FORMAT                            |
+*───────────────────────────────────────────────*/
+
+// Version is the current replay format version.
+const Version = 1
+
+// Seed names recorded by the game.
+const (
+	SeedAI         = "ai"
+	SeedBackground = "background"
+)
+
+// File is a recorded session: the RNG seeds it started with and the inputs
+// fed into every frame.
+type File struct {
+	Version int              `json:"version"`
+	Seeds   map[string]int64 `json:"seeds"`
+	Frames  []Frame          `json:"frames"`
+}
+
+// Frame captures everything external that influenced one World.Advance.
+// Paused frames ran with the simulation frozen (developer console open), so
+// playback must freeze it too.
+type Frame struct {
+	Paused   bool     `json:"paused,omitempty"`
+	MoveX    float64  `json:"mx,omitempty"`
+	MoveY    float64  `json:"my,omitempty"`
+	Commands []string `json:"cmds,omitempty"`
+	Checksum uint64   `json:"sum"`
+}
+
+// Load reads a replay file from disk.
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("replay: decode %s: %w", path, err)
+	}
+	if f.Version > Version {
+		return nil, fmt.Errorf("replay: unsupported version %d (max %d)", f.Version, Version)
+	}
+	return &f, nil
+}
+
+// Save writes the replay file to disk.
+func (f *File) Save(path string) error {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+/*───────────────────────────────────────────────*
+| CHECKSUM                                      |
+*───────────────────────────────────────────────*/
+
+// Checksum hashes every entity ID and Position in store order, so any
+// drift in simulation state shows up as a mismatch.
+func Checksum(w *ecs.World) uint64 {
+	h := fnv.New64a()
+	if w == nil {
+		return h.Sum64()
+	}
+	var buf [24]byte
+	ecs.NewQuery[*ecs.Position](w).Each(func(e *ecs.Entity, p *ecs.Position) {
+		binary.LittleEndian.PutUint64(buf[0:], uint64(e.ID))
+		binary.LittleEndian.PutUint64(buf[8:], math.Float64bits(p.X))
+		binary.LittleEndian.PutUint64(buf[16:], math.Float64bits(p.Y))
+		h.Write(buf[:])
+	})
+	return h.Sum64()
+}
+
+/*───────────────────────────────────────────────*
+| RECORDER                                      |
+*───────────────────────────────────────────────*/
+
+// Recorder accumulates frames while the game runs normally.
+type Recorder struct {
+	mu      sync.Mutex
+	file    File
+	pending []string
+	paused  func() bool
+}
+
+// NewRecorder starts an empty recording.
+func NewRecorder() *Recorder {
+	return &Recorder{file: File{Version: Version, Seeds: make(map[string]int64)}}
+}
+
+// SetSeed records the seed an RNG was initialized with.
+func (r *Recorder) SetSeed(name string, seed int64) {
+	r.mu.Lock()
+	r.file.Seeds[name] = seed
+	r.mu.Unlock()
+}
+
+// RecordCommand notes a dev-console command executed during this frame.
+func (r *Recorder) RecordCommand(command string) {
+	r.mu.Lock()
+	r.pending = append(r.pending, command)
+	r.mu.Unlock()
+}
+
+// SetPauseSource tells the recorder how to learn whether the simulation was
+// frozen during a frame; it is asked once per EndFrame.
+func (r *Recorder) SetPauseSource(paused func() bool) {
+	r.mu.Lock()
+	r.paused = paused
+	r.mu.Unlock()
+}
+
+// EndFrame captures the frame's input and resulting world checksum. Call it
+// after the world has been advanced.
+func (r *Recorder) EndFrame(w *ecs.World) {
+	r.mu.Lock()
+	source := r.paused
+	r.mu.Unlock()
+	paused := source != nil && source()
+
+	// Input is not polled while the simulation is frozen, so the last
+	// movement would be stale; it is not needed to replay the frame.
+	var mx, my float64
+	if !paused {
+		mx, my = input.ManagerInstance().Movement()
+	}
+	sum := Checksum(w)
+
+	r.mu.Lock()
+	r.file.Frames = append(r.file.Frames, Frame{
+		Paused:   paused,
+		MoveX:    mx,
+		MoveY:    my,
+		Commands: r.pending,
+		Checksum: sum,
+	})
+	r.pending = nil
+	r.mu.Unlock()
+}
+
+// File returns the recording so far.
+func (r *Recorder) File() *File {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f := r.file
+	f.Frames = append([]Frame(nil), r.file.Frames...)
+	return &f
+}
+
+/*───────────────────────────────────────────────*
+| PLAYER                                        |
+*───────────────────────────────────────────────*/
+
+// Divergence reports the first frame whose state no longer matches.
+type Divergence struct {
+	Frame    int
+	Expected uint64
+	Actual   uint64
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("replay diverged at frame %d: checksum %016x, recorded %016x", d.Frame, d.Actual, d.Expected)
+}
+
+// Player feeds a recorded File back into the game frame by frame.
+type Player struct {
+	file       *File
+	frame      int
+	divergence *Divergence
+}
+
+// NewPlayer prepares f for playback and routes input polling through it.
+func NewPlayer(f *File) *Player {
+	p := &Player{file: f}
+	input.ManagerInstance().SetSource(p.movement)
+	return p
+}
+
+// Close restores device input polling.
+func (p *Player) Close() {
+	input.ManagerInstance().SetSource(nil)
+}
+
+// Len reports how many frames the recording holds.
+func (p *Player) Len() int { return len(p.file.Frames) }
+
+// Done reports whether every frame has been played.
+func (p *Player) Done() bool { return p.frame >= len(p.file.Frames) }
+
+// Seed returns a recorded seed.
+func (p *Player) Seed(name string) (int64, bool) {
+	seed, ok := p.file.Seeds[name]
+	return seed, ok
+}
+
+// BeginFrame hands the frame's console commands to exec. Call it before the
+// world is advanced.
+func (p *Player) BeginFrame(exec func(command string)) {
+	if p.Done() || exec == nil {
+		return
+	}
+	for _, command := range p.file.Frames[p.frame].Commands {
+		exec(command)
+	}
+}
+
+// EndFrame verifies the world against the recorded checksum and moves to
+// the next frame. It returns the first divergence, once.
+func (p *Player) EndFrame(w *ecs.World) error {
+	if p.Done() {
+		return nil
+	}
+	want := p.file.Frames[p.frame].Checksum
+	got := Checksum(w)
+	index := p.frame
+	p.frame++
+
+	if got != want && p.divergence == nil {
+		p.divergence = &Divergence{Frame: index, Expected: want, Actual: got}
+		return p.divergence
+	}
+	return nil
+}
+
+// Paused reports whether the current frame was recorded with the
+// simulation frozen. Use it as the playback's simulation run condition in
+// place of live console state.
+func (p *Player) Paused() bool {
+	return !p.Done() && p.file.Frames[p.frame].Paused
+}
+
+// Divergence returns the first mismatch seen, or nil.
+func (p *Player) Divergence() *Divergence { return p.divergence }
+
+func (p *Player) movement() (float64, float64) {
+	if p.Done() {
+		return 0, 0
+	}
+	f := p.file.Frames[p.frame]
+	return f.MoveX, f.MoveY
+}
@@ -0,0 +1,165 @@
+package replay
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/input"
+)
+
+// steerSystem moves every entity by the polled input, like input.System.
+type steerSystem struct{}
+
+func (steerSystem) Update(w *ecs.World) {
+	m := input.ManagerInstance()
+	m.Poll()
+	mx, my := m.Movement()
+	scale := w.Clock().StepScale()
+	ecs.NewQuery[*ecs.Position](w).Each(func(_ *ecs.Entity, p *ecs.Position) {
+		p.X += mx * scale
+		p.Y += my * scale
+	})
+}
+
+func newSession() *ecs.World {
+	w := ecs.NewWorld()
+	w.NewEntity().Add(&ecs.Position{X: 5, Y: 5})
+	w.AddSystem(steerSystem{})
+	return w
+}
+
+func TestRecordedSessionReplaysWithoutDivergence(t *testing.T) {
+	frames := 30
+
+	rec := NewRecorder()
+	rec.SetSeed(SeedAI, 42)
+	w := newSession()
+	input.ManagerInstance().SetSource(func() (float64, float64) {
+		return math.Sin(float64(w.Clock().Frame())), 1
+	})
+	for i := 0; i < frames; i++ {
+		if i == 3 {
+			rec.RecordCommand("list")
+		}
+		w.Advance(ecs.DefaultStep)
+		rec.EndFrame(w)
+	}
+	input.ManagerInstance().SetSource(nil)
+
+	f := rec.File()
+	if len(f.Frames) != frames || f.Frames[3].Commands[0] != "list" {
+		t.Fatalf("expected %d frames with the recorded command, got %+v", frames, f.Frames[:4])
+	}
+
+	p := NewPlayer(f)
+	defer p.Close()
+	if seed, ok := p.Seed(SeedAI); !ok || seed != 42 {
+		t.Fatalf("expected recorded AI seed, got %d", seed)
+	}
+
+	replayed := newSession()
+	var executed []string
+	for !p.Done() {
+		p.BeginFrame(func(cmd string) { executed = append(executed, cmd) })
+		replayed.Advance(ecs.DefaultStep)
+		if err := p.EndFrame(replayed); err != nil {
+			t.Fatalf("unexpected divergence: %v", err)
+		}
+	}
+	if len(executed) != 1 {
+		t.Fatalf("expected recorded command to be replayed once, got %v", executed)
+	}
+}
+
+func TestPlayerReportsFirstDivergence(t *testing.T) {
+	w := newSession()
+	f := &File{Version: Version, Frames: []Frame{{Checksum: Checksum(w)}, {Checksum: 1}, {Checksum: 2}}}
+
+	p := NewPlayer(f)
+	defer p.Close()
+
+	var first error
+	for !p.Done() {
+		if err := p.EndFrame(w); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	var div *Divergence
+	if !errors.As(first, &div) || div.Frame != 1 {
+		t.Fatalf("expected divergence at frame 1, got %v", first)
+	}
+}
+
+// driftSystem moves every entity without input, like AI steering.
+type driftSystem struct{}
+
+func (driftSystem) Update(w *ecs.World) {
+	scale := w.Clock().StepScale()
+	ecs.NewQuery[*ecs.Position](w).Each(func(_ *ecs.Entity, p *ecs.Position) {
+		p.X += 0.25 * scale
+	})
+}
+
+// session mirrors core.GameWorld: whether the simulation runs is decided
+// once per frame, before Advance, from pause.
+type session struct {
+	w      *ecs.World
+	pause  func() bool
+	paused bool
+}
+
+func newPausableSession(pause func() bool) *session {
+	s := &session{w: newSession(), pause: pause}
+	s.w.AddSystem(driftSystem{})
+	s.w.AddPhaseCondition(ecs.PhaseSimulation, func(*ecs.World) bool { return !s.paused })
+	return s
+}
+
+func (s *session) frame() {
+	s.paused = s.pause()
+	s.w.Advance(ecs.DefaultStep)
+}
+
+func TestReplayFreezesFramesRecordedWithConsoleOpen(t *testing.T) {
+	frames := 20
+	consoleOpen := false
+
+	rec := NewRecorder()
+	live := newPausableSession(func() bool { return consoleOpen })
+	rec.SetPauseSource(func() bool { return live.paused })
+	input.ManagerInstance().SetSource(func() (float64, float64) { return 1, 0.5 })
+	for i := 0; i < frames; i++ {
+		consoleOpen = i >= 5 && i < 12 // opened mid-recording, then closed
+		live.frame()
+		rec.EndFrame(live.w)
+	}
+	input.ManagerInstance().SetSource(nil)
+
+	f := rec.File()
+	for i, fr := range f.Frames {
+		paused := i >= 5 && i < 12
+		if fr.Paused != paused {
+			t.Fatalf("frame %d: expected paused=%v, got %v", i, paused, fr.Paused)
+		}
+		if paused && (fr.MoveX != 0 || fr.MoveY != 0) {
+			t.Fatalf("frame %d: expected no movement recorded while paused, got (%v,%v)", i, fr.MoveX, fr.MoveY)
+		}
+	}
+	if f.Frames[5].Checksum != f.Frames[4].Checksum {
+		t.Fatalf("expected the world frozen while the console was open")
+	}
+
+	// Playback never opens the console; the recorded flag freezes it instead.
+	p := NewPlayer(f)
+	defer p.Close()
+	replayed := newPausableSession(p.Paused)
+	for !p.Done() {
+		replayed.frame()
+		if err := p.EndFrame(replayed.w); err != nil {
+			t.Fatalf("unexpected divergence: %v", err)
+		}
+	}
+}
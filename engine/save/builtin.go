@@ -0,0 +1,323 @@
+package save
+
+import (
+	"time"
+
+	"rp-go/engine/ecs"
+)
+
+/*───────────────────────────────────────────────*
+| BUILT-IN COMPONENT CODECS                     |
+*───────────────────────────────────────────────*/
+
+// DefaultRegistry returns a registry with codecs for every component that
+// makes up a persistent actor.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	RegisterBuiltins(r)
+	return r
+}
+
+// RegisterBuiltins installs the engine's built-in component codecs.
+func RegisterBuiltins(r *Registry) {
+	Register(r, encodeActor, decodeActor)
+	Register(r, encodePosition, decodePosition)
+	Register(r, encodeVelocity, decodeVelocity)
+	Register(r, encodeSprite, decodeSprite)
+	Register(r, encodeHealth, decodeHealth)
+	Register(r, encodeAIController, decodeAIController)
+	Register(r, encodeScriptState, decodeScriptState)
+	Register(r, encodeTreeState, decodeTreeState)
+	Register(r, encodeStateMachine, decodeStateMachine)
+	Register(r, encodeSteering, decodeSteering)
+	Register(r, encodePlayerInput, decodePlayerInput)
+	Register(r, encodeCameraTarget, decodeCameraTarget)
+	Register(r, encodeCollider, decodeCollider)
+}
+
+type actorRecord struct {
+	ID         string   `json:"id"`
+	Archetype  string   `json:"archetype,omitempty"`
+	Persistent bool     `json:"persistent"`
+	AIRefs     []string `json:"ai_refs,omitempty"`
+}
+
+func encodeActor(a *ecs.Actor) actorRecord {
+	return actorRecord{ID: a.ID, Archetype: a.Archetype, Persistent: a.Persistent, AIRefs: a.AIRefs}
+}
+
+func decodeActor(r actorRecord, _ *Context) (*ecs.Actor, error) {
+	return &ecs.Actor{ID: r.ID, Archetype: r.Archetype, Persistent: r.Persistent, AIRefs: r.AIRefs}, nil
+}
+
+type positionRecord struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+func encodePosition(p *ecs.Position) positionRecord {
+	return positionRecord{X: p.X, Y: p.Y}
+}
+
+func decodePosition(r positionRecord, _ *Context) (*ecs.Position, error) {
+	return &ecs.Position{X: r.X, Y: r.Y}, nil
+}
+
+type velocityRecord struct {
+	VX float64 `json:"vx"`
+	VY float64 `json:"vy"`
+}
+
+func encodeVelocity(v *ecs.Velocity) velocityRecord {
+	return velocityRecord{VX: v.VX, VY: v.VY}
+}
+
+func decodeVelocity(r velocityRecord, _ *Context) (*ecs.Velocity, error) {
+	return &ecs.Velocity{VX: r.VX, VY: r.VY}, nil
+}
+
+type spriteRecord struct {
+	Image          string  `json:"image"`
+	Width          int     `json:"width"`
+	Height         int     `json:"height"`
+	Rotation       float64 `json:"rotation,omitempty"`
+	FlipHorizontal bool    `json:"flip_horizontal,omitempty"`
+	PixelPerfect   bool    `json:"pixel_perfect,omitempty"`
+}
+
+func encodeSprite(s *ecs.Sprite) spriteRecord {
+	return spriteRecord{
+		Image:          s.ImagePath,
+		Width:          s.Width,
+		Height:         s.Height,
+		Rotation:       s.Rotation,
+		FlipHorizontal: s.FlipHorizontal,
+		PixelPerfect:   s.PixelPerfect,
+	}
+}
+
+func decodeSprite(r spriteRecord, ctx *Context) (*ecs.Sprite, error) {
+	s := &ecs.Sprite{
+		ImagePath:      r.Image,
+		Width:          r.Width,
+		Height:         r.Height,
+		Rotation:       r.Rotation,
+		FlipHorizontal: r.FlipHorizontal,
+		PixelPerfect:   r.PixelPerfect,
+	}
+	if r.Image != "" && ctx.LoadImage != nil {
+		s.Image = ctx.LoadImage(r.Image)
+	}
+	return s, nil
+}
+
+type healthRecord struct {
+	Current float64 `json:"current"`
+	Max     float64 `json:"max"`
+}
+
+func encodeHealth(h *ecs.Health) healthRecord {
+	return healthRecord{Current: h.Current, Max: h.Max}
+}
+
+func decodeHealth(r healthRecord, _ *Context) (*ecs.Health, error) {
+	return &ecs.Health{Current: r.Current, Max: r.Max}, nil
+}
+
+type aiControllerRecord struct {
+	Active  bool                   `json:"active"`
+	Speed   float64                `json:"speed,omitempty"`
+	Actions []ecs.AIActionInstance `json:"actions,omitempty"`
+
+	Follow  *ecs.AIFollowBehavior  `json:"follow,omitempty"`
+	Pursue  *ecs.AIPursueBehavior  `json:"pursue,omitempty"`
+	Patrol  *ecs.AIPathBehavior    `json:"patrol,omitempty"`
+	Retreat *ecs.AIRetreatBehavior `json:"retreat,omitempty"`
+	Travel  *ecs.AIPathBehavior    `json:"travel,omitempty"`
+
+	PatrolState ecs.AIPathState `json:"patrol_state"`
+	TravelState ecs.AIPathState `json:"travel_state"`
+}
+
+func encodeAIController(c *ecs.AIController) aiControllerRecord {
+	return aiControllerRecord{
+		Active:      c.Active,
+		Speed:       c.Speed,
+		Actions:     rawActions(c.Actions),
+		Follow:      c.Follow,
+		Pursue:      c.Pursue,
+		Patrol:      c.Patrol,
+		Retreat:     c.Retreat,
+		Travel:      c.Travel,
+		PatrolState: c.PatrolState,
+		TravelState: c.TravelState,
+	}
+}
+
+// rawActions drops decoded params, which are runtime-only and may hold
+// types the binary codec does not know.
+func rawActions(acts []ecs.AIActionInstance) []ecs.AIActionInstance {
+	if len(acts) == 0 {
+		return nil
+	}
+	out := make([]ecs.AIActionInstance, len(acts))
+	for i, a := range acts {
+		a.Decoded = nil
+		out[i] = a
+	}
+	return out
+}
+
+func decodeAIController(r aiControllerRecord, _ *Context) (*ecs.AIController, error) {
+	return &ecs.AIController{
+		Active:      r.Active,
+		Speed:       r.Speed,
+		Actions:     r.Actions,
+		Follow:      r.Follow,
+		Pursue:      r.Pursue,
+		Patrol:      r.Patrol,
+		Retreat:     r.Retreat,
+		Travel:      r.Travel,
+		PatrolState: r.PatrolState,
+		TravelState: r.TravelState,
+	}, nil
+}
+
+type scriptStateRecord struct {
+	Current int           `json:"current"`
+	NextAt  time.Duration `json:"next_at"`
+}
+
+func encodeScriptState(s *ecs.ScriptState) scriptStateRecord {
+	return scriptStateRecord{Current: s.Current, NextAt: s.NextAt}
+}
+
+func decodeScriptState(r scriptStateRecord, ctx *Context) (*ecs.ScriptState, error) {
+	return &ecs.ScriptState{Current: r.Current, NextAt: ctx.Rebase(r.NextAt)}, nil
+}
+
+type treeNodeRecord struct {
+	Index int           `json:"index,omitempty"`
+	Until time.Duration `json:"until,omitempty"`
+}
+
+type treeStateRecord struct {
+	Nodes map[string]treeNodeRecord `json:"nodes,omitempty"`
+}
+
+func encodeTreeState(s *ecs.TreeState) treeStateRecord {
+	r := treeStateRecord{Nodes: make(map[string]treeNodeRecord, len(s.Nodes))}
+	for key, n := range s.Nodes {
+		r.Nodes[key] = treeNodeRecord{Index: n.Index, Until: n.Until}
+	}
+	return r
+}
+
+func decodeTreeState(r treeStateRecord, ctx *Context) (*ecs.TreeState, error) {
+	s := &ecs.TreeState{Nodes: make(map[string]ecs.TreeNodeState, len(r.Nodes))}
+	for key, n := range r.Nodes {
+		if n.Until != 0 { // zero on nodes that keep no timer
+			n.Until = ctx.Rebase(n.Until)
+		}
+		s.Nodes[key] = ecs.TreeNodeState{Index: n.Index, Until: n.Until}
+	}
+	return s, nil
+}
+
+type stateMachineRecord struct {
+	Machine string        `json:"machine"`
+	State   string        `json:"state"`
+	Since   time.Duration `json:"since"`
+}
+
+func encodeStateMachine(m *ecs.AIStateMachine) stateMachineRecord {
+	return stateMachineRecord{Machine: m.Machine, State: m.State, Since: m.Since}
+}
+
+func decodeStateMachine(r stateMachineRecord, ctx *Context) (*ecs.AIStateMachine, error) {
+	return &ecs.AIStateMachine{Machine: r.Machine, State: r.State, Since: ctx.Rebase(r.Since)}, nil
+}
+
+type steeringRecord struct {
+	VX     float64 `json:"vx"`
+	VY     float64 `json:"vy"`
+	Wander float64 `json:"wander,omitempty"`
+	Group  string  `json:"group,omitempty"`
+}
+
+func encodeSteering(s *ecs.Steering) steeringRecord {
+	return steeringRecord{VX: s.VX, VY: s.VY, Wander: s.Wander, Group: s.Group}
+}
+
+func decodeSteering(r steeringRecord, _ *Context) (*ecs.Steering, error) {
+	return &ecs.Steering{VX: r.VX, VY: r.VY, Wander: r.Wander, Group: r.Group}, nil
+}
+
+type playerInputRecord struct {
+	Enabled bool `json:"enabled"`
+}
+
+func encodePlayerInput(p *ecs.PlayerInput) playerInputRecord {
+	return playerInputRecord{Enabled: p.Enabled}
+}
+
+func decodePlayerInput(r playerInputRecord, _ *Context) (*ecs.PlayerInput, error) {
+	return &ecs.PlayerInput{Enabled: r.Enabled}, nil
+}
+
+type cameraTargetRecord struct{}
+
+func encodeCameraTarget(*ecs.CameraTarget) cameraTargetRecord { return cameraTargetRecord{} }
+
+func decodeCameraTarget(cameraTargetRecord, *Context) (*ecs.CameraTarget, error) {
+	return &ecs.CameraTarget{}, nil
+}
+
+type colliderRecord struct {
+	Shape    ecs.ColliderShape     `json:"shape"`
+	Radius   float64               `json:"radius,omitempty"`
+	HalfW    float64               `json:"half_w,omitempty"`
+	HalfH    float64               `json:"half_h,omitempty"`
+	Points   []ecs.Vec2            `json:"points,omitempty"`
+	Offset   ecs.Vec2              `json:"offset"`
+	Layer    uint32                `json:"layer,omitempty"`
+	Mask     uint32                `json:"mask,omitempty"`
+	Trigger  bool                  `json:"trigger,omitempty"`
+	Static   bool                  `json:"static,omitempty"`
+	Response ecs.CollisionResponse `json:"response,omitempty"`
+	Bounce   float64               `json:"bounce,omitempty"`
+}
+
+func encodeCollider(c *ecs.Collider) colliderRecord {
+	return colliderRecord{
+		Shape:    c.Shape,
+		Radius:   c.Radius,
+		HalfW:    c.HalfW,
+		HalfH:    c.HalfH,
+		Points:   c.Points,
+		Offset:   c.Offset,
+		Layer:    c.Layer,
+		Mask:     c.Mask,
+		Trigger:  c.Trigger,
+		Static:   c.Static,
+		Response: c.Response,
+		Bounce:   c.Bounce,
+	}
+}
+
+func decodeCollider(r colliderRecord, _ *Context) (*ecs.Collider, error) {
+	return &ecs.Collider{
+		Shape:    r.Shape,
+		Radius:   r.Radius,
+		HalfW:    r.HalfW,
+		HalfH:    r.HalfH,
+		Points:   r.Points,
+		Offset:   r.Offset,
+		Layer:    r.Layer,
+		Mask:     r.Mask,
+		Trigger:  r.Trigger,
+		Static:   r.Static,
+		Response: r.Response,
+		Bounce:   r.Bounce,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package save
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/gfx"
+	"rp-go/engine/platform"
+)
+
+/*───────────────────────────────────────────────*
+| CODEC REGISTRY                                |
+*───────────────────────────────────────────────*/
+
+// Context carries the resources codecs need while decoding.
+type Context struct {
+	// LoadImage resolves sprite asset paths. Defaults to gfx.LoadImage.
+	LoadImage func(path string) *platform.Image
+
+	shift time.Duration // loading world's Clock.Elapsed minus the saved one
+}
+
+// Rebase moves a saved Clock.Elapsed timestamp onto the loading world's
+// clock, so timers keep the time they had left.
+func (c *Context) Rebase(t time.Duration) time.Duration {
+	if c == nil {
+		return t
+	}
+	return t + c.shift
+}
+
+// codec converts one component type to and from a plain record value that
+// both the JSON and binary encoders can serialize.
+type codec struct {
+	empty      bool // zero-size record; carries no payload in binary saves
+	encode     func(ecs.Component) (any, bool)
+	decodeJSON func(json.RawMessage, *Context) (ecs.Component, error)
+	decodeData func(any, *Context) (ecs.Component, error)
+}
+
+// Registry maps Component.Name() to the codec used to persist it.
+type Registry struct {
+	codecs  map[string]codec
+	Context Context
+}
+
+// NewRegistry returns an empty registry that resolves images via gfx.
+func NewRegistry() *Registry {
+	return &Registry{
+		codecs:  make(map[string]codec),
+		Context: Context{LoadImage: gfx.LoadImage},
+	}
+}
+
+// Register installs a codec for component type C, keyed by C's Name().
+// D is the on-disk record; it must be a distinct type per component so the
+// binary encoder can tell records apart.
+func Register[C ecs.Component, D any](r *Registry, encode func(C) D, decode func(D, *Context) (C, error)) {
+	var zero C
+	name := zero.Name()
+
+	var sample D
+	empty := reflect.TypeOf(sample).Size() == 0
+	if !empty {
+		gob.RegisterName("save."+name, sample)
+	}
+
+	r.codecs[name] = codec{
+		empty: empty,
+		encode: func(c ecs.Component) (any, bool) {
+			typed, ok := c.(C)
+			if !ok {
+				return nil, false
+			}
+			return encode(typed), true
+		},
+		decodeJSON: func(raw json.RawMessage, ctx *Context) (ecs.Component, error) {
+			var rec D
+			if err := json.Unmarshal(raw, &rec); err != nil {
+				return nil, err
+			}
+			return decode(rec, ctx)
+		},
+		decodeData: func(v any, ctx *Context) (ecs.Component, error) {
+			if v == nil && empty {
+				return decode(sample, ctx)
+			}
+			rec, ok := v.(D)
+			if !ok {
+				return nil, fmt.Errorf("unexpected record %T for %s", v, name)
+			}
+			return decode(rec, ctx)
+		},
+	}
+}
+
+// Has reports whether a codec is registered for the component name.
+func (r *Registry) Has(name string) bool {
+	_, ok := r.codecs[name]
+	return ok
+}
+
+// Names lists registered component names in sorted order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.codecs))
+	for name := range r.codecs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// context returns the decoding context for a save taken at elapsed and
+// loaded into w.
+func (r *Registry) context(elapsed time.Duration, w *ecs.World) *Context {
+	ctx := r.Context
+	if ctx.LoadImage == nil {
+		ctx.LoadImage = gfx.LoadImage
+	}
+	ctx.shift = w.Clock().Elapsed() - elapsed
+	return &ctx
+}
+
+func init() {
+	// AIActionInstance.Params holds arbitrary JSON-shaped values.
+	gob.Register(map[string]any{})
+	gob.Register([]any{})
+}
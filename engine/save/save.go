@@ -0,0 +1,259 @@
+package save
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"rp-go/engine/ecs"
+)
+
+/*───────────────────────────────────────────────*
+| SAVE FILE FORMAT                              |
+*───────────────────────────────────────────────*/
+
+// Version is the current save format version. Loading a newer file fails.
+const Version = 1
+
+// binaryMagic prefixes every binary save so stray files are rejected early.
+var binaryMagic = []byte("RPSAVE")
+
+// File is the in-memory form of a save. Elapsed is the saved world's
+// Clock.Elapsed; timers in the save are moved by the difference to the
+// loading world's clock.
+type File struct {
+	Version  int            `json:"version"`
+	Elapsed  time.Duration  `json:"elapsed,omitempty"`
+	Entities []EntityRecord `json:"entities"`
+}
+
+// EntityRecord holds every persisted component of one entity. ID is the
+// entity's ID at save time; loaded entities receive fresh IDs.
+type EntityRecord struct {
+	ID         ecs.EntityID      `json:"id"`
+	Components []ComponentRecord `json:"components"`
+}
+
+// ComponentRecord pairs a Component.Name() with its codec record.
+type ComponentRecord struct {
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+// ErrUnsupportedVersion is returned when a save was written by a newer build.
+var ErrUnsupportedVersion = errors.New("save: unsupported version")
+
+/*───────────────────────────────────────────────*
+| SNAPSHOT / RESTORE                            |
+*───────────────────────────────────────────────*/
+
+// Snapshot captures every entity whose Actor is marked Persistent.
+// Components without a registered codec are skipped.
+func (r *Registry) Snapshot(w *ecs.World) *File {
+	f := &File{Version: Version}
+	if w == nil {
+		return f
+	}
+	f.Elapsed = w.Clock().Elapsed()
+	w.EntitiesManager().ForEach(func(e *ecs.Entity) {
+		actor := ecs.GetTyped[*ecs.Actor](e, "Actor")
+		if actor == nil || !actor.Persistent {
+			return
+		}
+		f.Entities = append(f.Entities, r.snapshotEntity(e))
+	})
+	return f
+}
+
+func (r *Registry) snapshotEntity(e *ecs.Entity) EntityRecord {
+	names := make([]string, 0, len(e.Components))
+	for name := range e.Components {
+		if r.Has(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	rec := EntityRecord{ID: e.ID, Components: make([]ComponentRecord, 0, len(names))}
+	for _, name := range names {
+		data, ok := r.codecs[name].encode(e.Components[name])
+		if !ok {
+			continue
+		}
+		rec.Components = append(rec.Components, ComponentRecord{Type: name, Data: data})
+	}
+	return rec
+}
+
+// Restore spawns every entity in f into w and returns them in file order.
+func (r *Registry) Restore(f *File, w *ecs.World) ([]*ecs.Entity, error) {
+	if f == nil || w == nil {
+		return nil, nil
+	}
+	if f.Version > Version {
+		return nil, fmt.Errorf("%w %d (max %d)", ErrUnsupportedVersion, f.Version, Version)
+	}
+	ctx := r.context(f.Elapsed, w)
+	return r.restore(f.Entities, w, func(c codec, rec ComponentRecord) (ecs.Component, error) {
+		return c.decodeData(rec.Data, ctx)
+	})
+}
+
+func (r *Registry) restore(records []EntityRecord, w *ecs.World, decode func(codec, ComponentRecord) (ecs.Component, error)) ([]*ecs.Entity, error) {
+	spawned := make([]*ecs.Entity, 0, len(records))
+	for _, rec := range records {
+		comps := make([]ecs.Component, 0, len(rec.Components))
+		for _, cr := range rec.Components {
+			c, ok := r.codecs[cr.Type]
+			if !ok {
+				fmt.Printf("[SAVE] Skipping unknown component %q on entity %d\n", cr.Type, rec.ID)
+				continue
+			}
+			comp, err := decode(c, cr)
+			if err != nil {
+				return spawned, fmt.Errorf("save: entity %d component %s: %w", rec.ID, cr.Type, err)
+			}
+			comps = append(comps, comp)
+		}
+
+		e := w.NewEntity()
+		for _, comp := range comps {
+			e.Add(comp)
+		}
+		spawned = append(spawned, e)
+	}
+	return spawned, nil
+}
+
+/*───────────────────────────────────────────────*
+| JSON FORM                                     |
+*───────────────────────────────────────────────*/
+
+type jsonFile struct {
+	Version  int           `json:"version"`
+	Elapsed  time.Duration `json:"elapsed"`
+	Entities []struct {
+		ID         ecs.EntityID `json:"id"`
+		Components []struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		} `json:"components"`
+	} `json:"entities"`
+}
+
+// WriteJSON writes an indented, diff-friendly save of w.
+func (r *Registry) WriteJSON(out io.Writer, w *ecs.World) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.Snapshot(w))
+}
+
+// ReadJSON loads a JSON save into w.
+func (r *Registry) ReadJSON(in io.Reader, w *ecs.World) ([]*ecs.Entity, error) {
+	var raw jsonFile
+	if err := json.NewDecoder(in).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("save: decode json: %w", err)
+	}
+	if raw.Version > Version {
+		return nil, fmt.Errorf("%w %d (max %d)", ErrUnsupportedVersion, raw.Version, Version)
+	}
+
+	records := make([]EntityRecord, len(raw.Entities))
+	for i, e := range raw.Entities {
+		records[i].ID = e.ID
+		for _, c := range e.Components {
+			records[i].Components = append(records[i].Components, ComponentRecord{Type: c.Type, Data: c.Data})
+		}
+	}
+
+	ctx := r.context(raw.Elapsed, w)
+	return r.restore(records, w, func(c codec, rec ComponentRecord) (ecs.Component, error) {
+		return c.decodeJSON(rec.Data.(json.RawMessage), ctx)
+	})
+}
+
+/*───────────────────────────────────────────────*
+| BINARY FORM                                   |
+*───────────────────────────────────────────────*/
+
+// WriteBinary writes a compact gob-encoded save of w.
+func (r *Registry) WriteBinary(out io.Writer, w *ecs.World) error {
+	f := r.Snapshot(w)
+	for i := range f.Entities {
+		for j, c := range f.Entities[i].Components {
+			if r.codecs[c.Type].empty {
+				f.Entities[i].Components[j].Data = nil
+			}
+		}
+	}
+
+	if _, err := out.Write(binaryMagic); err != nil {
+		return err
+	}
+	return gob.NewEncoder(out).Encode(f)
+}
+
+// ReadBinary loads a binary save into w.
+func (r *Registry) ReadBinary(in io.Reader, w *ecs.World) ([]*ecs.Entity, error) {
+	br := bufio.NewReader(in)
+	magic := make([]byte, len(binaryMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, binaryMagic) {
+		return nil, errors.New("save: not a binary save file")
+	}
+
+	var f File
+	if err := gob.NewDecoder(br).Decode(&f); err != nil {
+		return nil, fmt.Errorf("save: decode binary: %w", err)
+	}
+	return r.Restore(&f, w)
+}
+
+/*───────────────────────────────────────────────*
+| FILE HELPERS                                  |
+*───────────────────────────────────────────────*/
+
+// SaveFile writes w to path, choosing JSON for ".json" and binary otherwise.
+func (r *Registry) SaveFile(path string, w *ecs.World) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf := bufio.NewWriter(f)
+	if isJSONPath(path) {
+		err = r.WriteJSON(buf, w)
+	} else {
+		err = r.WriteBinary(buf, w)
+	}
+	if err != nil {
+		return err
+	}
+	return buf.Flush()
+}
+
+// LoadFile reads a save written by SaveFile into w.
+func (r *Registry) LoadFile(path string, w *ecs.World) ([]*ecs.Entity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if isJSONPath(path) {
+		return r.ReadJSON(f, w)
+	}
+	return r.ReadBinary(f, w)
+}
+
+func isJSONPath(path string) bool {
+	return filepath.Ext(path) == ".json"
+}
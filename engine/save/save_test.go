@@ -0,0 +1,163 @@
+package save
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/platform"
+)
+
+func populate(w *ecs.World) {
+	player := w.NewEntity()
+	player.Add(&ecs.Actor{ID: "player", Archetype: "ship", Persistent: true})
+	player.Add(&ecs.Position{X: 10, Y: 20})
+	player.Add(&ecs.Velocity{VX: 1.5})
+	player.Add(&ecs.Sprite{ImagePath: "assets/entities/ship.png", Width: 64, Height: 64, PixelPerfect: true})
+	player.Add(&ecs.Health{Current: 80, Max: 100})
+	player.Add(&ecs.CameraTarget{})
+	player.Add(&ecs.Collider{
+		Shape:    ecs.ShapePolygon,
+		Points:   []ecs.Vec2{{X: 0, Y: -30}, {X: 24, Y: 24}, {X: -24, Y: 24}},
+		Mask:     3,
+		Response: ecs.ResponseBounce,
+		Bounce:   0.5,
+	})
+	player.Add(&ecs.ScriptState{Current: 2, NextAt: 1500 * time.Millisecond})
+	player.Add(&ecs.AIController{
+		Active:  true,
+		Speed:   3,
+		Actions: []ecs.AIActionInstance{{Name: "patrol", Type: "patrol", Priority: 1, Params: map[string]any{"speed": 2.0}}},
+		Patrol:  &ecs.AIPathBehavior{Variant: "loop", Waypoints: []ecs.AIWaypoint{{X: 1, Y: 2}}},
+		PatrolState: ecs.AIPathState{
+			Index:   1,
+			Forward: true,
+		},
+	})
+
+	transient := w.NewEntity()
+	transient.Add(&ecs.Actor{ID: "debris"})
+	transient.Add(&ecs.Position{X: 99})
+}
+
+func TestJSONAndBinaryRoundTripPersistentEntities(t *testing.T) {
+	src := ecs.NewWorld()
+	populate(src)
+
+	reg := DefaultRegistry()
+	var loaded []string
+	reg.Context.LoadImage = func(path string) *platform.Image {
+		loaded = append(loaded, path)
+		return platform.NewImage(64, 64)
+	}
+
+	var want bytes.Buffer
+	if err := reg.WriteJSON(&want, src); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+
+	fromJSON := ecs.NewWorld()
+	if _, err := reg.ReadJSON(bytes.NewReader(want.Bytes()), fromJSON); err != nil {
+		t.Fatalf("read json: %v", err)
+	}
+
+	var bin bytes.Buffer
+	if err := reg.WriteBinary(&bin, src); err != nil {
+		t.Fatalf("write binary: %v", err)
+	}
+	if bin.Len() >= want.Len() {
+		t.Fatalf("expected binary save (%d bytes) to be smaller than json (%d bytes)", bin.Len(), want.Len())
+	}
+	fromBinary := ecs.NewWorld()
+	if _, err := reg.ReadBinary(&bin, fromBinary); err != nil {
+		t.Fatalf("read binary: %v", err)
+	}
+
+	for label, w := range map[string]*ecs.World{"json": fromJSON, "binary": fromBinary} {
+		if n := w.EntitiesManager().Count(); n != 1 {
+			t.Fatalf("%s: expected only the persistent entity, got %d", label, n)
+		}
+		_, sprite := ecs.NewQuery[*ecs.Sprite](w).First()
+		if sprite == nil || sprite.Image == nil {
+			t.Fatalf("%s: expected sprite image to be re-resolved", label)
+		}
+
+		var got bytes.Buffer
+		if err := reg.WriteJSON(&got, w); err != nil {
+			t.Fatalf("%s: rewrite json: %v", label, err)
+		}
+		if !bytes.Equal(got.Bytes(), want.Bytes()) {
+			t.Fatalf("%s: round trip mismatch\nwant: %s\ngot:  %s", label, want.String(), got.String())
+		}
+	}
+
+	if len(loaded) != 2 || loaded[0] != "assets/entities/ship.png" {
+		t.Fatalf("expected images to load through the context, got %v", loaded)
+	}
+}
+
+func TestReadRejectsNewerVersion(t *testing.T) {
+	in := bytes.NewBufferString(`{"version": 99, "entities": []}`)
+	if _, err := DefaultRegistry().ReadJSON(in, ecs.NewWorld()); err == nil {
+		t.Fatalf("expected newer save version to be rejected")
+	}
+}
+
+// worldAt returns a world whose clock has run for elapsed whole seconds.
+func worldAt(elapsed int) *ecs.World {
+	w := ecs.NewWorld()
+	w.Clock().Step = time.Second
+	for i := 0; i < elapsed; i++ {
+		w.Advance(time.Second)
+	}
+	return w
+}
+
+func TestTimersKeepTimeLeftAcrossClocks(t *testing.T) {
+	src := worldAt(10)
+	guard := src.NewEntity()
+	guard.Add(&ecs.Actor{ID: "guard", Persistent: true})
+	guard.Add(&ecs.ScriptState{Current: 1, NextAt: 10500 * time.Millisecond})
+	guard.Add(&ecs.TreeState{Nodes: map[string]ecs.TreeNodeState{
+		"guard/root":             {Index: 1},
+		"guard/root.children[1]": {Until: 12 * time.Second},
+	}})
+	guard.Add(&ecs.AIStateMachine{Machine: "sentry", State: "patrol", Since: 9 * time.Second})
+
+	reg := DefaultRegistry()
+	var js, bin bytes.Buffer
+	if err := reg.WriteJSON(&js, src); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	if err := reg.WriteBinary(&bin, src); err != nil {
+		t.Fatalf("write binary: %v", err)
+	}
+
+	read := map[string]func(*ecs.World) ([]*ecs.Entity, error){
+		"json":   func(w *ecs.World) ([]*ecs.Entity, error) { return reg.ReadJSON(bytes.NewReader(js.Bytes()), w) },
+		"binary": func(w *ecs.World) ([]*ecs.Entity, error) { return reg.ReadBinary(bytes.NewReader(bin.Bytes()), w) },
+	}
+	for label, load := range read {
+		dst := worldAt(1)
+		loaded, err := load(dst)
+		if err != nil || len(loaded) != 1 {
+			t.Fatalf("%s: load: %v", label, err)
+		}
+		e := loaded[0]
+
+		if s := ecs.GetTyped[*ecs.ScriptState](e, "AIScriptState"); s.NextAt != 1500*time.Millisecond {
+			t.Errorf("%s: expected the script delay to end 500ms after load, at 1.5s, got %v", label, s.NextAt)
+		}
+		tree := ecs.GetTyped[*ecs.TreeState](e, "AITreeState")
+		if n := tree.Nodes["guard/root.children[1]"]; n.Until != 3*time.Second {
+			t.Errorf("%s: expected the cooldown to end 2s after load, at 3s, got %v", label, n.Until)
+		}
+		if n := tree.Nodes["guard/root"]; n != (ecs.TreeNodeState{Index: 1}) {
+			t.Errorf("%s: expected timer-less nodes unchanged, got %+v", label, n)
+		}
+		if m := ecs.GetTyped[*ecs.AIStateMachine](e, "AIStateMachine"); m.Since != 0 {
+			t.Errorf("%s: expected the state entered 1s before load, at 0s, got %v", label, m.Since)
+		}
+	}
+}
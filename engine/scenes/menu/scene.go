@@ -8,6 +8,7 @@ import (
 
 	"rp-go/engine/ecs"
 	"rp-go/engine/events"
+	"rp-go/engine/locale"
 	"rp-go/engine/platform"
 )
 
@@ -75,8 +76,8 @@ func (s *Scene) Draw(w *ecs.World, screen *platform.Image) {
 	// background
 	screen.Fill(color.RGBA{R: 6, G: 12, B: 18, A: 255})
 
-	title := "R P G   P R O J E C T"
-	sub := "Press Enter to Start"
+	title := locale.T("menu.title")
+	sub := locale.T("menu.start")
 
 	// center text roughly using offsets
 	platform.DrawText(screen, title, platform.DefaultFont(), w/2-160, h/2-40, color.White)
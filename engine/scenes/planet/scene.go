@@ -10,7 +10,11 @@ import (
 	"rp-go/engine/ecs"
 	"rp-go/engine/events"
 	"rp-go/engine/gfx"
+	"rp-go/engine/locale"
+	"rp-go/engine/nav"
 	"rp-go/engine/platform"
+	"rp-go/engine/systems/ai"
+	dataSys "rp-go/engine/systems/data"
 	"rp-go/engine/world"
 )
 
@@ -23,6 +27,7 @@ type Scene struct {
 	ctx        *world.WorldContext
 	landTimer  float64
 	player     *ecs.Entity
+	nav        *nav.Service
 }
 
 /*───────────────────────────────────────────────*
@@ -66,6 +71,7 @@ func (s *Scene) Init(w *ecs.World) {
 	s.player.Add(&ecs.Velocity{})
 	s.player.Add(&ecs.Sprite{
 		Image:        gfx.LoadImage("assets/entities/lander.png"),
+		ImagePath:    "assets/entities/lander.png",
 		Width:        64,
 		Height:       64,
 		PixelPerfect: true,
@@ -78,6 +84,7 @@ func (s *Scene) Init(w *ecs.World) {
 	ground.Add(&ecs.Position{X: 0, Y: 300})
 	ground.Add(&ecs.Sprite{
 		Image:        gfx.LoadImage("assets/entities/building.png"),
+		ImagePath:    "assets/entities/building.png",
 		Width:        128,
 		Height:       128,
 		PixelPerfect: true,
@@ -96,9 +103,30 @@ func (s *Scene) Init(w *ecs.World) {
 	}
 	cam.Add(camComp)
 
+	s.applyTerrain(w)
+
 	fmt.Println("[PLANET] Landing sequence starting")
 }
 
+// applyTerrain hands the planet's terrain map to the AI navigator, so AI
+// movement routes around water and mountains. Edits to terrain_planet.json
+// take effect the next time the scene is entered.
+func (s *Scene) applyTerrain(w *ecs.World) {
+	aiSys, _ := w.FindSystem((*ai.System)(nil)).(*ai.System)
+	data, _ := w.FindSystem((*dataSys.System)(nil)).(*dataSys.System)
+	if aiSys == nil || data == nil || aiSys.Navigator() == nil {
+		return
+	}
+	terrain, ok := data.Terrain[s.Name()]
+	if !ok {
+		fmt.Println("[PLANET] No terrain map; AI moves freely")
+		return
+	}
+	s.nav = aiSys.Navigator()
+	s.nav.SetFinder(nav.GridFromTerrain(terrain))
+	fmt.Printf("[PLANET] Terrain %dx%d loaded for pathfinding\n", terrain.Cols, terrain.Rows)
+}
+
 /*───────────────────────────────────────────────*
  | UPDATE                                         |
  *───────────────────────────────────────────────*/
@@ -138,8 +166,8 @@ func (s *Scene) Draw(w *ecs.World, screen *platform.Image) {
 	screen.Fill(color.RGBA{R: 20, G: 10, B: 30, A: 255})
 
 	// Optional: text overlay
-	platform.DrawText(screen, "Planet Surface", platform.DefaultFont(), 20, 32, color.White)
-	platform.DrawText(screen, "Press ENTER to return to space", platform.DefaultFont(), 20, 56, color.RGBA{200, 200, 220, 255})
+	platform.DrawText(screen, locale.T("planet.title"), platform.DefaultFont(), 20, 32, color.White)
+	platform.DrawText(screen, locale.T("planet.return"), platform.DefaultFont(), 20, 56, color.RGBA{200, 200, 220, 255})
 }
 
 /*───────────────────────────────────────────────*
@@ -148,6 +176,10 @@ func (s *Scene) Draw(w *ecs.World, screen *platform.Image) {
 
 func (s *Scene) Unload(w *ecs.World) {
 	fmt.Println("[SCENE] Unload:", s.Name())
+	if s.nav != nil {
+		s.nav.SetFinder(nil)
+		s.nav = nil
+	}
 	s.ctx = nil
 }
 
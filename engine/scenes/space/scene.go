@@ -58,9 +58,11 @@ func (s *Scene) Init(w *ecs.World) {
 	player.Add(&ecs.Velocity{})
 	player.Add(&ecs.PlayerInput{Enabled: true})
 	player.Add(&ecs.CameraTarget{})
+	player.Add(&ecs.Collider{Shape: ecs.ShapeCircle, Radius: 26, Response: ecs.ResponseSlide})
 
 	player.Add(&ecs.Sprite{
 		Image:        gfx.LoadImage("assets/entities/ship.png"),
+		ImagePath:    "assets/entities/ship.png",
 		Width:        64,
 		Height:       64,
 		PixelPerfect: true,
@@ -87,10 +89,12 @@ func (s *Scene) Init(w *ecs.World) {
 	planet.Add(&ecs.Position{X: 350, Y: 180})
 	planet.Add(&ecs.Sprite{
 		Image:        gfx.LoadImage("assets/entities/planet.png"),
+		ImagePath:    "assets/entities/planet.png",
 		Width:        128,
 		Height:       128,
 		PixelPerfect: true,
 	})
+	planet.Add(&ecs.Collider{Shape: ecs.ShapeCircle, Radius: 60, Static: true})
 	fmt.Printf("[SCENE] Planet created (entity %d)\n", planet.ID)
 
 	// ---------------------------------------------------------------------
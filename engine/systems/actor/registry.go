@@ -52,6 +52,30 @@ func (r *Registry) Add(actor *ecs.Actor, entity *ecs.Entity) {
 	r.all = append(r.all, entity)
 }
 
+// Remove drops an actor entity from every index.
+func (r *Registry) Remove(actor *ecs.Actor, entity *ecs.Entity) {
+	if r == nil || entity == nil {
+		return
+	}
+	if actor != nil {
+		if current, ok := r.byID[actor.ID]; ok && current == entity {
+			delete(r.byID, actor.ID)
+		}
+		if actor.Archetype != "" {
+			r.byArchetype[actor.Archetype] = removeEntity(r.byArchetype[actor.Archetype], entity)
+		}
+	}
+	r.all = removeEntity(r.all, entity)
+}
+
+// Len reports how many actors are indexed.
+func (r *Registry) Len() int {
+	if r == nil {
+		return 0
+	}
+	return len(r.all)
+}
+
 // FindByID retrieves the entity associated with a given actor ID.
 func (r *Registry) FindByID(id string) (*ecs.Entity, bool) {
 	if r == nil {
@@ -92,6 +116,54 @@ func (r *Registry) FindByTemplatePrefix(prefix string) []*ecs.Entity {
 	return matches
 }
 
+// FindByTemplate returns the entities spawned from a template, sorted by ID.
+// Spawned actors are named "<template>-<n>", so unlike FindByTemplatePrefix
+// "dark-elf-ship" does not match "dark-elf-ship-raider-001".
+func (r *Registry) FindByTemplate(template string) []*ecs.Entity {
+	if r == nil || template == "" {
+		return nil
+	}
+	var matches []*ecs.Entity
+	for id, e := range r.byID {
+		if FromTemplate(id, template) {
+			matches = append(matches, e)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches
+}
+
+// FindByTag returns the entities carrying the given Tag, sorted by ID.
+// Components of the same name do not count.
+func (r *Registry) FindByTag(tag string) []*ecs.Entity {
+	if r == nil || tag == "" {
+		return nil
+	}
+	var matches []*ecs.Entity
+	for _, e := range r.all {
+		if e.HasTag(tag) {
+			matches = append(matches, e)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	return matches
+}
+
+// FromTemplate reports whether an actor ID was generated for template,
+// i.e. is the template name followed by "-" and an instance number.
+func FromTemplate(id, template string) bool {
+	n, ok := strings.CutPrefix(id, template+"-")
+	if !ok || n == "" {
+		return false
+	}
+	for _, c := range n {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // Entities returns all registered entities as a sorted, independent slice.
 func (r *Registry) Entities() []*ecs.Entity {
 	if r == nil || len(r.all) == 0 {
@@ -120,3 +192,12 @@ func (r *Registry) All() []*ecs.Entity {
 	return r.Entities()
 }
 
+
+func removeEntity(list []*ecs.Entity, target *ecs.Entity) []*ecs.Entity {
+	for i, e := range list {
+		if e == target {
+			return append(list[:i], list[i+1:]...)
+		}
+	}
+	return list
+}
@@ -13,14 +13,17 @@ import (
  | ACTOR SYSTEM                                  |
  *───────────────────────────────────────────────*/
 
-// System maintains and updates the global Actor registry each frame.
-// It synchronizes ECS entities with their Actor components and listens
-// for data reload events to refresh template-based logic.
+// System maintains the global Actor registry. The registry is kept in sync
+// through world lifecycle hooks rather than rebuilt each frame, and the
+// system listens for data reload events to refresh template-based logic.
 type System struct {
 	registry    *Registry
 	templates   data.ActorDatabase // cached actor definitions
 	mu          sync.RWMutex
 	initialized bool
+
+	world  *ecs.World // world the registry is currently bound to
+	unbind []func()
 }
 
 /*───────────────────────────────────────────────*
@@ -42,51 +45,63 @@ func (s *System) Registry() *Registry {
 	return s.registry
 }
 
-// Update rebuilds the actor registry from the ECS world every frame.
-// Ensures that only one PlayerInput is active and avoids AI/input conflicts.
+// Update binds the registry to the world on first use and ensures that
+// only one PlayerInput is active, avoiding AI/input conflicts.
 func (s *System) Update(w *ecs.World) {
+	if w == nil {
+		return
+	}
 	if s.registry == nil {
 		s.registry = NewRegistry()
 	}
-	s.registry.Reset()
-
-	primaryAssigned := false
-
-	manager := w.EntitiesManager()
-	if manager == nil {
-		return
+	if s.world != w {
+		s.bind(w)
 	}
 
-	manager.ForEach(func(e *ecs.Entity) {
-		actor, ok := e.Get("Actor").(*ecs.Actor)
-		if !ok || actor == nil {
+	primaryAssigned := false
+	ecs.NewQuery[*ecs.PlayerInput](w).Each(func(e *ecs.Entity, controller *ecs.PlayerInput) {
+		if !e.Has("Actor") {
 			return
 		}
-
-		// Index this actor
-		s.registry.Add(actor, e)
-
-		// Enforce PlayerInput exclusivity and AI coordination
-		if controller, ok := e.Get("PlayerInput").(*ecs.PlayerInput); ok && controller != nil {
-			if _, hasAI := e.Get("AIController").(*ecs.AIController); hasAI {
-				controller.Enabled = false
-				return
-			}
-			if !primaryAssigned {
-				controller.Enabled = true
-				primaryAssigned = true
-			} else {
-				controller.Enabled = false
-			}
+		if _, hasAI := e.Get("AIController").(*ecs.AIController); hasAI {
+			controller.Enabled = false
+			return
+		}
+		if !primaryAssigned {
+			controller.Enabled = true
+			primaryAssigned = true
+		} else {
+			controller.Enabled = false
 		}
 	})
 
 	if !s.initialized {
-		fmt.Printf("[ACTOR] Registry initialized with %d entities\n", len(s.registry.all))
+		fmt.Printf("[ACTOR] Registry initialized with %d entities\n", s.registry.Len())
 		s.initialized = true
 	}
 }
 
+// bind subscribes the registry to Actor add/remove notifications. Existing
+// actors are replayed by the world, so binding late is safe.
+func (s *System) bind(w *ecs.World) {
+	for _, unsubscribe := range s.unbind {
+		unsubscribe()
+	}
+	s.registry.Reset()
+	s.world = w
+	s.unbind = []func(){
+		w.OnComponentAdded("Actor", func(e *ecs.Entity, c ecs.Component) {
+			if actor, ok := c.(*ecs.Actor); ok {
+				s.registry.Add(actor, e)
+			}
+		}),
+		w.OnComponentRemoved("Actor", func(e *ecs.Entity, c ecs.Component) {
+			actor, _ := c.(*ecs.Actor)
+			s.registry.Remove(actor, e)
+		}),
+	}
+}
+
 /*───────────────────────────────────────────────*
  | DATA RELOAD HOOK                              |
  *───────────────────────────────────────────────*/
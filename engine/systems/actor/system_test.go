@@ -42,6 +42,34 @@ func TestRegistryIndexesActors(t *testing.T) {
 	}
 }
 
+func TestRegistryFindsTemplatesAndTags(t *testing.T) {
+	w := ecs.NewWorld()
+
+	commander := w.NewEntity()
+	commander.Add(&ecs.Actor{ID: "dark-elf-ship-commander-001", Archetype: "enemy"})
+	commander.Add(ecs.Tag("boss"))
+
+	raider := w.NewEntity()
+	raider.Add(&ecs.Actor{ID: "dark-elf-ship-raider-001", Archetype: "enemy"})
+
+	system := NewSystem()
+	system.Update(w)
+	registry := system.Registry()
+
+	if got := registry.FindByTemplate("dark-elf-ship-commander"); len(got) != 1 || got[0] != commander {
+		t.Fatalf("expected commander from its template, got %+v", got)
+	}
+	if got := registry.FindByTemplate("dark-elf-ship"); len(got) != 0 {
+		t.Fatalf("expected no spawns of the bare prefix template, got %+v", got)
+	}
+	if got := registry.FindByTag("boss"); len(got) != 1 || got[0] != commander {
+		t.Fatalf("expected tagged commander, got %+v", got)
+	}
+	if got := registry.FindByTag("Actor"); len(got) != 0 {
+		t.Fatalf("expected component names not to match as tags, got %+v", got)
+	}
+}
+
 func TestActorSystemEnforcesSinglePlayerInput(t *testing.T) {
 	w := ecs.NewWorld()
 
@@ -73,3 +101,25 @@ func TestActorSystemEnforcesSinglePlayerInput(t *testing.T) {
 		t.Fatalf("expected entities with AI to have player input disabled")
 	}
 }
+
+func TestRegistryTracksActorLifecycleIncrementally(t *testing.T) {
+	w := ecs.NewWorld()
+	system := NewSystem()
+	system.Update(w)
+
+	late := w.NewEntity()
+	late.Add(&ecs.Actor{ID: "late-arrival", Archetype: "enemy"})
+
+	registry := system.Registry()
+	if found, ok := registry.FindByID("late-arrival"); !ok || found != late {
+		t.Fatalf("expected actor added after binding to be indexed without another Update")
+	}
+
+	w.RemoveEntity(late)
+	if _, ok := registry.FindByID("late-arrival"); ok {
+		t.Fatalf("expected destroyed actor to be dropped from the registry")
+	}
+	if len(registry.FindByArchetype("enemy")) != 0 || registry.Len() != 0 {
+		t.Fatalf("expected archetype and global indices to be cleared")
+	}
+}
@@ -2,6 +2,7 @@ package ai
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"rp-go/engine/ecs"
@@ -11,10 +12,45 @@ import (
  | BEHAVIOR FUNCTION TYPE                        |
  *───────────────────────────────────────────────*/
 
-// BehaviorFunc defines a single AI action executor.
+// BehaviorFunc defines a single AI action executor working on raw params.
 // Returns true if this action took control (e.g. movement was applied).
 type BehaviorFunc func(world *ecs.World, entity *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, params map[string]any) bool
 
+// Status is the outcome of running a behavior for one frame.
+type Status int
+
+const (
+	Failure Status = iota // could not act (no target, conditions unmet)
+	Success               // finished (patrol loop done, safe distance reached)
+	Running               // acting and wants to continue next frame
+)
+
+func (s Status) String() string {
+	switch s {
+	case Success:
+		return "success"
+	case Running:
+		return "running"
+	}
+	return "failure"
+}
+
+// statusOf maps a took-control result to a Status: behaviors that only
+// report control keep running while they act and fail otherwise.
+func statusOf(tookControl bool) Status {
+	if tookControl {
+		return Running
+	}
+	return Failure
+}
+
+// behavior is a registered handler. decode turns raw ai.json params into
+// the value run receives: a *P for typed behaviors, the map itself otherwise.
+type behavior struct {
+	run    func(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, params any) Status
+	decode func(raw map[string]any) (any, error)
+}
+
 /*───────────────────────────────────────────────*
  | BEHAVIOR CATALOG                              |
  *───────────────────────────────────────────────*/
@@ -23,7 +59,8 @@ type BehaviorFunc func(world *ecs.World, entity *ecs.Entity, pos *ecs.Position,
 // It enables modular registration and hot-swapping of behaviors.
 type BehaviorCatalog struct {
 	mu        sync.RWMutex
-	behaviors map[string]BehaviorFunc
+	behaviors map[string]behavior
+	quiet     bool
 }
 
 // GlobalBehaviorCatalog is the shared instance used by all AI systems.
@@ -32,7 +69,7 @@ var GlobalBehaviorCatalog = NewBehaviorCatalog()
 // NewBehaviorCatalog constructs an empty registry.
 func NewBehaviorCatalog() *BehaviorCatalog {
 	return &BehaviorCatalog{
-		behaviors: make(map[string]BehaviorFunc),
+		behaviors: make(map[string]behavior),
 	}
 }
 
@@ -40,34 +77,127 @@ func NewBehaviorCatalog() *BehaviorCatalog {
  | REGISTRATION                                  |
  *───────────────────────────────────────────────*/
 
-// Register associates a behavior type name (e.g. "pursue") with a handler.
+// Register associates a behavior type name (e.g. "pursue") with a handler
+// that reads params from the raw map.
 func (c *BehaviorCatalog) Register(name string, fn BehaviorFunc) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	if name == "" || fn == nil {
+	if fn == nil {
 		return
 	}
-	c.behaviors[name] = fn
-	fmt.Printf("[AI] Registered behavior: %s\n", name)
+	c.register(name, behavior{
+		run: func(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, params any) Status {
+			m, _ := params.(map[string]any)
+			return statusOf(fn(w, e, pos, vel, m))
+		},
+		decode: func(raw map[string]any) (any, error) { return raw, nil },
+	})
+}
+
+// RegisterBehavior registers a behavior with typed params. ai.json params
+// are decoded over a copy of defaults once per controller; unknown keys and
+// mistyped values are errors.
+func RegisterBehavior[P any](c *BehaviorCatalog, name string, defaults P, fn func(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *P) bool) {
+	if fn == nil {
+		return
+	}
+	RegisterTask(c, name, defaults, func(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *P) Status {
+		return statusOf(fn(w, e, pos, vel, p))
+	})
+}
+
+// RegisterTask registers a typed behavior that reports when it finishes,
+// so sequences and behavior trees can move on to the next step.
+func RegisterTask[P any](c *BehaviorCatalog, name string, defaults P, fn func(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *P) Status) {
+	if fn == nil {
+		return
+	}
+	c.register(name, behavior{
+		run: func(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, params any) Status {
+			p, ok := params.(*P)
+			if !ok {
+				return Failure
+			}
+			return fn(w, e, pos, vel, p)
+		},
+		decode: func(raw map[string]any) (any, error) { return decodeParams(defaults, raw) },
+	})
+}
+
+func (c *BehaviorCatalog) register(name string, b behavior) {
+	if name == "" {
+		return
+	}
+	c.mu.Lock()
+	c.behaviors[name] = b
+	c.mu.Unlock()
+	c.logf("[AI] Registered behavior: %s\n", name)
 }
 
 // Unregister removes a behavior from the catalog.
 func (c *BehaviorCatalog) Unregister(name string) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
 	delete(c.behaviors, name)
-	fmt.Printf("[AI] Unregistered behavior: %s\n", name)
+	c.mu.Unlock()
+	c.logf("[AI] Unregistered behavior: %s\n", name)
+}
+
+// SetQuiet silences the registration log, for tools that register the
+// built-in behaviors only to inspect them.
+func (c *BehaviorCatalog) SetQuiet(quiet bool) {
+	c.mu.Lock()
+	c.quiet = quiet
+	c.mu.Unlock()
+}
+
+func (c *BehaviorCatalog) logf(format string, args ...any) {
+	c.mu.RLock()
+	quiet := c.quiet
+	c.mu.RUnlock()
+	if !quiet {
+		fmt.Printf(format, args...)
+	}
 }
 
-// Get retrieves a behavior handler by name.
+// Get retrieves a behavior handler by name. Typed behaviors decode params
+// on every call; the AI system itself decodes once per controller.
 func (c *BehaviorCatalog) Get(name string) (BehaviorFunc, bool) {
+	b, ok := c.lookup(name)
+	if !ok {
+		return nil, false
+	}
+	return func(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, params map[string]any) bool {
+		p, err := b.decode(params)
+		return err == nil && b.run(w, e, pos, vel, p) == Running
+	}, true
+}
+
+// Decode turns raw params into the value the named behavior runs with.
+func (c *BehaviorCatalog) Decode(name string, params map[string]any) (any, error) {
+	b, ok := c.lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown behavior type %q", name)
+	}
+	return b.decode(params)
+}
+
+// CheckParams reports whether params decode for the named behavior. Unknown
+// types pass; they are reported separately. Plugs into data.LintOptions.
+func (c *BehaviorCatalog) CheckParams(name string, params map[string]any) error {
+	b, ok := c.lookup(name)
+	if !ok {
+		return nil
+	}
+	_, err := b.decode(params)
+	return err
+}
+
+func (c *BehaviorCatalog) lookup(name string) (behavior, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	fn, ok := c.behaviors[name]
-	return fn, ok
+	b, ok := c.behaviors[name]
+	return b, ok
 }
 
-// List returns a snapshot of all registered behavior names.
+// List returns a sorted snapshot of all registered behavior names.
 func (c *BehaviorCatalog) List() []string {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -75,6 +205,7 @@ func (c *BehaviorCatalog) List() []string {
 	for name := range c.behaviors {
 		out = append(out, name)
 	}
+	sort.Strings(out)
 	return out
 }
 
@@ -83,15 +214,21 @@ func (c *BehaviorCatalog) List() []string {
  *───────────────────────────────────────────────*/
 
 // RegisterDefaultBehaviors installs built-in behaviors into the catalog.
-// This should be called once during engine initialization.
+// This should be called once during engine initialization. The values
+// passed here are the params defaults for each behavior.
 func RegisterDefaultBehaviors(sys *System) {
-	GlobalBehaviorCatalog.Register("pursue", sys.behaviorPursue)
-	GlobalBehaviorCatalog.Register("patrol", sys.behaviorPatrol)
-	GlobalBehaviorCatalog.Register("retreat", sys.behaviorRetreat)
-	GlobalBehaviorCatalog.Register("follow", sys.behaviorFollow)
-	GlobalBehaviorCatalog.Register("idle", func(*ecs.World, *ecs.Entity, *ecs.Position, *ecs.Velocity, map[string]any) bool {
+	RegisterBehavior(GlobalBehaviorCatalog, "pursue", PursueParams{EngageDistance: 300, Speed: 2.0}, sys.behaviorPursue)
+	RegisterTask(GlobalBehaviorCatalog, "patrol", PatrolParams{Speed: 2.0}, sys.behaviorPatrol)
+	RegisterTask(GlobalBehaviorCatalog, "retreat", RetreatParams{TriggerDistance: 200, SafeDistance: 320, Speed: 2.4}, sys.behaviorRetreat)
+	RegisterBehavior(GlobalBehaviorCatalog, "follow", FollowParams{MinDistance: 32, Speed: 2.2}, sys.behaviorFollow)
+	RegisterBehavior(GlobalBehaviorCatalog, "steer", steerWith(SteerWeights{Arrive: 1, Avoid: 2}), sys.behaviorSteer)
+	RegisterBehavior(GlobalBehaviorCatalog, "flock", steerWith(SteerWeights{Arrive: 1, Separation: 1.5, Alignment: 0.5, Cohesion: 0.3, Avoid: 2}), sys.behaviorSteer)
+	RegisterBehavior(GlobalBehaviorCatalog, "wander", steerWith(SteerWeights{Wander: 1, Separation: 1, Avoid: 2}), sys.behaviorSteer)
+	RegisterTask(GlobalBehaviorCatalog, "script", ScriptParams{}, sys.behaviorScript)
+	RegisterTask(GlobalBehaviorCatalog, "tree", TreeParams{}, sys.behaviorTree)
+	RegisterTask(GlobalBehaviorCatalog, "fsm", MachineParams{}, sys.behaviorFSM)
+	RegisterBehavior(GlobalBehaviorCatalog, "idle", struct{}{}, func(*ecs.World, *ecs.Entity, *ecs.Position, *ecs.Velocity, *struct{}) bool {
 		return false
 	})
-	fmt.Printf("[AI] Default behaviors registered (%d total)\n", len(GlobalBehaviorCatalog.List()))
+	GlobalBehaviorCatalog.logf("[AI] Default behaviors registered (%d total)\n", len(GlobalBehaviorCatalog.List()))
 }
-
@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"fmt"
 	"math"
 
 	"rp-go/engine/ecs"
@@ -8,28 +9,31 @@ import (
 
 // ConditionSet defines a set of preconditions before an action triggers.
 type ConditionSet struct {
-	Target     string   `json:"target,omitempty"`
-	Within     float64  `json:"within,omitempty"`  // distance must be less than
-	Beyond     float64  `json:"beyond,omitempty"`  // distance must be greater than
+	Target     Selector `json:"target,omitzero"`
+	Within     float64  `json:"within,omitempty"` // distance must be less than
+	Beyond     float64  `json:"beyond,omitempty"` // distance must be greater than
 	HealthLess float64  `json:"health_lt,omitempty"`
 	HealthMore float64  `json:"health_gt,omitempty"`
 }
 
-// checkConditions returns true if all conditions are satisfied.
-func (s *System) checkConditions(w *ecs.World, e *ecs.Entity, cond map[string]any) bool {
-	if len(cond) == 0 {
-		return true
+// decodeConditions decodes an action's conditions strictly; it returns nil
+// when there are none.
+func decodeConditions(raw map[string]any) (*ConditionSet, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	c, err := decodeParams(ConditionSet{}, raw)
+	if err != nil {
+		return nil, fmt.Errorf("conditions: %w", err)
 	}
+	return c, nil
+}
 
-	// Convert to struct for clarity
-	c := ConditionSet{}
-	if v, ok := cond["target"].(string); ok {
-		c.Target = v
+// checkConditions returns true if all conditions are satisfied.
+func (s *System) checkConditions(w *ecs.World, e *ecs.Entity, c *ConditionSet) bool {
+	if c == nil {
+		return true
 	}
-	c.Within = getFloat(cond, "within", 0)
-	c.Beyond = getFloat(cond, "beyond", 0)
-	c.HealthLess = getFloat(cond, "health_lt", 0)
-	c.HealthMore = getFloat(cond, "health_gt", 0)
 
 	// Check health
 	if hp, ok := e.Get("Health").(*ecs.Health); ok && hp != nil && hp.Max > 0 {
@@ -43,21 +47,9 @@ func (s *System) checkConditions(w *ecs.World, e *ecs.Entity, cond map[string]an
 	}
 
 	// Check distance to target
-	if c.Target != "" && (c.Within > 0 || c.Beyond > 0) {
-		var target *ecs.Entity
-		w.EntitiesManager().ForEach(func(ent *ecs.Entity) {
-			if target != nil {
-				return
-			}
-			act, _ := ent.Get("Actor").(*ecs.Actor)
-			if act != nil && act.ID == c.Target {
-				target = ent
-			}
-		})
-		if target != nil {
-			tp, _ := target.Get("Position").(*ecs.Position)
-			p1, _ := e.Get("Position").(*ecs.Position)
-			if tp != nil && p1 != nil {
+	if !c.Target.IsZero() && (c.Within > 0 || c.Beyond > 0) {
+		if tp, ok := s.targetPosition(w, e, c.Target); ok {
+			if p1, _ := e.Get("Position").(*ecs.Position); p1 != nil {
 				d := math.Hypot(tp.X-p1.X, tp.Y-p1.Y)
 				if c.Within > 0 && d > c.Within {
 					return false
@@ -71,4 +63,3 @@ func (s *System) checkConditions(w *ecs.World, e *ecs.Entity, cond map[string]an
 
 	return true
 }
-
@@ -6,40 +6,36 @@ import (
 	"rp-go/engine/ecs"
 )
 
-func (s *System) behaviorFollow(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p map[string]any) bool {
-	targetName, _ := p["target"].(string)
-	speed := getFloat(p, "speed", 2.2)
-	offsetX := getFloat(p, "offset_x", 0)
-	offsetY := getFloat(p, "offset_y", 0)
-	minDist := getFloat(p, "min_distance", 32)
+// FollowParams configures the "follow" behavior.
+type FollowParams struct {
+	Target      Selector `json:"target"`       // actor to follow
+	OffsetX     float64  `json:"offset_x"`     // formation offset from the target
+	OffsetY     float64  `json:"offset_y"`     //
+	MinDistance float64  `json:"min_distance"` // stop inside this distance
+	MaxDistance float64  `json:"max_distance"` // slow down inside this distance (0 = never)
+	Speed       float64  `json:"speed"`
+}
 
-	var target *ecs.Entity
-	w.EntitiesManager().ForEach(func(ent *ecs.Entity) {
-		if target != nil {
-			return
-		}
-		act, _ := ent.Get("Actor").(*ecs.Actor)
-		if act != nil && act.ID == targetName {
-			target = ent
-		}
-	})
-	if target == nil {
-		return false
-	}
-	tp, _ := target.Get("Position").(*ecs.Position)
-	if tp == nil {
+func (s *System) behaviorFollow(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *FollowParams) bool {
+	speed := p.Speed
+	offsetX, offsetY := p.OffsetX, p.OffsetY
+	minDist := p.MinDistance
+
+	tp, ok := s.targetPosition(w, e, p.Target)
+	if !ok {
 		return false
 	}
 	tx := tp.X + offsetX
 	ty := tp.Y + offsetY
-	dx := tx - pos.X
-	dy := ty - pos.Y
-	dist := math.Hypot(dx, dy)
+	dist := math.Hypot(tx-pos.X, ty-pos.Y)
 	if dist < minDist {
 		return false
 	}
-	vel.VX = (dx / dist) * speed
-	vel.VY = (dy / dist) * speed
+	if p.MaxDistance > minDist && dist < p.MaxDistance {
+		speed *= (dist - minDist) / (p.MaxDistance - minDist)
+	}
+	hx, hy := s.heading(w, e, pos, tx, ty)
+	vel.VX = hx * speed
+	vel.VY = hy * speed
 	return true
 }
-
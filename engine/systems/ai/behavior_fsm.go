@@ -0,0 +1,198 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"rp-go/engine/data"
+	"rp-go/engine/ecs"
+	"rp-go/engine/events"
+)
+
+/*───────────────────────────────────────────────*
+| STATE MACHINES                                |
+*───────────────────────────────────────────────*/
+
+// MachineParams configures the "fsm" behavior: each state runs one action
+// until a transition's guards hold. Example in ai.json:
+//
+//	{
+//	  "name": "sentry",
+//	  "type": "fsm",
+//	  "params": {
+//	    "initial": "patrol",
+//	    "states": {
+//	      "patrol":  {"action": "patrol_square",
+//	                  "transitions": [{"to": "pursue", "when": {"target": "player", "within": 280}}]},
+//	      "pursue":  {"action": "pursue_player_close",
+//	                  "transitions": [{"to": "retreat", "when": {"health_lt": 0.5}},
+//	                                  {"to": "patrol", "when": {"target": "player", "beyond": 400}}]},
+//	      "retreat": {"action": "retreat_if_damaged",
+//	                  "transitions": [{"to": "patrol", "on": "success"}]}
+//	    }
+//	  }
+//	}
+type MachineParams struct {
+	Initial string                   `json:"initial"`
+	States  map[string]*MachineState `json:"states"`
+
+	name  string // owning action, recorded in AIStateMachine
+	bound bool   // state actions resolved
+}
+
+// MachineState is one state. Action runs every frame while the state is
+// current; OnEnter and OnExit steps run once, for one frame, as the state
+// is entered or left.
+type MachineState struct {
+	Action      string         `json:"action"`
+	Params      map[string]any `json:"params,omitempty"`
+	OnEnter     []ScriptStep   `json:"on_enter,omitempty"`
+	OnExit      []ScriptStep   `json:"on_exit,omitempty"`
+	Transitions []Transition   `json:"transitions,omitempty"`
+
+	action  ecs.AIActionInstance
+	onEnter []ecs.AIActionInstance
+	onExit  []ecs.AIActionInstance
+}
+
+// Transition leaves the state for To once every guard given holds. Guards
+// are checked after the state's action ran; the first matching transition
+// in list order wins.
+type Transition struct {
+	To    string        `json:"to"`
+	When  *ConditionSet `json:"when,omitempty"`  // conditions, as on actions
+	After Duration      `json:"after,omitempty"` // minimum time in the state
+	On    string        `json:"on,omitempty"`    // action status: "success" or "failure"
+}
+
+// validate checks that the initial state and every transition target exist.
+func (p *MachineParams) validate() error {
+	if len(p.States) == 0 {
+		return &data.ParamError{Key: "states", Message: "state machine needs states"}
+	}
+	if _, ok := p.States[p.Initial]; !ok {
+		return &data.ParamError{Key: "initial", Message: fmt.Sprintf("initial state %q is not defined", p.Initial)}
+	}
+	for _, name := range p.stateNames() {
+		st := p.States[name]
+		path := "states." + name
+		if st == nil || st.Action == "" {
+			return &data.ParamError{Key: path + ".action", Message: "state names no action"}
+		}
+		for i, tr := range st.Transitions {
+			trPath := fmt.Sprintf("%s.transitions[%d]", path, i)
+			if _, ok := p.States[tr.To]; !ok {
+				return &data.ParamError{Key: trPath + ".to", Message: fmt.Sprintf("unknown state %q", tr.To)}
+			}
+			if tr.On != "" && tr.On != "success" && tr.On != "failure" {
+				return &data.ParamError{Key: trPath + ".on", Message: `on must be "success" or "failure"`}
+			}
+		}
+	}
+	return nil
+}
+
+// stateNames returns the state names sorted, for stable error reports.
+func (p *MachineParams) stateNames() []string {
+	names := make([]string, 0, len(p.States))
+	for name := range p.States {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// bindMachine resolves every state action and hook against cat and decodes
+// it.
+func (s *System) bindMachine(cat *AIActionCatalogLookup, p *MachineParams, name string, depth int) error {
+	p.name = name
+	p.bound = true
+	for _, stateName := range p.stateNames() {
+		st := p.States[stateName]
+		st.action = resolveStep(cat, ScriptStep{Action: st.Action, Params: st.Params})
+		if err := s.decodeAction(cat, &st.action, depth+1); err != nil {
+			return fmt.Errorf("state %s (%s): %w", stateName, st.Action, err)
+		}
+		st.onEnter = s.resolveSteps(cat, st.OnEnter, depth+1)
+		st.onExit = s.resolveSteps(cat, st.OnExit, depth+1)
+	}
+	return nil
+}
+
+// behaviorFSM runs the current state's action, then takes the first
+// transition whose guards hold. It fails only when the state's action
+// fails, so lower priority actions can run meanwhile.
+func (s *System) behaviorFSM(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *MachineParams) Status {
+	if len(p.States) == 0 {
+		return Failure
+	}
+	if !p.bound {
+		s.mu.RLock()
+		cat := s.catalog
+		s.mu.RUnlock()
+		if err := s.bindMachine(cat, p, "fsm", 1); err != nil {
+			p.States = nil
+			fmt.Printf("[AI] State machine disabled: %v\n", err)
+			return Failure
+		}
+	}
+
+	now := w.Clock().Elapsed()
+	m := ecs.GetTyped[*ecs.AIStateMachine](e, "AIStateMachine")
+	if m == nil {
+		m = &ecs.AIStateMachine{}
+		w.Commands().AddComponent(e, m)
+	}
+	if _, ok := p.States[m.State]; !ok || m.Machine != p.name {
+		s.enterState(w, e, pos, vel, p, m, "", p.Initial, now)
+	}
+
+	cur := p.States[m.State]
+	status := s.executeAction(w, e, pos, vel, cur.action)
+	for _, tr := range cur.Transitions {
+		if s.transitionReady(w, e, tr, status, now-m.Since) {
+			for _, hook := range cur.onExit {
+				s.executeAction(w, e, pos, vel, hook)
+			}
+			s.enterState(w, e, pos, vel, p, m, m.State, tr.To, now)
+			break
+		}
+	}
+
+	if status == Failure {
+		return Failure
+	}
+	return Running
+}
+
+func (s *System) transitionReady(w *ecs.World, e *ecs.Entity, tr Transition, status Status, inState time.Duration) bool {
+	switch {
+	case inState < time.Duration(tr.After):
+		return false
+	case tr.On == "success" && status != Success:
+		return false
+	case tr.On == "failure" && status != Failure:
+		return false
+	}
+	return s.checkConditions(w, e, tr.When)
+}
+
+// enterState switches m to the state to, runs its enter hooks and queues an
+// AIStateChangedEvent for the debug tools.
+func (s *System) enterState(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *MachineParams, m *ecs.AIStateMachine, from, to string, now time.Duration) {
+	m.Machine = p.name
+	m.State = to
+	m.Since = now
+	for _, hook := range p.States[to].onEnter {
+		s.executeAction(w, e, pos, vel, hook)
+	}
+	if bus, ok := w.EventBus.(*events.TypedBus); ok && bus != nil {
+		events.Queue(bus, events.AIStateChangedEvent{
+			EntityID: int(e.ID),
+			Machine:  p.name,
+			From:     from,
+			To:       to,
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/events"
+)
+
+func TestStateMachineRuns(t *testing.T) {
+	step := func(key string) string {
+		return `{"action": "scripted", "params": {"key": "` + key + `"}}`
+	}
+
+	tests := []struct {
+		name    string
+		states  string
+		scripts map[string]string
+		health  float64
+		want    []string // leaves run, state and status each frame
+		changes []string // AIStateChangedEvents
+	}{
+		{
+			name:    "starts in the initial state",
+			states:  `"patrol": ` + step("p"),
+			scripts: map[string]string{"p": "RF"},
+			want:    []string{"p @patrol running", "p @patrol failure"},
+			changes: []string{"->patrol"},
+		},
+		{
+			name: "on success waits for the action to finish",
+			states: `"patrol": {"action": "scripted", "params": {"key": "p"},
+				"transitions": [{"to": "rest", "on": "success"}]},
+				"rest": ` + step("r"),
+			scripts: map[string]string{"p": "RS", "r": "R"},
+			want:    []string{"p @patrol running", "p @rest running", "r @rest running"},
+			changes: []string{"->patrol", "patrol->rest"},
+		},
+		{
+			name: "on failure leaves a failing action",
+			states: `"patrol": {"action": "scripted", "params": {"key": "p"},
+				"transitions": [{"to": "rest", "on": "failure"}]},
+				"rest": ` + step("r"),
+			scripts: map[string]string{"p": "SF", "r": "R"},
+			want:    []string{"p @patrol running", "p @rest failure", "r @rest running"},
+			changes: []string{"->patrol", "patrol->rest"},
+		},
+		{
+			name: "after holds the state for its duration",
+			states: `"patrol": {"action": "scripted", "params": {"key": "p"},
+				"transitions": [{"to": "rest", "after": "250ms"}]},
+				"rest": ` + step("r"),
+			scripts: map[string]string{"p": "R", "r": "R"},
+			want:    []string{"p @patrol running", "p @patrol running", "p @patrol running", "p @rest running", "r @rest running"},
+			changes: []string{"->patrol", "patrol->rest"},
+		},
+		{
+			name: "after and on must both hold",
+			states: `"patrol": {"action": "scripted", "params": {"key": "p"},
+				"transitions": [{"to": "rest", "after": "150ms", "on": "success"}]},
+				"rest": ` + step("r"),
+			scripts: map[string]string{"p": "SSRS", "r": "R"},
+			want:    []string{"p @patrol running", "p @patrol running", "p @patrol running", "p @rest running"},
+			changes: []string{"->patrol", "patrol->rest"},
+		},
+		{
+			name: "when guards on conditions",
+			states: `"patrol": {"action": "scripted", "params": {"key": "p"},
+				"transitions": [{"to": "flee", "when": {"health_lt": 0.5}}]},
+				"flee": ` + step("f"),
+			scripts: map[string]string{"p": "R", "f": "R"},
+			health:  30,
+			want:    []string{"p @flee running", "f @flee running"},
+			changes: []string{"->patrol", "patrol->flee"},
+		},
+		{
+			name: "unmet when keeps the state",
+			states: `"patrol": {"action": "scripted", "params": {"key": "p"},
+				"transitions": [{"to": "flee", "when": {"health_lt": 0.5}}]},
+				"flee": ` + step("f"),
+			scripts: map[string]string{"p": "R", "f": "R"},
+			health:  80,
+			want:    []string{"p @patrol running", "p @patrol running"},
+			changes: []string{"->patrol"},
+		},
+		{
+			name: "first matching transition wins",
+			states: `"patrol": {"action": "scripted", "params": {"key": "p"},
+				"transitions": [{"to": "flee", "on": "failure"}, {"to": "rest"}, {"to": "flee"}]},
+				"rest": ` + step("r") + `, "flee": ` + step("f"),
+			scripts: map[string]string{"p": "S", "r": "R", "f": "R"},
+			want:    []string{"p @rest running"},
+			changes: []string{"->patrol", "patrol->rest"},
+		},
+		{
+			name: "hooks run once as states are left and entered",
+			states: `"patrol": {"action": "scripted", "params": {"key": "p"},
+				"on_enter": [` + step("enter_patrol") + `],
+				"on_exit": [` + step("exit_patrol") + `],
+				"transitions": [{"to": "rest", "on": "success"}]},
+				"rest": {"action": "scripted", "params": {"key": "r"},
+				"on_enter": [` + step("enter_rest") + `]}`,
+			scripts: map[string]string{"p": "RS", "r": "R", "enter_patrol": "S", "exit_patrol": "S", "enter_rest": "S"},
+			want: []string{
+				"enter_patrol,p @patrol running",
+				"p,exit_patrol,enter_rest @rest running",
+				"r @rest running",
+			},
+			changes: []string{"->patrol", "patrol->rest"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := newScripted(t, tt.scripts)
+			s, p := buildAction(t, "sentry", "fsm", `{"initial": "patrol", "states": {`+tt.states+`}}`)
+			health := tt.health
+			if health == 0 {
+				health = 100
+			}
+			w, e, pos, vel := newTestEntity(health)
+			bus := events.NewBus()
+			w.EventBus = bus
+			var changes []string
+			events.Subscribe(bus, func(ev events.AIStateChangedEvent) {
+				if ev.EntityID != int(e.ID) || ev.Machine != "sentry" {
+					t.Errorf("event for machine %q on entity %d", ev.Machine, ev.EntityID)
+				}
+				changes = append(changes, ev.From+"->"+ev.To)
+			})
+
+			var got []string
+			for range tt.want {
+				w.Advance(frame)
+				sc.log = sc.log[:0]
+				st := s.behaviorFSM(w, e, pos, vel, p.(*MachineParams))
+				w.Flush()
+				bus.Flush()
+				m := e.Get("AIStateMachine").(*ecs.AIStateMachine)
+				got = append(got, strings.Join(sc.log, ",")+" @"+m.State+" "+st.String())
+			}
+			if strings.Join(got, " | ") != strings.Join(tt.want, " | ") {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+			if strings.Join(changes, " ") != strings.Join(tt.changes, " ") {
+				t.Fatalf("expected state changes %q, got %q", tt.changes, changes)
+			}
+		})
+	}
+}
@@ -6,23 +6,46 @@ import (
 	"rp-go/engine/ecs"
 )
 
-func (s *System) behaviorPatrol(_ *ecs.World, _ *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p map[string]any) bool {
-	wpList, ok := p["waypoints"].([]any)
-	if !ok || len(wpList) == 0 {
-		return false
+// PatrolParams configures the "patrol" behavior.
+type PatrolParams struct {
+	Waypoints []Waypoint `json:"waypoints"`
+	Speed     float64    `json:"speed"`
+}
+
+// Waypoint is one patrol point.
+type Waypoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// behaviorPatrol visits the waypoints in order, tracking progress in the
+// controller's PatrolState. It succeeds each time the last waypoint is
+// reached and starts the next lap from the first.
+func (s *System) behaviorPatrol(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *PatrolParams) Status {
+	if len(p.Waypoints) == 0 {
+		return Failure
 	}
-	speed := getFloat(p, "speed", 2.0)
-	wp := wpList[0].(map[string]any)
-	tx := getFloat(wp, "x", pos.X)
-	ty := getFloat(wp, "y", pos.Y)
-	dx := tx - pos.X
-	dy := ty - pos.Y
-	dist := math.Hypot(dx, dy)
+	state := &ecs.AIPathState{}
+	if ctrl := ecs.GetTyped[*ecs.AIController](e, "AIController"); ctrl != nil {
+		state = &ctrl.PatrolState
+	}
+	if state.Index < 0 || state.Index >= len(p.Waypoints) {
+		state.Index = 0
+	}
+
+	speed := p.Speed
+	wp := p.Waypoints[state.Index]
+	dist := math.Hypot(wp.X-pos.X, wp.Y-pos.Y)
 	if dist < 2 {
-		return false
+		state.Index++
+		if state.Index == len(p.Waypoints) {
+			state.Index = 0
+			return Success
+		}
+		return Running
 	}
-	vel.VX = (dx / dist) * speed
-	vel.VY = (dy / dist) * speed
-	return true
+	hx, hy := s.heading(w, e, pos, wp.X, wp.Y)
+	vel.VX = hx * speed
+	vel.VY = hy * speed
+	return Running
 }
-
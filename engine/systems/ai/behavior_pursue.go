@@ -6,39 +6,27 @@ import (
 	"rp-go/engine/ecs"
 )
 
-func (s *System) behaviorPursue(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p map[string]any) bool {
-	targetName, _ := p["target"].(string)
-	speed := getFloat(p, "speed", 2.0)
-	maxDist := getFloat(p, "engage_distance", 300)
-	if targetName == "" {
-		return false
-	}
+// PursueParams configures the "pursue" behavior.
+type PursueParams struct {
+	Target         Selector `json:"target"`          // actor to chase
+	EngageDistance float64  `json:"engage_distance"` // give up beyond this distance
+	Speed          float64  `json:"speed"`
+}
 
-	var target *ecs.Entity
-	w.EntitiesManager().ForEach(func(ent *ecs.Entity) {
-		if target != nil {
-			return
-		}
-		act, _ := ent.Get("Actor").(*ecs.Actor)
-		if act != nil && act.ID == targetName {
-			target = ent
-		}
-	})
-	if target == nil {
-		return false
-	}
-	tp, _ := target.Get("Position").(*ecs.Position)
-	if tp == nil {
+func (s *System) behaviorPursue(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *PursueParams) bool {
+	speed := p.Speed
+	maxDist := p.EngageDistance
+
+	tp, ok := s.targetPosition(w, e, p.Target)
+	if !ok {
 		return false
 	}
-	dx := tp.X - pos.X
-	dy := tp.Y - pos.Y
-	dist := math.Hypot(dx, dy)
+	dist := math.Hypot(tp.X-pos.X, tp.Y-pos.Y)
 	if dist > maxDist || dist < 1 {
 		return false
 	}
-	vel.VX = (dx / dist) * speed
-	vel.VY = (dy / dist) * speed
+	hx, hy := s.heading(w, e, pos, tp.X, tp.Y)
+	vel.VX = hx * speed
+	vel.VY = hy * speed
 	return true
 }
-
@@ -6,43 +6,39 @@ import (
 	"rp-go/engine/ecs"
 )
 
-func (s *System) behaviorRetreat(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p map[string]any) bool {
-	targetName, _ := p["target"].(string)
-	trigger := getFloat(p, "trigger_distance", 200)
-	safe := getFloat(p, "safe_distance", 320)
-	speed := getFloat(p, "speed", 2.4)
-	if targetName == "" {
-		return false
-	}
+// RetreatParams configures the "retreat" behavior.
+type RetreatParams struct {
+	Target          Selector `json:"target"`           // actor to flee from
+	TriggerDistance float64  `json:"trigger_distance"` // start fleeing inside this distance
+	SafeDistance    float64  `json:"safe_distance"`    // ignore the target beyond this distance
+	Speed           float64  `json:"speed"`
+}
 
-	var target *ecs.Entity
-	w.EntitiesManager().ForEach(func(ent *ecs.Entity) {
-		if target != nil {
-			return
-		}
-		act, _ := ent.Get("Actor").(*ecs.Actor)
-		if act != nil && act.ID == targetName {
-			target = ent
-		}
-	})
-	if target == nil {
-		return false
-	}
-	tp, _ := target.Get("Position").(*ecs.Position)
-	if tp == nil {
-		return false
+// behaviorRetreat flees from the target once it comes within the trigger
+// distance and succeeds when the target is beyond the safe distance.
+func (s *System) behaviorRetreat(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *RetreatParams) Status {
+	trigger := p.TriggerDistance
+	safe := p.SafeDistance
+	speed := p.Speed
+
+	tp, ok := s.targetPosition(w, e, p.Target)
+	if !ok {
+		return Failure
 	}
 	dx := pos.X - tp.X
 	dy := pos.Y - tp.Y
 	dist := math.Hypot(dx, dy)
 	if dist > safe {
-		return false
+		return Success
 	}
-	if dist < trigger {
-		vel.VX = (dx / dist) * speed
-		vel.VY = (dy / dist) * speed
-		return true
+	if dist < trigger && dist > 0 {
+		// Aim for the point where the target would be left at the safe
+		// distance, so fleeing routes around terrain too.
+		away := safe - dist + waypointRadius
+		hx, hy := s.heading(w, e, pos, pos.X+dx/dist*away, pos.Y+dy/dist*away)
+		vel.VX = hx * speed
+		vel.VY = hy * speed
+		return Running
 	}
-	return false
+	return Failure
 }
-
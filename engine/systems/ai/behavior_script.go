@@ -1,7 +1,7 @@
 package ai
 
 import (
-	"encoding/json"
+	"fmt"
 	"time"
 
 	"rp-go/engine/ecs"
@@ -11,14 +11,28 @@ import (
 | SCRIPTED BEHAVIOR SEQUENCES                   |
 *───────────────────────────────────────────────*/
 
+// ScriptParams configures the "script" behavior.
+type ScriptParams struct {
+	Steps []ScriptStep `json:"steps"`
+
+	resolved []ecs.AIActionInstance // steps bound to their actions and decoded
+}
+
 // ScriptStep represents one step in a scripted AI sequence.
 type ScriptStep struct {
 	Action string         `json:"action"`
-	Params map[string]any `json:"params"`
-	Delay  int            `json:"delay_ms,omitempty"` // optional pause before next step
+	Params map[string]any `json:"params,omitempty"`
+	Delay  Duration       `json:"delay,omitempty"` // optional pause before next step
 }
 
-// behaviorScript executes a JSON-defined sequence of sub-actions.
+// maxScriptDepth bounds scripts running scripts, so a cycle in ai.json
+// cannot recurse forever.
+const maxScriptDepth = 8
+
+// behaviorScript runs a JSON-defined sequence of sub-actions. A step that
+// is running keeps the script on it; a step that succeeds advances, after
+// its delay, and a step that fails restarts the script and fails it. The
+// script succeeds once its last step does.
 // Example in ai.json:
 //
 //	{
@@ -27,7 +41,7 @@ type ScriptStep struct {
 //	  "params": {
 //	    "steps": [
 //	      {"action": "patrol_square"},
-//	      {"action": "retreat_if_damaged", "delay_ms": 500}
+//	      {"action": "retreat_if_damaged", "delay": "500ms"}
 //	    ]
 //	  }
 //	}
@@ -36,53 +50,85 @@ func (s *System) behaviorScript(
 	e *ecs.Entity,
 	pos *ecs.Position,
 	vel *ecs.Velocity,
-	params map[string]any,
-) bool {
-	rawSteps, ok := params["steps"]
-	if !ok {
-		return false
+	p *ScriptParams,
+) Status {
+	if len(p.Steps) == 0 {
+		return Failure
 	}
-
-	var steps []ScriptStep
-	switch v := rawSteps.(type) {
-	case []any:
-		b, _ := json.Marshal(v)
-		_ = json.Unmarshal(b, &steps)
-	case []ScriptStep:
-		steps = v
-	default:
-		return false
+	if p.resolved == nil {
+		// Params decoded outside BuildControllerFromRefs.
+		s.mu.RLock()
+		cat := s.catalog
+		s.mu.RUnlock()
+		p.resolved = s.resolveSteps(cat, p.Steps, 1)
 	}
 
-	// Use ECS ScriptState for cross-system consistency
+	// Use ECS ScriptState for cross-system consistency. It is attached via
+	// the command buffer since AI may run concurrently with other systems.
 	state := ecs.GetTyped[*ecs.ScriptState](e, "AIScriptState")
 	if state == nil {
 		state = &ecs.ScriptState{}
-		e.AddNamed("AIScriptState", state)
+		w.Commands().AddComponent(e, state)
 	}
 
-	// Wait for delay between steps
-	if time.Now().Before(state.NextAt) {
-		return true
+	// Wait for delay between steps (simulation time, so pauses and time
+	// scaling apply).
+	now := w.Clock().Elapsed()
+	if now < state.NextAt {
+		return Running
 	}
-	if state.Current >= len(steps) {
+	if state.Current >= len(p.Steps) {
 		state.Current = 0
 	}
 
-	step := steps[state.Current]
-	act := ecs.AIActionInstance{
-		Name:     step.Action,
-		Type:     step.Action,
-		Priority: 0,
-		Params:   step.Params,
+	step := p.Steps[state.Current]
+	switch s.executeAction(w, e, pos, vel, p.resolved[state.Current]) {
+	case Running:
+		return Running
+	case Failure:
+		state.Current = 0
+		return Failure
+	}
+	if step.Delay > 0 {
+		state.NextAt = now + time.Duration(step.Delay)
+	}
+	state.Current++
+	if state.Current == len(p.Steps) {
+		state.Current = 0
+		return Success
 	}
+	return Running
+}
 
-	executed := s.executeAction(w, e, pos, vel, act)
-	if executed {
-		if step.Delay > 0 {
-			state.NextAt = time.Now().Add(time.Duration(step.Delay) * time.Millisecond)
+// resolveSteps binds each step to its action and decodes its params. Steps
+// that fail to decode stay unbound and never run.
+func (s *System) resolveSteps(cat *AIActionCatalogLookup, steps []ScriptStep, depth int) []ecs.AIActionInstance {
+	out := make([]ecs.AIActionInstance, len(steps))
+	for i, step := range steps {
+		act := resolveStep(cat, step)
+		if err := s.decodeAction(cat, &act, depth); err != nil {
+			fmt.Printf("[AI] Script step %d (%s): %v\n", i, step.Action, err)
 		}
-		state.Current++
+		out[i] = act
+	}
+	return out
+}
+
+// resolveStep turns a script step into an action. Steps name either an
+// ai.json action, whose params the step's own params override, or a
+// behavior type directly.
+func resolveStep(cat *AIActionCatalogLookup, step ScriptStep) ecs.AIActionInstance {
+	tpl, ok := cat.Get(step.Action)
+	if !ok {
+		return ecs.AIActionInstance{Name: step.Action, Type: step.Action, Params: step.Params}
+	}
+
+	params := make(map[string]any, len(tpl.Params)+len(step.Params))
+	for k, v := range tpl.Params {
+		params[k] = v
+	}
+	for k, v := range step.Params {
+		params[k] = v
 	}
-	return executed
+	return ecs.AIActionInstance{Name: tpl.Name, Type: tpl.Type, Params: params, Conditions: tpl.Conditions}
 }
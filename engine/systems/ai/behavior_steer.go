@@ -0,0 +1,302 @@
+package ai
+
+import (
+	"math"
+
+	"rp-go/engine/data"
+	"rp-go/engine/ecs"
+	"rp-go/engine/nav"
+)
+
+/*───────────────────────────────────────────────*
+ | STEERING BEHAVIORS                            |
+ *───────────────────────────────────────────────*/
+
+// SteerParams configures the steering behaviors ("steer", "flock" and
+// "wander", which differ only in their default weights). Each force is the
+// change from the current velocity to the velocity it desires; the weighted
+// sum is limited to MaxForce and applied as acceleration.
+type SteerParams struct {
+	Target   Selector `json:"target"`    // actor to seek or arrive at
+	OffsetX  float64  `json:"offset_x"`  // slot offset from the target
+	OffsetY  float64  `json:"offset_y"`  //
+	FleeFrom Selector `json:"flee_from"` // actor to flee from
+	Group    string   `json:"group"`     // flock with steering entities of the same group
+
+	MaxSpeed float64 `json:"max_speed"`
+	MaxForce float64 `json:"max_force"` // speed change per tick
+	TurnRate float64 `json:"turn_rate"` // heading change per tick, radians (0 = unlimited)
+
+	SlowRadius       float64 `json:"slow_radius"`       // arrive decelerates inside this distance
+	FleeRadius       float64 `json:"flee_radius"`       // flee ignores threats beyond this distance (0 = never)
+	NeighborRadius   float64 `json:"neighbor_radius"`   // alignment and cohesion range
+	SeparationRadius float64 `json:"separation_radius"` // keep at least this far from flock mates
+	LookAhead        float64 `json:"look_ahead"`        // obstacle probe length at full speed
+	WanderRadius     float64 `json:"wander_radius"`
+	WanderDistance   float64 `json:"wander_distance"` // wander circle distance ahead
+	WanderJitter     float64 `json:"wander_jitter"`   // wander angle change per tick, radians
+
+	Weights SteerWeights `json:"weights"`
+}
+
+// SteerWeights scales each force before they are summed; 0 disables one.
+type SteerWeights struct {
+	Seek       float64 `json:"seek"`
+	Arrive     float64 `json:"arrive"`
+	Flee       float64 `json:"flee"`
+	Wander     float64 `json:"wander"`
+	Separation float64 `json:"separation"`
+	Alignment  float64 `json:"alignment"`
+	Cohesion   float64 `json:"cohesion"`
+	Avoid      float64 `json:"avoid"`
+}
+
+// steerDefaults are shared by the steering behavior types; each sets its
+// own weights on a copy.
+var steerDefaults = SteerParams{
+	MaxSpeed:         3,
+	MaxForce:         0.2,
+	SlowRadius:       96,
+	NeighborRadius:   160,
+	SeparationRadius: 56,
+	LookAhead:        64,
+	WanderRadius:     32,
+	WanderDistance:   64,
+	WanderJitter:     0.25,
+}
+
+func steerWith(w SteerWeights) SteerParams {
+	p := steerDefaults
+	p.Weights = w
+	return p
+}
+
+func (p *SteerParams) validate() error {
+	if p.MaxSpeed <= 0 {
+		return &data.ParamError{Key: "max_speed", Message: "max_speed must be positive"}
+	}
+	if p.MaxForce <= 0 {
+		return &data.ParamError{Key: "max_force", Message: "max_force must be positive"}
+	}
+	ws := p.Weights
+	for _, w := range []struct {
+		key string
+		v   float64
+	}{
+		{"seek", ws.Seek}, {"arrive", ws.Arrive}, {"flee", ws.Flee}, {"wander", ws.Wander},
+		{"separation", ws.Separation}, {"alignment", ws.Alignment}, {"cohesion", ws.Cohesion}, {"avoid", ws.Avoid},
+	} {
+		if w.v < 0 {
+			return &data.ParamError{Key: "weights." + w.key, Message: "weights cannot be negative"}
+		}
+	}
+	return nil
+}
+
+// behaviorSteer blends the weighted forces into the entity's steered
+// velocity. It fails when a named target or threat is not in the world.
+func (s *System) behaviorSteer(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *SteerParams) bool {
+	st := ecs.GetTyped[*ecs.Steering](e, "AISteering")
+	if st == nil {
+		st = &ecs.Steering{}
+		w.Commands().AddComponent(e, st)
+	}
+	st.Group = p.Group
+
+	here, cur := vec2{pos.X, pos.Y}, vec2{st.VX, st.VY}
+	scale := w.Clock().StepScale()
+	var force vec2
+
+	if !p.Target.IsZero() {
+		target, ok := s.targetPosition(w, e, p.Target)
+		if !ok {
+			return false
+		}
+		goal := vec2{target.X + p.OffsetX, target.Y + p.OffsetY}
+		if p.Weights.Seek > 0 {
+			force = force.add(s.seek(w, e, pos, cur, goal, p.MaxSpeed).scale(p.Weights.Seek))
+		}
+		if p.Weights.Arrive > 0 {
+			force = force.add(s.arrive(w, e, pos, cur, goal, p).scale(p.Weights.Arrive))
+		}
+	}
+	if !p.FleeFrom.IsZero() {
+		threat, ok := s.targetPosition(w, e, p.FleeFrom)
+		if !ok {
+			return false
+		}
+		if p.Weights.Flee > 0 {
+			force = force.add(flee(here, cur, vec2{threat.X, threat.Y}, p).scale(p.Weights.Flee))
+		}
+	}
+	if p.Weights.Wander > 0 {
+		force = force.add(s.wander(cur, st, p, scale).scale(p.Weights.Wander))
+	}
+	if p.Weights.Separation > 0 || p.Weights.Alignment > 0 || p.Weights.Cohesion > 0 {
+		force = force.add(s.flock(w, e, here, cur, p))
+	}
+	if p.Weights.Avoid > 0 {
+		force = force.add(s.avoid(here, cur, p).scale(p.Weights.Avoid))
+	}
+
+	next := cur.add(force.truncate(p.MaxForce).scale(scale)).truncate(p.MaxSpeed)
+	if p.TurnRate > 0 {
+		next = limitTurn(cur, next, p.TurnRate*scale)
+	}
+	st.VX, st.VY = next.x, next.y
+	vel.VX, vel.VY = next.x, next.y
+	return true
+}
+
+/*───────────────────────────────────────────────*
+ | FORCES                                        |
+ *───────────────────────────────────────────────*/
+
+// seek heads for goal at full speed, along a path when terrain is in the way.
+func (s *System) seek(w *ecs.World, e *ecs.Entity, pos *ecs.Position, cur, goal vec2, speed float64) vec2 {
+	hx, hy := s.heading(w, e, pos, goal.x, goal.y)
+	return vec2{hx, hy}.scale(speed).sub(cur)
+}
+
+// arrive is seek that slows down inside SlowRadius and stops at the goal.
+func (s *System) arrive(w *ecs.World, e *ecs.Entity, pos *ecs.Position, cur, goal vec2, p *SteerParams) vec2 {
+	speed := p.MaxSpeed
+	if d := goal.sub(vec2{pos.X, pos.Y}).len(); d < p.SlowRadius {
+		speed *= d / p.SlowRadius
+	}
+	return s.seek(w, e, pos, cur, goal, speed)
+}
+
+func flee(here, cur, threat vec2, p *SteerParams) vec2 {
+	away := here.sub(threat)
+	if p.FleeRadius > 0 && away.len() > p.FleeRadius {
+		return vec2{}
+	}
+	return away.norm().scale(p.MaxSpeed).sub(cur)
+}
+
+// wander seeks a point that drifts around a circle ahead of the entity.
+func (s *System) wander(cur vec2, st *ecs.Steering, p *SteerParams, scale float64) vec2 {
+	st.Wander += (s.rng.Float64()*2 - 1) * p.WanderJitter * scale
+	ahead := cur.norm()
+	if ahead == (vec2{}) {
+		ahead = vec2{1, 0}
+	}
+	aim := ahead.scale(p.WanderDistance).add(vec2{math.Cos(st.Wander), math.Sin(st.Wander)}.scale(p.WanderRadius))
+	return aim.norm().scale(p.MaxSpeed).sub(cur)
+}
+
+// flock returns the weighted separation, alignment and cohesion forces
+// from the steering entities of the same group.
+func (s *System) flock(w *ecs.World, e *ecs.Entity, here, cur vec2, p *SteerParams) vec2 {
+	var push, heading, center vec2
+	mates := 0
+	s.neighbors(w, e, here.x, here.y, math.Max(p.NeighborRadius, p.SeparationRadius), func(o *ecs.Entity, op *ecs.Position) {
+		ost := ecs.GetTyped[*ecs.Steering](o, "AISteering")
+		if ost == nil || ost.Group != p.Group {
+			return
+		}
+		away := here.sub(vec2{op.X, op.Y})
+		d := away.len()
+		if d < p.SeparationRadius {
+			if d == 0 {
+				// Stacked exactly: split by ID so the pair moves apart.
+				away, d = vec2{1, 0}, 1
+				if e.ID < o.ID {
+					away.x = -1
+				}
+			}
+			push = push.add(away.scale(1 / d)) // closer mates push harder
+		}
+		if d < p.NeighborRadius {
+			heading = heading.add(vec2{ost.VX, ost.VY})
+			center = center.add(vec2{op.X, op.Y})
+			mates++
+		}
+	})
+
+	var force vec2
+	if push != (vec2{}) {
+		force = force.add(push.norm().scale(p.MaxSpeed).sub(cur).scale(p.Weights.Separation))
+	}
+	if mates > 0 {
+		if heading != (vec2{}) {
+			force = force.add(heading.norm().scale(p.MaxSpeed).sub(cur).scale(p.Weights.Alignment))
+		}
+		toCenter := center.scale(1 / float64(mates)).sub(here)
+		force = force.add(toCenter.norm().scale(p.MaxSpeed).sub(cur).scale(p.Weights.Cohesion))
+	}
+	return force
+}
+
+// avoid steers away from blocked terrain ahead, probing further the faster
+// the entity moves. It turns toward whichever side is clear and brakes when
+// neither is.
+func (s *System) avoid(here, cur vec2, p *SteerParams) vec2 {
+	n := s.Navigator()
+	speed := cur.len()
+	if n == nil || speed == 0 {
+		return vec2{}
+	}
+	reach := p.LookAhead * speed / p.MaxSpeed
+	dir := cur.norm()
+	clear := func(d vec2) bool {
+		to := here.add(d.scale(reach))
+		return n.LineOfSight(nav.Point{X: here.x, Y: here.y}, nav.Point{X: to.x, Y: to.y})
+	}
+	if clear(dir) {
+		return vec2{}
+	}
+	left, right := dir.rotate(-math.Pi/4), dir.rotate(math.Pi/4)
+	switch {
+	case clear(left):
+		return left.scale(p.MaxSpeed).sub(cur)
+	case clear(right):
+		return right.scale(p.MaxSpeed).sub(cur)
+	}
+	return cur.scale(-1)
+}
+
+// limitTurn rotates cur toward next by at most maxTurn radians, keeping
+// next's speed.
+func limitTurn(cur, next vec2, maxTurn float64) vec2 {
+	if cur == (vec2{}) || next == (vec2{}) {
+		return next
+	}
+	turn := math.Atan2(cur.x*next.y-cur.y*next.x, cur.x*next.x+cur.y*next.y)
+	if math.Abs(turn) <= maxTurn {
+		return next
+	}
+	return cur.norm().rotate(math.Copysign(maxTurn, turn)).scale(next.len())
+}
+
+/*───────────────────────────────────────────────*
+ | VECTORS                                       |
+ *───────────────────────────────────────────────*/
+
+type vec2 struct{ x, y float64 }
+
+func (v vec2) add(o vec2) vec2      { return vec2{v.x + o.x, v.y + o.y} }
+func (v vec2) sub(o vec2) vec2      { return vec2{v.x - o.x, v.y - o.y} }
+func (v vec2) scale(k float64) vec2 { return vec2{v.x * k, v.y * k} }
+func (v vec2) len() float64         { return math.Hypot(v.x, v.y) }
+func (v vec2) rotate(a float64) vec2 {
+	sin, cos := math.Sincos(a)
+	return vec2{v.x*cos - v.y*sin, v.x*sin + v.y*cos}
+}
+
+func (v vec2) norm() vec2 {
+	l := v.len()
+	if l == 0 {
+		return vec2{}
+	}
+	return v.scale(1 / l)
+}
+
+// truncate caps the length of v at max.
+func (v vec2) truncate(max float64) vec2 {
+	if l := v.len(); l > max {
+		return v.scale(max / l)
+	}
+	return v
+}
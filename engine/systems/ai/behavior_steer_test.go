@@ -0,0 +1,190 @@
+package ai
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"rp-go/engine/data"
+	"rp-go/engine/ecs"
+	"rp-go/engine/platform"
+)
+
+func near(a, b vec2) bool {
+	return math.Abs(a.x-b.x) < 1e-9 && math.Abs(a.y-b.y) < 1e-9
+}
+
+func TestLimitTurn(t *testing.T) {
+	r := math.Sqrt2
+	tests := []struct {
+		name      string
+		cur, next vec2
+		maxTurn   float64
+		want      vec2
+	}{
+		{"within the limit", vec2{1, 0}, vec2{2, 1}, math.Pi / 4, vec2{2, 1}},
+		{"left turn clamped, speed kept", vec2{1, 0}, vec2{0, 2}, math.Pi / 4, vec2{r, r}},
+		{"right turn clamped", vec2{1, 0}, vec2{0, -2}, math.Pi / 4, vec2{r, -r}},
+		{"starting from rest", vec2{}, vec2{0, 2}, 0.1, vec2{0, 2}},
+		{"stopping", vec2{1, 0}, vec2{}, 0.1, vec2{}},
+	}
+	for _, tt := range tests {
+		if got := limitTurn(tt.cur, tt.next, tt.maxTurn); !near(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := (vec2{3, 4}).truncate(1); !near(got, vec2{0.6, 0.8}) {
+		t.Fatalf("expected (0.6,0.8), got %v", got)
+	}
+	if got := (vec2{3, 4}).truncate(10); got != (vec2{3, 4}) {
+		t.Fatalf("expected short vectors to be kept, got %v", got)
+	}
+}
+
+func TestSteerParamsValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(p *SteerParams)
+		key  string // "" for valid params
+	}{
+		{"defaults", func(*SteerParams) {}, ""},
+		{"no speed", func(p *SteerParams) { p.MaxSpeed = 0 }, "max_speed"},
+		{"negative force", func(p *SteerParams) { p.MaxForce = -1 }, "max_force"},
+		{"negative weight", func(p *SteerParams) { p.Weights.Cohesion = -0.5 }, "weights.cohesion"},
+	}
+	for _, tt := range tests {
+		p := steerWith(SteerWeights{Arrive: 1})
+		tt.edit(&p)
+		err := p.validate()
+		var pe *data.ParamError
+		switch {
+		case tt.key == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.key != "" && (!errors.As(err, &pe) || pe.Key != tt.key):
+			t.Errorf("%s: expected an error on %s, got %v", tt.name, tt.key, err)
+		}
+	}
+}
+
+// flockScene places e at the origin, a flock mate at (10,0) heading up, a
+// mate of another group below it and a mate out of range.
+func flockScene() (*ecs.World, *ecs.Entity) {
+	w := ecs.NewWorld()
+	add := func(x, y float64, st *ecs.Steering) *ecs.Entity {
+		e := w.NewEntity()
+		e.Add(&ecs.Position{X: x, Y: y})
+		e.Add(&ecs.Velocity{})
+		e.Add(st)
+		return e
+	}
+	e := add(0, 0, &ecs.Steering{Group: "a"})
+	add(10, 0, &ecs.Steering{Group: "a", VY: 1})
+	add(0, 5, &ecs.Steering{Group: "b", VX: -1})
+	add(1000, 0, &ecs.Steering{Group: "a", VY: -1})
+	return w, e
+}
+
+func TestFlockForces(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights SteerWeights
+		want    vec2
+	}{
+		{"separation pushes away from close mates", SteerWeights{Separation: 1}, vec2{-3, 0}},
+		{"alignment matches the mates' heading", SteerWeights{Alignment: 1}, vec2{0, 3}},
+		{"cohesion heads for the mates' center", SteerWeights{Cohesion: 1}, vec2{3, 0}},
+		{"weights scale each force", SteerWeights{Separation: 2, Alignment: 0.5}, vec2{-6, 1.5}},
+	}
+	for _, tt := range tests {
+		w, e := flockScene()
+		p := steerWith(tt.weights)
+		p.Group = "a"
+		if got := (&System{}).flock(w, e, vec2{}, vec2{}, &p); !near(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestSteerBlendsAndLimitsForces(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights SteerWeights
+		want    vec2
+	}{
+		{"one force is capped at max_force", SteerWeights{Separation: 1}, vec2{-0.2, 0}},
+		{"forces are summed before the cap", SteerWeights{Separation: 1, Alignment: 1}, vec2{-0.2 / math.Sqrt2, 0.2 / math.Sqrt2}},
+	}
+	for _, tt := range tests {
+		w, e := flockScene()
+		w.Advance(ecs.DefaultStep)
+		p := steerWith(tt.weights)
+		p.Group = "a"
+		pos := e.Get("Position").(*ecs.Position)
+		vel := e.Get("Velocity").(*ecs.Velocity)
+		if !(&System{}).behaviorSteer(w, e, pos, vel, &p) {
+			t.Fatalf("%s: steering failed", tt.name)
+		}
+		if got := (vec2{vel.VX, vel.VY}); !near(got, tt.want) {
+			t.Errorf("%s: expected velocity %v, got %v", tt.name, tt.want, got)
+		}
+		if st := e.Get("AISteering").(*ecs.Steering); st.VX != vel.VX || st.VY != vel.VY {
+			t.Errorf("%s: steered velocity %v not remembered", tt.name, *st)
+		}
+	}
+}
+
+// mover integrates velocities after the AI has set them.
+type mover struct{}
+
+func (mover) Update(w *ecs.World) {
+	scale := w.Clock().StepScale()
+	ecs.NewQuery2[*ecs.Position, *ecs.Velocity](w).Each(func(_ *ecs.Entity, p *ecs.Position, v *ecs.Velocity) {
+		p.X += v.VX * scale
+		p.Y += v.VY * scale
+	})
+}
+
+func (mover) Draw(*ecs.World, *platform.Image) {}
+
+func (mover) Access() ecs.Access {
+	return ecs.Access{Reads: []string{"Velocity"}, Writes: []string{"Position"}}
+}
+
+func TestFlockSeparatesTwoEntities(t *testing.T) {
+	for _, gap := range []float64{4, 0} {
+		s := NewSystem(data.AIActionCatalog{Actions: []data.AIActionTemplate{{
+			Name: "school",
+			Type: "flock",
+			Params: map[string]any{
+				"group":   "fish",
+				"weights": map[string]any{"separation": 1, "alignment": 0, "cohesion": 0},
+			},
+		}}})
+		w := ecs.NewWorld()
+		w.AddSystem(s)
+		w.AddSystem(mover{})
+		var fish [2]*ecs.Position
+		for i := range fish {
+			e := w.NewEntity()
+			fish[i] = &ecs.Position{X: float64(i) * gap}
+			e.Add(fish[i])
+			e.Add(&ecs.Velocity{})
+			e.Add(s.BuildControllerFromRefs([]string{"school"}))
+		}
+
+		for i := 0; i < 60; i++ {
+			w.Advance(ecs.DefaultStep)
+		}
+
+		a, b := fish[0], fish[1]
+		if d := math.Hypot(b.X-a.X, b.Y-a.Y); d < steerDefaults.SeparationRadius {
+			t.Errorf("gap %.0f: expected the fish at least %.0f apart, got %.2f", gap, steerDefaults.SeparationRadius, d)
+		}
+		if mid := (a.X + b.X) / 2; math.Abs(mid-gap/2) > 1e-9 || a.Y != 0 || b.Y != 0 {
+			t.Errorf("gap %.0f: expected the fish to part evenly along x, got (%.2f,%.2f) and (%.2f,%.2f)", gap, a.X, a.Y, b.X, b.Y)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package ai
+
+import (
+	"fmt"
+	"time"
+
+	"rp-go/engine/data"
+	"rp-go/engine/ecs"
+)
+
+/*───────────────────────────────────────────────*
+| BEHAVIOR TREES                                |
+*───────────────────────────────────────────────*/
+
+// TreeParams configures the "tree" behavior: a behavior tree evaluated
+// every frame per entity. Example in ai.json:
+//
+//	{
+//	  "name": "guard",
+//	  "type": "tree",
+//	  "params": {
+//	    "root": {
+//	      "type": "selector", "reactive": true,
+//	      "children": [
+//	        {"type": "sequence", "children": [
+//	          {"type": "condition", "conditions": {"health_lt": 0.5}},
+//	          {"type": "action", "action": "retreat_if_damaged"}
+//	        ]},
+//	        {"type": "cooldown", "duration": "3s",
+//	         "child": {"type": "action", "action": "pursue_player_close"}},
+//	        {"type": "action", "action": "patrol_square"}
+//	      ]
+//	    }
+//	  }
+//	}
+type TreeParams struct {
+	Root *TreeNode `json:"root"`
+
+	name  string // owning action, prefixes the node state keys
+	bound bool   // action leaves resolved
+}
+
+// TreeNode is one node of a behavior tree. Which fields apply depends on
+// Type:
+//
+//	sequence, selector  Children, run in order, resuming the running child;
+//	                    Reactive ones start over from the first child every
+//	                    frame, so an earlier child can take over
+//	parallel            Children, ticked each frame until they finish; Policy
+//	                    "all" (default) or "one" must succeed
+//	inverter            Child, success and failure swapped
+//	repeat              Child, rerun until it has succeeded Count times
+//	                    (0 = forever); a failure stops it
+//	cooldown            Child, fails for Duration after the child finishes
+//	wait                running for Duration, then success
+//	condition           Conditions, success when they hold
+//	action              Action (an ai.json action or behavior type) with
+//	                    optional Params overrides
+type TreeNode struct {
+	Type       string         `json:"type"`
+	Children   []*TreeNode    `json:"children,omitempty"`
+	Child      *TreeNode      `json:"child,omitempty"`
+	Action     string         `json:"action,omitempty"`
+	Params     map[string]any `json:"params,omitempty"`
+	Conditions *ConditionSet  `json:"conditions,omitempty"`
+	Duration   Duration       `json:"duration,omitempty"`
+	Count      int            `json:"count,omitempty"`
+	Policy     string         `json:"policy,omitempty"`
+	Reactive   bool           `json:"reactive,omitempty"`
+
+	path   string               // position in the tree, e.g. "root.children[1]"
+	action ecs.AIActionInstance // bound action leaf
+}
+
+// maxParallelChildren bounds a parallel node so the children that finished
+// fit in a bitmask in TreeNodeState.Index.
+const maxParallelChildren = 62
+
+// validate checks the tree's shape; errors name the offending node.
+func (p *TreeParams) validate() error {
+	if p.Root == nil {
+		return &data.ParamError{Key: "root", Message: "tree needs a root node"}
+	}
+	return p.Root.validate("root")
+}
+
+func (n *TreeNode) validate(path string) error {
+	n.path = path
+	fail := func(field, format string, args ...any) error {
+		return &data.ParamError{Key: path + field, Message: fmt.Sprintf(format, args...)}
+	}
+	if n.Reactive && n.Type != "sequence" && n.Type != "selector" {
+		return fail(".reactive", "reactive applies to sequence and selector nodes")
+	}
+	switch n.Type {
+	case "sequence", "selector", "parallel":
+		if len(n.Children) == 0 {
+			return fail(".children", "%s needs children", n.Type)
+		}
+		if n.Policy != "" && (n.Type != "parallel" || (n.Policy != "all" && n.Policy != "one")) {
+			return fail(".policy", "policy must be \"all\" or \"one\" on a parallel node")
+		}
+		if n.Type == "parallel" && len(n.Children) > maxParallelChildren {
+			return fail(".children", "parallel takes at most %d children", maxParallelChildren)
+		}
+		for i, child := range n.Children {
+			if child == nil {
+				return fail(fmt.Sprintf(".children[%d]", i), "empty node")
+			}
+			if err := child.validate(fmt.Sprintf("%s.children[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "inverter", "repeat", "cooldown":
+		if n.Child == nil {
+			return fail(".child", "%s needs a child", n.Type)
+		}
+		if n.Type == "cooldown" && n.Duration <= 0 {
+			return fail(".duration", "cooldown needs a positive duration")
+		}
+		if n.Count < 0 {
+			return fail(".count", "count must not be negative")
+		}
+		return n.Child.validate(path + ".child")
+	case "wait":
+		if n.Duration <= 0 {
+			return fail(".duration", "wait needs a positive duration")
+		}
+	case "condition":
+		if n.Conditions == nil {
+			return fail(".conditions", "condition needs conditions")
+		}
+	case "action":
+		if n.Action == "" {
+			return fail(".action", "action node names no action")
+		}
+	case "":
+		return fail(".type", "node has no type")
+	default:
+		return fail(".type", "unknown node type %q", n.Type)
+	}
+	return nil
+}
+
+// bindTree resolves every action leaf against cat and decodes it.
+func (s *System) bindTree(cat *AIActionCatalogLookup, p *TreeParams, name string, depth int) error {
+	p.name = name
+	p.bound = true
+	var bind func(n *TreeNode) error
+	bind = func(n *TreeNode) error {
+		if n.Type == "action" {
+			n.action = resolveStep(cat, ScriptStep{Action: n.Action, Params: n.Params})
+			if err := s.decodeAction(cat, &n.action, depth+1); err != nil {
+				return fmt.Errorf("%s (%s): %w", n.path, n.Action, err)
+			}
+		}
+		if n.Child != nil {
+			if err := bind(n.Child); err != nil {
+				return err
+			}
+		}
+		for _, child := range n.Children {
+			if err := bind(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return bind(p.Root)
+}
+
+/*───────────────────────────────────────────────*
+| EVALUATION                                    |
+*───────────────────────────────────────────────*/
+
+// treeTick carries one entity's evaluation of a tree for a frame.
+type treeTick struct {
+	w     *ecs.World
+	e     *ecs.Entity
+	pos   *ecs.Position
+	vel   *ecs.Velocity
+	state *ecs.TreeState
+	name  string
+	now   time.Duration
+}
+
+// behaviorTree ticks the tree from its root and reports the root's status.
+// Node memory lives in the entity's TreeState component.
+func (s *System) behaviorTree(w *ecs.World, e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity, p *TreeParams) Status {
+	if p.Root == nil {
+		return Failure
+	}
+	if !p.bound {
+		s.mu.RLock()
+		cat := s.catalog
+		s.mu.RUnlock()
+		if err := s.bindTree(cat, p, "tree", 1); err != nil {
+			p.Root = nil
+			fmt.Printf("[AI] Behavior tree disabled: %v\n", err)
+			return Failure
+		}
+	}
+
+	state := ecs.GetTyped[*ecs.TreeState](e, "AITreeState")
+	if state == nil {
+		state = &ecs.TreeState{}
+		w.Commands().AddComponent(e, state)
+	}
+	if state.Nodes == nil {
+		state.Nodes = make(map[string]ecs.TreeNodeState)
+	}
+
+	t := &treeTick{w: w, e: e, pos: pos, vel: vel, state: state, name: p.name, now: w.Clock().Elapsed()}
+	return s.tick(t, p.Root)
+}
+
+func (t *treeTick) load(n *TreeNode) ecs.TreeNodeState {
+	return t.state.Nodes[t.name+"/"+n.path]
+}
+
+func (t *treeTick) store(n *TreeNode, ns ecs.TreeNodeState) {
+	key := t.name + "/" + n.path
+	if ns == (ecs.TreeNodeState{}) {
+		delete(t.state.Nodes, key)
+		return
+	}
+	t.state.Nodes[key] = ns
+}
+
+// reset forgets the progress of n's subtree, for a running branch that was
+// preempted or whose parent finished without it. Cooldown timers are kept:
+// they limit how often a child runs, not how far it got.
+func (t *treeTick) reset(n *TreeNode) {
+	if n.Type != "cooldown" {
+		t.store(n, ecs.TreeNodeState{})
+	}
+	if n.Child != nil {
+		t.reset(n.Child)
+	}
+	for _, child := range n.Children {
+		t.reset(child)
+	}
+}
+
+func (s *System) tick(t *treeTick, n *TreeNode) Status {
+	switch n.Type {
+	case "sequence", "selector":
+		// A sequence stops at the first failure, a selector at the first
+		// success; either resumes a running child next frame unless it is
+		// reactive, in which case earlier children are checked again first.
+		stopOn := Failure
+		if n.Type == "selector" {
+			stopOn = Success
+		}
+		ns := t.load(n)
+		start := ns.Index
+		if n.Reactive {
+			start = 0
+		}
+		for i := start; i < len(n.Children); i++ {
+			st := s.tick(t, n.Children[i])
+			if st != Running && st != stopOn {
+				continue
+			}
+			if i < ns.Index {
+				t.reset(n.Children[ns.Index]) // preempted by an earlier child
+			}
+			if st == Running {
+				t.store(n, ecs.TreeNodeState{Index: i})
+			} else {
+				t.store(n, ecs.TreeNodeState{})
+			}
+			return st
+		}
+		t.store(n, ecs.TreeNodeState{})
+		if stopOn == Failure {
+			return Success
+		}
+		return Failure
+
+	case "parallel":
+		// Children that finished without deciding the outcome (successes
+		// under "all", failures under "one") are marked done in Index and
+		// not ticked again until the node finishes.
+		decides := Failure
+		if n.Policy == "one" {
+			decides = Success
+		}
+		done := t.load(n).Index
+		var running []*TreeNode
+		st := Running
+		for i, child := range n.Children {
+			if done&(1<<i) != 0 {
+				continue
+			}
+			switch cs := s.tick(t, child); cs {
+			case Running:
+				running = append(running, child)
+			case decides:
+				st = cs
+			default:
+				done |= 1 << i
+			}
+		}
+		if st == Running && done == 1<<len(n.Children)-1 {
+			st = Success
+			if decides == Success {
+				st = Failure
+			}
+		}
+		if st == Running {
+			t.store(n, ecs.TreeNodeState{Index: done})
+			return Running
+		}
+		// Children still running are abandoned; they start over next time.
+		for _, child := range running {
+			t.reset(child)
+		}
+		t.store(n, ecs.TreeNodeState{})
+		return st
+
+	case "inverter":
+		switch s.tick(t, n.Child) {
+		case Success:
+			return Failure
+		case Failure:
+			return Success
+		}
+		return Running
+
+	case "repeat":
+		ns := t.load(n)
+		switch s.tick(t, n.Child) {
+		case Failure:
+			t.store(n, ecs.TreeNodeState{})
+			return Failure
+		case Success:
+			ns.Index++
+			if n.Count > 0 && ns.Index >= n.Count {
+				t.store(n, ecs.TreeNodeState{})
+				return Success
+			}
+			t.store(n, ns)
+		}
+		return Running
+
+	case "cooldown":
+		ns := t.load(n)
+		if t.now < ns.Until {
+			return Failure
+		}
+		st := s.tick(t, n.Child)
+		if st != Running {
+			t.store(n, ecs.TreeNodeState{Until: t.now + time.Duration(n.Duration)})
+		}
+		return st
+
+	case "wait":
+		ns := t.load(n)
+		if ns.Index == 0 {
+			ns = ecs.TreeNodeState{Index: 1, Until: t.now + time.Duration(n.Duration)}
+			t.store(n, ns)
+		}
+		if t.now < ns.Until {
+			return Running
+		}
+		t.store(n, ecs.TreeNodeState{})
+		return Success
+
+	case "condition":
+		if s.checkConditions(t.w, t.e, n.Conditions) {
+			return Success
+		}
+		return Failure
+
+	case "action":
+		return s.executeAction(t.w, t.e, t.pos, t.vel, n.action)
+	}
+	return Failure
+}
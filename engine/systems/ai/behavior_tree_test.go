@@ -0,0 +1,281 @@
+package ai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"rp-go/engine/data"
+	"rp-go/engine/ecs"
+)
+
+// frame is the simulated time between ticks in these tests.
+const frame = 100 * time.Millisecond
+
+// scriptedParams names the script a "scripted" leaf plays back.
+type scriptedParams struct {
+	Key string `json:"key"`
+}
+
+// scripted leaves return the statuses of their script in turn, repeating
+// the last one ("S" success, "F" failure, "R" running), and log their key.
+type scripted struct {
+	scripts map[string]string
+	runs    map[string]int
+	log     []string
+}
+
+func (sc *scripted) run(_ *ecs.World, _ *ecs.Entity, _ *ecs.Position, _ *ecs.Velocity, p *scriptedParams) Status {
+	script := sc.scripts[p.Key]
+	if script == "" {
+		return Failure
+	}
+	i := min(sc.runs[p.Key], len(script)-1)
+	sc.runs[p.Key]++
+	sc.log = append(sc.log, p.Key)
+	switch script[i] {
+	case 'S':
+		return Success
+	case 'R':
+		return Running
+	}
+	return Failure
+}
+
+// newScripted registers the "scripted" behavior for the length of a test.
+func newScripted(t *testing.T, scripts map[string]string) *scripted {
+	sc := &scripted{scripts: scripts, runs: make(map[string]int)}
+	RegisterTask(GlobalBehaviorCatalog, "scripted", scriptedParams{}, sc.run)
+	t.Cleanup(func() { GlobalBehaviorCatalog.Unregister("scripted") })
+	return sc
+}
+
+// buildAction decodes params (JSON) as the only action of a new system
+// and returns the system with the decoded params.
+func buildAction(t *testing.T, name, typ, params string) (*System, any) {
+	t.Helper()
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(params), &raw); err != nil {
+		t.Fatalf("bad params: %v", err)
+	}
+	s := NewSystem(data.AIActionCatalog{Actions: []data.AIActionTemplate{
+		{Name: name, Type: typ, Params: raw},
+	}})
+	ctrl := s.BuildControllerFromRefs([]string{name})
+	if ctrl == nil || len(ctrl.Actions) != 1 {
+		t.Fatalf("%s did not decode", name)
+	}
+	return s, ctrl.Actions[0].Decoded.(*boundAction).params
+}
+
+// newTestEntity returns a world stepping every frame and an entity at the
+// origin with the given health out of 100.
+func newTestEntity(health float64) (*ecs.World, *ecs.Entity, *ecs.Position, *ecs.Velocity) {
+	w := ecs.NewWorld()
+	w.Clock().Step = frame
+	e := w.NewEntity()
+	pos, vel := &ecs.Position{}, &ecs.Velocity{}
+	e.Add(pos)
+	e.Add(vel)
+	e.Add(&ecs.Health{Current: health, Max: 100})
+	return w, e, pos, vel
+}
+
+// tickTree builds root as a tree action and ticks it once per frame,
+// returning each frame's leaves and root status as "a,b:running".
+func tickTree(t *testing.T, root string, scripts map[string]string, health float64, frames int) []string {
+	t.Helper()
+	sc := newScripted(t, scripts)
+	s, p := buildAction(t, "test_tree", "tree", `{"root":`+root+`}`)
+	w, e, pos, vel := newTestEntity(health)
+
+	var trace []string
+	for i := 0; i < frames; i++ {
+		w.Advance(frame)
+		sc.log = sc.log[:0]
+		st := s.behaviorTree(w, e, pos, vel, p.(*TreeParams))
+		w.Flush()
+		trace = append(trace, strings.Join(sc.log, ",")+":"+st.String())
+	}
+	return trace
+}
+
+func TestBehaviorTreeTicks(t *testing.T) {
+	leaf := func(key string) string {
+		return `{"type": "action", "action": "scripted", "params": {"key": "` + key + `"}}`
+	}
+	a, b, c := leaf("a"), leaf("b"), leaf("c")
+
+	tests := []struct {
+		name    string
+		root    string
+		scripts map[string]string
+		health  float64
+		want    []string
+	}{
+		// Status propagation.
+		{
+			name:    "sequence runs children until one fails",
+			root:    `{"type": "sequence", "children": [` + a + `,` + b + `,` + c + `]}`,
+			scripts: map[string]string{"a": "S", "b": "F", "c": "S"},
+			want:    []string{"a,b:failure"},
+		},
+		{
+			name:    "sequence succeeds when every child does",
+			root:    `{"type": "sequence", "children": [` + a + `,` + b + `]}`,
+			scripts: map[string]string{"a": "S", "b": "S"},
+			want:    []string{"a,b:success"},
+		},
+		{
+			name:    "selector stops at the first success",
+			root:    `{"type": "selector", "children": [` + a + `,` + b + `,` + c + `]}`,
+			scripts: map[string]string{"a": "F", "b": "S", "c": "S"},
+			want:    []string{"a,b:success"},
+		},
+		{
+			name:    "selector fails when every child does",
+			root:    `{"type": "selector", "children": [` + a + `,` + b + `]}`,
+			scripts: map[string]string{"a": "F", "b": "F"},
+			want:    []string{"a,b:failure"},
+		},
+		{
+			name:    "parallel waits for all children",
+			root:    `{"type": "parallel", "children": [` + a + `,` + b + `]}`,
+			scripts: map[string]string{"a": "RS", "b": "S"},
+			want:    []string{"a,b:running", "a:success"},
+		},
+		{
+			name:    "parallel with policy one succeeds with any child",
+			root:    `{"type": "parallel", "policy": "one", "children": [` + a + `,` + b + `]}`,
+			scripts: map[string]string{"a": "R", "b": "RS"},
+			want:    []string{"a,b:running", "a,b:success"},
+		},
+		{
+			name:    "inverter swaps success and failure",
+			root:    `{"type": "inverter", "child": ` + a + `}`,
+			scripts: map[string]string{"a": "SFR"},
+			want:    []string{"a:failure", "a:success", "a:running"},
+		},
+		{
+			name:    "condition gates a sequence",
+			root:    `{"type": "sequence", "children": [{"type": "condition", "conditions": {"health_lt": 0.5}},` + a + `]}`,
+			scripts: map[string]string{"a": "S"},
+			health:  30,
+			want:    []string{"a:success"},
+		},
+		{
+			name:    "failed condition skips the rest",
+			root:    `{"type": "sequence", "children": [{"type": "condition", "conditions": {"health_lt": 0.5}},` + a + `]}`,
+			scripts: map[string]string{"a": "S"},
+			health:  80,
+			want:    []string{":failure"},
+		},
+
+		// Resume and preemption.
+		{
+			name:    "selector resumes the running child",
+			root:    `{"type": "selector", "children": [` + a + `,` + b + `]}`,
+			scripts: map[string]string{"a": "FS", "b": "R"},
+			want:    []string{"a,b:running", "b:running"},
+		},
+		{
+			name:    "reactive selector lets an earlier child take over",
+			root:    `{"type": "selector", "reactive": true, "children": [` + a + `,` + b + `]}`,
+			scripts: map[string]string{"a": "FS", "b": "R"},
+			want:    []string{"a,b:running", "a:success"},
+		},
+		{
+			name: "preempted branch starts over",
+			root: `{"type": "selector", "reactive": true, "children": [` + a + `,
+				{"type": "sequence", "children": [` + b + `,` + c + `]}]}`,
+			scripts: map[string]string{"a": "FSF", "b": "S", "c": "R"},
+			want:    []string{"a,b,c:running", "a:success", "a,b,c:running"},
+		},
+		{
+			name:    "reactive sequence rechecks earlier children",
+			root:    `{"type": "sequence", "reactive": true, "children": [` + a + `,` + b + `]}`,
+			scripts: map[string]string{"a": "SSF", "b": "R"},
+			want:    []string{"a,b:running", "a,b:running", "a:failure"},
+		},
+
+		// Timing, one frame every 100ms.
+		{
+			name:    "wait runs for its duration",
+			root:    `{"type": "sequence", "children": [{"type": "wait", "duration": "250ms"},` + a + `]}`,
+			scripts: map[string]string{"a": "S"},
+			want:    []string{":running", ":running", ":running", "a:success"},
+		},
+		{
+			name:    "cooldown fails until its duration has passed",
+			root:    `{"type": "cooldown", "duration": "250ms", "child": ` + a + `}`,
+			scripts: map[string]string{"a": "S"},
+			want:    []string{"a:success", ":failure", ":failure", "a:success"},
+		},
+		{
+			name:    "cooldown starts once a running child finishes",
+			root:    `{"type": "cooldown", "duration": "150ms", "child": ` + a + `}`,
+			scripts: map[string]string{"a": "RS"},
+			want:    []string{"a:running", "a:success", ":failure", "a:success"},
+		},
+		{
+			name:    "repeat reruns its child count times",
+			root:    `{"type": "repeat", "count": 3, "child": ` + a + `}`,
+			scripts: map[string]string{"a": "S"},
+			want:    []string{"a:running", "a:running", "a:success", "a:running"},
+		},
+		{
+			name:    "repeat stops on failure",
+			root:    `{"type": "repeat", "child": ` + a + `}`,
+			scripts: map[string]string{"a": "SSF"},
+			want:    []string{"a:running", "a:running", "a:failure"},
+		},
+		{
+			name: "preempted wait restarts its timer",
+			root: `{"type": "selector", "reactive": true, "children": [` + a + `,
+				{"type": "wait", "duration": "300ms"}]}`,
+			scripts: map[string]string{"a": "FSF"},
+			want:    []string{"a:running", "a:success", "a:running", "a:running", "a:running", "a:success"},
+		},
+		{
+			name: "finished parallel resets running children",
+			root: `{"type": "parallel", "children": [` + a + `,
+				{"type": "wait", "duration": "300ms"}]}`,
+			scripts: map[string]string{"a": "FS"},
+			want:    []string{"a:failure", "a:running", ":running", ":running", ":success"},
+		},
+		{
+			name: "parallel remembers children that finished earlier",
+			root: `{"type": "parallel", "children": [{"type": "wait", "duration": "200ms"},
+				{"type": "wait", "duration": "300ms"}]}`,
+			want: []string{":running", ":running", ":running", ":success", ":running"},
+		},
+		{
+			name:    "parallel with policy one fails once every child has",
+			root:    `{"type": "parallel", "policy": "one", "children": [` + a + `,` + b + `]}`,
+			scripts: map[string]string{"a": "F", "b": "RRF"},
+			want:    []string{"a,b:running", "b:running", "b:failure", "a,b:failure"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := tt.health
+			if health == 0 {
+				health = 100
+			}
+			got := tickTree(t, tt.root, tt.scripts, health, len(tt.want))
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBehaviorTreeRejectsReactiveOutsideSequences(t *testing.T) {
+	p := &TreeParams{Root: &TreeNode{Type: "parallel", Reactive: true, Children: []*TreeNode{{Type: "wait", Duration: Duration(time.Second)}}}}
+	err := p.validate()
+	if err == nil || !strings.Contains(err.Error(), "root.reactive") {
+		t.Fatalf("expected a reactive error on the root, got %v", err)
+	}
+}
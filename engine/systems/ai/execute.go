@@ -2,18 +2,33 @@ package ai
 
 import "rp-go/engine/ecs"
 
-// executeAction dispatches an AI action to its registered behavior handler.
-// Returns true if the action executed successfully this frame.
+// boundAction is what decodeAction stores in AIActionInstance.Decoded: the
+// typed params plus the decoded preconditions.
+type boundAction struct {
+	params any
+	when   *ConditionSet
+}
+
+// executeAction dispatches an AI action to its registered behavior handler
+// and returns its status for this frame. Actions whose params never decoded,
+// or whose conditions do not hold, fail without running.
 func (s *System) executeAction(
 	w *ecs.World,
 	e *ecs.Entity,
 	pos *ecs.Position,
 	vel *ecs.Velocity,
 	act ecs.AIActionInstance,
-) bool {
-	fn, ok := GlobalBehaviorCatalog.Get(act.Type)
+) Status {
+	bound, ok := act.Decoded.(*boundAction)
 	if !ok {
-		return false
+		return Failure
+	}
+	b, ok := GlobalBehaviorCatalog.lookup(act.Type)
+	if !ok {
+		return Failure
+	}
+	if !s.checkConditions(w, e, bound.when) {
+		return Failure
 	}
-	return fn(w, e, pos, vel, act.Params)
+	return b.run(w, e, pos, vel, bound.params)
 }
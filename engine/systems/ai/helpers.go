@@ -5,21 +5,10 @@ import (
 	"time"
 )
 
-func getFloat(m map[string]any, key string, def float64) float64 {
-	if v, ok := m[key]; ok {
-		switch n := v.(type) {
-		case float64:
-			return n
-		case int:
-			return float64(n)
-		}
-	}
-	return def
-}
-
 func (s *System) ensureRNG() {
 	if s.rng == nil {
-		s.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+		s.seed = time.Now().UnixNano()
+		s.rng = rand.New(rand.NewSource(s.seed))
 	}
 }
 
@@ -0,0 +1,80 @@
+package ai
+
+import (
+	"math"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/nav"
+)
+
+/*───────────────────────────────────────────────*
+ | NAVIGATION                                    |
+ *───────────────────────────────────────────────*/
+
+const (
+	waypointRadius = 4  // a path point counts as reached inside this distance
+	replanDistance = 32 // request a new path once the goal moves this far
+)
+
+// SetNavigator routes movement behaviors around blocked terrain. Without a
+// navigator, or while the goal is in plain sight, they steer straight at it.
+func (s *System) SetNavigator(n *nav.Service) {
+	s.mu.Lock()
+	s.nav = n
+	s.mu.Unlock()
+}
+
+// Navigator returns the path service movement behaviors use, or nil.
+func (s *System) Navigator() *nav.Service {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.nav
+}
+
+// heading returns the unit direction e should move in to reach (gx, gy),
+// following the entity's NavPath when terrain blocks the straight line.
+// A new path is requested when the goal moves or the current path runs out
+// with the goal still out of sight (shared paths may end at another
+// requester's goal). While it is being searched the entity keeps to its
+// previous path, or heads straight for the goal when it has none.
+func (s *System) heading(w *ecs.World, e *ecs.Entity, pos *ecs.Position, gx, gy float64) (float64, float64) {
+	from, goal := nav.Point{X: pos.X, Y: pos.Y}, nav.Point{X: gx, Y: gy}
+	tx, ty := gx, gy
+
+	n := s.Navigator()
+	path := ecs.GetTyped[*ecs.NavPath](e, "AINavPath")
+	if n != nil && !n.LineOfSight(from, goal) {
+		if path == nil {
+			path = &ecs.NavPath{}
+			w.Commands().AddComponent(e, path)
+		}
+		exhausted := path.Index >= len(path.Points)
+		if exhausted || math.Hypot(gx-path.GoalX, gy-path.GoalY) > replanDistance {
+			if pts, ready := n.Request(from, goal); ready {
+				path.Points = path.Points[:0]
+				for _, p := range pts {
+					path.Points = append(path.Points, ecs.AIWaypoint{X: p.X, Y: p.Y})
+				}
+				path.Index = 0
+				path.GoalX, path.GoalY = gx, gy
+			}
+		}
+		for path.Index < len(path.Points) {
+			wp := path.Points[path.Index]
+			if math.Hypot(wp.X-pos.X, wp.Y-pos.Y) > waypointRadius {
+				tx, ty = wp.X, wp.Y
+				break
+			}
+			path.Index++
+		}
+	} else if path != nil {
+		path.Points = path.Points[:0]
+	}
+
+	dx, dy := tx-pos.X, ty-pos.Y
+	dist := math.Hypot(dx, dy)
+	if dist == 0 {
+		return 0, 0
+	}
+	return dx / dist, dy / dist
+}
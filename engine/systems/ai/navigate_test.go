@@ -0,0 +1,53 @@
+package ai
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/nav"
+)
+
+// blindFinder never has line of sight and answers every search with a
+// straight path to the requested target.
+type blindFinder struct{ searches atomic.Int32 }
+
+func (f *blindFinder) FindPath(_, to nav.Point) ([]nav.Point, bool) {
+	f.searches.Add(1)
+	return []nav.Point{to}, true
+}
+
+func (*blindFinder) Walkable(nav.Point) bool { return true }
+
+func (*blindFinder) LineOfSight(_, _ nav.Point) bool { return false }
+
+func TestHeadingReplansExhaustedPathWithoutLineOfSight(t *testing.T) {
+	w := ecs.NewWorld()
+	finder := &blindFinder{}
+	svc := nav.NewService(nav.Config{})
+	svc.SetFinder(finder)
+	s := &System{}
+	s.SetNavigator(svc)
+
+	e := w.NewEntity()
+	pos := &ecs.Position{}
+	e.Add(pos)
+	// A shared path that ended at another requester's target, short of
+	// this entity's goal, which has not moved.
+	path := &ecs.NavPath{Points: []ecs.AIWaypoint{{X: 0, Y: 0}}, Index: 1, GoalX: 200}
+	e.Add(path)
+
+	s.heading(w, e, pos, 200, 0)
+	svc.Sync()
+	dx, dy := s.heading(w, e, pos, 200, 0)
+
+	if finder.searches.Load() != 1 {
+		t.Fatalf("expected the exhausted path to be replanned, got %d searches", finder.searches.Load())
+	}
+	if len(path.Points) != 1 || path.Index != 0 || path.Points[0].X != 200 {
+		t.Fatalf("expected the new path to the goal, got %+v", path)
+	}
+	if dx != 1 || dy != 0 {
+		t.Fatalf("expected to head along the new path, got (%.2f,%.2f)", dx, dy)
+	}
+}
@@ -0,0 +1,72 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"rp-go/engine/data"
+)
+
+/*───────────────────────────────────────────────*
+| TYPED PARAMS                                  |
+*───────────────────────────────────────────────*/
+
+// decodeParams decodes raw over a copy of defaults, rejecting unknown keys.
+// Key-level failures come back as *data.ParamError so lint output can point
+// at the key. Params with a validate method are checked after decoding.
+func decodeParams[P any](defaults P, raw map[string]any) (*P, error) {
+	p := defaults
+	if len(raw) > 0 {
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&p); err != nil {
+			return nil, paramError(err)
+		}
+	}
+	if v, ok := any(&p).(interface{ validate() error }); ok {
+		if err := v.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &p, nil
+}
+
+func paramError(err error) error {
+	if key, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return &data.ParamError{Key: strings.Trim(key, `"`), Message: "unknown key"}
+	}
+	var typ *json.UnmarshalTypeError
+	if errors.As(err, &typ) && typ.Field != "" {
+		return &data.ParamError{Key: typ.Field, Message: fmt.Sprintf("expected %s, got %s", typ.Type, typ.Value)}
+	}
+	return err
+}
+
+// Duration is a time.Duration written in ai.json as a string ("500ms",
+// "1.5s").
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"500ms\"")
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
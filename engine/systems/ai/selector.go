@@ -0,0 +1,113 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+/*───────────────────────────────────────────────*
+ | TARGET SELECTORS                              |
+ *───────────────────────────────────────────────*/
+
+// Selector names the actor a behavior or condition targets. It is written
+// in ai.json as a string:
+//
+//	"player"                  the actor with that ID, else the first spawned from that template
+//	"id:player"               exactly the actor with that ID
+//	"template:dark-elf-ship"  actors spawned from a template ("dark-elf-ship-001", ...)
+//	"archetype:enemy"         actors of an archetype
+//	"tag:boss"                actors carrying a Tag
+//	"self.parent"             the entity's parent in the hierarchy
+//	"nearest:archetype:enemy" the closest match of another selector
+//	"weakest:tag:escort"      the match with the lowest health fraction
+//
+// A selector matching several actors picks the first spawned (lowest
+// entity ID) unless wrapped in nearest: or weakest:. An entity never
+// targets itself.
+type Selector struct {
+	raw   string
+	kind  selectorKind
+	value string
+	pick  selectorPick
+}
+
+type selectorKind int
+
+const (
+	selectName selectorKind = iota // bare name: ID, then template
+	selectID
+	selectTemplate
+	selectArchetype
+	selectTag
+	selectParent
+)
+
+type selectorPick int
+
+const (
+	pickFirst selectorPick = iota
+	pickNearest
+	pickWeakest
+)
+
+var selectorKinds = map[string]selectorKind{
+	"id":        selectID,
+	"template":  selectTemplate,
+	"archetype": selectArchetype,
+	"tag":       selectTag,
+}
+
+// ParseSelector parses a selector string; "" parses to the zero Selector,
+// which matches nothing.
+func ParseSelector(s string) (Selector, error) {
+	sel := Selector{raw: s}
+	rest := s
+	if inner, ok := strings.CutPrefix(rest, "nearest:"); ok {
+		sel.pick, rest = pickNearest, inner
+	} else if inner, ok := strings.CutPrefix(rest, "weakest:"); ok {
+		sel.pick, rest = pickWeakest, inner
+	}
+
+	switch prefix, value, found := strings.Cut(rest, ":"); {
+	case rest == "self.parent":
+		sel.kind = selectParent
+	case found:
+		kind, ok := selectorKinds[prefix]
+		if !ok {
+			return Selector{}, fmt.Errorf("selector %q: unknown prefix %q", s, prefix+":")
+		}
+		if value == "" {
+			return Selector{}, fmt.Errorf("selector %q: missing name after %q", s, prefix+":")
+		}
+		sel.kind, sel.value = kind, value
+	case rest == "" && sel.pick != pickFirst:
+		return Selector{}, fmt.Errorf("selector %q: missing selector to pick from", s)
+	default:
+		sel.kind, sel.value = selectName, rest
+	}
+	return sel, nil
+}
+
+// IsZero reports whether the selector is empty.
+func (sel Selector) IsZero() bool { return sel.raw == "" }
+
+// String returns the selector as written.
+func (sel Selector) String() string { return sel.raw }
+
+func (sel Selector) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sel.raw)
+}
+
+func (sel *Selector) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("target must be a selector string like \"player\" or \"nearest:archetype:enemy\"")
+	}
+	parsed, err := ParseSelector(s)
+	if err != nil {
+		return err
+	}
+	*sel = parsed
+	return nil
+}
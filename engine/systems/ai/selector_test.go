@@ -0,0 +1,66 @@
+package ai
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		in    string
+		kind  selectorKind
+		value string
+		pick  selectorPick
+		err   string // substring of the expected error
+	}{
+		{in: "player", kind: selectName, value: "player"},
+		{in: "id:player", kind: selectID, value: "player"},
+		{in: "template:dark-elf-ship", kind: selectTemplate, value: "dark-elf-ship"},
+		{in: "archetype:enemy", kind: selectArchetype, value: "enemy"},
+		{in: "tag:boss", kind: selectTag, value: "boss"},
+		{in: "self.parent", kind: selectParent},
+		{in: "nearest:archetype:enemy", kind: selectArchetype, value: "enemy", pick: pickNearest},
+		{in: "weakest:tag:escort", kind: selectTag, value: "escort", pick: pickWeakest},
+		{in: "nearest:player", kind: selectName, value: "player", pick: pickNearest},
+		{in: "", kind: selectName},
+
+		{in: "faction:red", err: `unknown prefix "faction:"`},
+		{in: "tag:", err: `missing name after "tag:"`},
+		{in: "nearest:", err: "missing selector to pick from"},
+		{in: "weakest:squad:a", err: `unknown prefix "squad:"`},
+		{in: "nearest:weakest:tag:a", err: `unknown prefix "weakest:"`},
+	}
+	for _, tt := range tests {
+		sel, err := ParseSelector(tt.in)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%q: expected error containing %q, got %v", tt.in, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.in, err)
+			continue
+		}
+		if sel.kind != tt.kind || sel.value != tt.value || sel.pick != tt.pick || sel.String() != tt.in {
+			t.Errorf("%q: parsed to %+v", tt.in, sel)
+		}
+	}
+}
+
+func TestSelectorJSON(t *testing.T) {
+	var p struct{ Target Selector }
+	if err := json.Unmarshal([]byte(`{"Target": "nearest:tag:boss"}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := json.Marshal(p); string(out) != `{"Target":"nearest:tag:boss"}` {
+		t.Fatalf("expected the selector to round-trip, got %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"Target": 3}`), &p); err == nil {
+		t.Fatal("expected a non-string target to fail")
+	}
+	if err := json.Unmarshal([]byte(`{"Target": "nearest:"}`), &p); err == nil {
+		t.Fatal("expected a bad selector to fail")
+	}
+}
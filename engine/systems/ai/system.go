@@ -10,7 +10,9 @@ import (
 	"rp-go/engine/data"
 	"rp-go/engine/ecs"
 	"rp-go/engine/events"
+	"rp-go/engine/nav"
 	"rp-go/engine/platform"
+	"rp-go/engine/systems/spatial"
 )
 
 /*───────────────────────────────────────────────*
@@ -20,8 +22,13 @@ import (
 type System struct {
 	mu       sync.RWMutex
 	rng      *rand.Rand
+	seed     int64
 	catalog  *AIActionCatalogLookup
 	lastLoad time.Time
+	nav      *nav.Service
+	actors   ActorLookup
+	index    *spatial.Index
+	buf      []*ecs.Entity // neighbor query scratch
 }
 
 /*───────────────────────────────────────────────*
@@ -30,14 +37,27 @@ type System struct {
 
 // NewSystem constructs an AI system and initializes the behavior catalog.
 func NewSystem(cat data.AIActionCatalog) *System {
-	sys := &System{
-		rng:     rand.New(rand.NewSource(time.Now().UnixNano())),
-		catalog: NewCatalogLookup(cat),
-	}
+	sys := &System{catalog: NewCatalogLookup(cat)}
+	sys.Reseed(time.Now().UnixNano())
 	RegisterDefaultBehaviors(sys)
 	return sys
 }
 
+// Reseed resets the behavior RNG so runs can be reproduced (e.g. replays).
+func (s *System) Reseed(seed int64) {
+	s.mu.Lock()
+	s.seed = seed
+	s.rng = rand.New(rand.NewSource(seed))
+	s.mu.Unlock()
+}
+
+// Seed returns the seed the behavior RNG was last initialized with.
+func (s *System) Seed() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.seed
+}
+
 /*───────────────────────────────────────────────*
  | DATA RELOAD HOOK                              |
  *───────────────────────────────────────────────*/
@@ -58,23 +78,34 @@ func (s *System) OnDataReload(e events.DataReloaded, cat data.AIActionCatalog) {
  *───────────────────────────────────────────────*/
 
 // BuildControllerFromRefs instantiates a controller from action names.
+// Params are decoded here, once; actions whose params fail to decode are
+// left out with a log line.
 func (s *System) BuildControllerFromRefs(refs []string) *ecs.AIController {
 	if len(refs) == 0 {
 		return nil
 	}
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	cat := s.catalog
+	s.mu.RUnlock()
 
 	ctrl := &ecs.AIController{Active: true}
 	for _, name := range refs {
-		if tpl, ok := s.catalog.Get(name); ok {
-			ctrl.Actions = append(ctrl.Actions, ecs.AIActionInstance{
-				Name:     tpl.Name,
-				Type:     tpl.Type,
-				Priority: tpl.Priority,
-				Params:   tpl.Params,
-			})
+		tpl, ok := cat.Get(name)
+		if !ok {
+			continue
 		}
+		act := ecs.AIActionInstance{
+			Name:       tpl.Name,
+			Type:       tpl.Type,
+			Priority:   tpl.Priority,
+			Params:     tpl.Params,
+			Conditions: tpl.Conditions,
+		}
+		if err := s.decodeAction(cat, &act, 0); err != nil {
+			fmt.Printf("[AI] Skipping action %q: %v\n", name, err)
+			continue
+		}
+		ctrl.Actions = append(ctrl.Actions, act)
 	}
 	sort.SliceStable(ctrl.Actions, func(i, j int) bool {
 		return ctrl.Actions[i].Priority < ctrl.Actions[j].Priority
@@ -82,15 +113,60 @@ func (s *System) BuildControllerFromRefs(refs []string) *ecs.AIController {
 	return ctrl
 }
 
+// decodeAction fills act.Decoded from act.Params and act.Conditions. Script
+// steps, tree leaves and machine states are resolved against cat and
+// decoded as well.
+func (s *System) decodeAction(cat *AIActionCatalogLookup, act *ecs.AIActionInstance, depth int) error {
+	if depth > maxScriptDepth {
+		return fmt.Errorf("scripts nested deeper than %d", maxScriptDepth)
+	}
+	decoded, err := GlobalBehaviorCatalog.Decode(act.Type, act.Params)
+	if err != nil {
+		return err
+	}
+	when, err := decodeConditions(act.Conditions)
+	if err != nil {
+		return err
+	}
+	switch p := decoded.(type) {
+	case *ScriptParams:
+		p.resolved = s.resolveSteps(cat, p.Steps, depth+1)
+	case *TreeParams:
+		if err := s.bindTree(cat, p, act.Name, depth); err != nil {
+			return err
+		}
+	case *MachineParams:
+		if err := s.bindMachine(cat, p, act.Name, depth); err != nil {
+			return err
+		}
+	}
+	act.Decoded = &boundAction{params: decoded, when: when}
+	return nil
+}
+
 /*───────────────────────────────────────────────*
  | UPDATE LOOP                                   |
  *───────────────────────────────────────────────*/
 
+// Access declares the components AI behaviors touch for the scheduler.
+func (s *System) Access() ecs.Access {
+	return ecs.Access{
+		Reads:  []string{"Actor", "Position", "Health", "Parent", "SpatialIndex"},
+		Writes: []string{"AIController", "Velocity", "AIScriptState", "AITreeState", "AIStateMachine", "AINavPath", "AISteering", "AITargets"},
+	}
+}
+
 func (s *System) Update(w *ecs.World) {
 	if w == nil {
 		return
 	}
+	if w.Clock().StepScale() == 0 {
+		return // idle pass or paused: decisions, RNG and timers wait for a tick
+	}
 	s.ensureRNG()
+	if n := s.Navigator(); n != nil {
+		n.Sync() // publish the paths requested last frame
+	}
 
 	manager := w.EntitiesManager()
 	if manager == nil {
@@ -110,8 +186,15 @@ func (s *System) Update(w *ecs.World) {
 		}
 
 		vel.VX, vel.VY = 0, 0
-		for _, act := range ctrl.Actions {
-			if s.executeAction(w, e, pos, vel, act) {
+		for i := range ctrl.Actions {
+			if ctrl.Actions[i].Decoded == nil {
+				// Controllers restored from a save carry raw params only.
+				s.mu.RLock()
+				cat := s.catalog
+				s.mu.RUnlock()
+				_ = s.decodeAction(cat, &ctrl.Actions[i], 0)
+			}
+			if s.executeAction(w, e, pos, vel, ctrl.Actions[i]) == Running {
 				break
 			}
 		}
@@ -0,0 +1,207 @@
+package ai
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/systems/actor"
+	"rp-go/engine/systems/spatial"
+)
+
+/*───────────────────────────────────────────────*
+ | TARGET LOOKUP                                 |
+ *───────────────────────────────────────────────*/
+
+// ActorLookup finds actor entities for target selectors; actor.Registry
+// implements it. Lists are sorted by entity ID.
+type ActorLookup interface {
+	FindByID(id string) (*ecs.Entity, bool)
+	FindByTemplate(template string) []*ecs.Entity
+	FindByArchetype(archetype string) []*ecs.Entity
+	FindByTag(tag string) []*ecs.Entity
+}
+
+// retargetInterval is how long a resolved selector is trusted before it is
+// resolved again; a target that dies is dropped at once.
+const retargetInterval = 500 * time.Millisecond
+
+// SetActorLookup resolves behavior targets through lookup instead of
+// scanning every entity.
+func (s *System) SetActorLookup(lookup ActorLookup) {
+	s.mu.Lock()
+	s.actors = lookup
+	s.mu.Unlock()
+}
+
+// SetSpatialIndex answers neighbor queries (flocking) from index instead
+// of scanning every entity.
+func (s *System) SetSpatialIndex(index *spatial.Index) {
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+}
+
+// target returns the live entity sel picks for e, or nil. Resolutions are
+// cached on e's TargetCache for retargetInterval.
+func (s *System) target(w *ecs.World, e *ecs.Entity, sel Selector) *ecs.Entity {
+	if sel.IsZero() {
+		return nil
+	}
+	now := w.Clock().Elapsed()
+	cache := ecs.GetTyped[*ecs.TargetCache](e, "AITargets")
+	if cache == nil {
+		cache = &ecs.TargetCache{Entries: make(map[string]ecs.TargetEntry)}
+		w.Commands().AddComponent(e, cache)
+	} else if entry, ok := cache.Entries[sel.raw]; ok && now < entry.Until {
+		if !entry.Found {
+			return nil
+		}
+		if t := w.GetEntity(entry.ID); t.Alive() {
+			return t
+		}
+	}
+
+	t := s.resolve(w, e, sel)
+	entry := ecs.TargetEntry{Until: now + retargetInterval}
+	if t != nil {
+		entry.ID, entry.Found = t.ID, true
+	}
+	cache.Entries[sel.raw] = entry
+	return t
+}
+
+// targetPosition returns the position of the entity sel picks for e.
+func (s *System) targetPosition(w *ecs.World, e *ecs.Entity, sel Selector) (*ecs.Position, bool) {
+	t := s.target(w, e, sel)
+	if t == nil {
+		return nil, false
+	}
+	pos, ok := t.Get("Position").(*ecs.Position)
+	return pos, ok && pos != nil
+}
+
+// resolve evaluates sel for e without the cache.
+func (s *System) resolve(w *ecs.World, e *ecs.Entity, sel Selector) *ecs.Entity {
+	if sel.kind == selectParent {
+		return w.ParentOf(e)
+	}
+	s.mu.RLock()
+	lookup := s.actors
+	s.mu.RUnlock()
+	if lookup == nil {
+		lookup = scanLookup{w}
+	}
+
+	var candidates []*ecs.Entity
+	switch sel.kind {
+	case selectName:
+		if t, ok := lookup.FindByID(sel.value); ok {
+			candidates = []*ecs.Entity{t}
+		} else {
+			candidates = lookup.FindByTemplate(sel.value)
+		}
+	case selectID:
+		if t, ok := lookup.FindByID(sel.value); ok {
+			candidates = []*ecs.Entity{t}
+		}
+	case selectTemplate:
+		candidates = lookup.FindByTemplate(sel.value)
+	case selectArchetype:
+		candidates = lookup.FindByArchetype(sel.value)
+	case selectTag:
+		candidates = lookup.FindByTag(sel.value)
+	}
+	return pick(e, candidates, sel.pick)
+}
+
+// pick chooses among candidates sorted by entity ID, skipping e itself.
+// Weakest compares health fractions, then distance; actors without Health
+// are never the weakest.
+func pick(e *ecs.Entity, candidates []*ecs.Entity, how selectorPick) *ecs.Entity {
+	pos, _ := e.Get("Position").(*ecs.Position)
+	var best *ecs.Entity
+	bestHealth, bestDist := math.Inf(1), math.Inf(1)
+	for _, c := range candidates {
+		if c == e || !c.Alive() {
+			continue
+		}
+		if how == pickFirst {
+			return c
+		}
+		health := 0.0
+		if how == pickWeakest {
+			hp, _ := c.Get("Health").(*ecs.Health)
+			if hp == nil || hp.Max <= 0 {
+				continue
+			}
+			health = hp.Fraction()
+		}
+		dist := math.Inf(1)
+		if cp, ok := c.Get("Position").(*ecs.Position); ok && pos != nil {
+			dist = (cp.X-pos.X)*(cp.X-pos.X) + (cp.Y-pos.Y)*(cp.Y-pos.Y)
+		}
+		if best == nil || health < bestHealth || (health == bestHealth && dist < bestDist) {
+			best, bestHealth, bestDist = c, health, dist
+		}
+	}
+	return best
+}
+
+// scanLookup answers selector queries by scanning the world, for systems
+// running without an actor registry.
+type scanLookup struct{ w *ecs.World }
+
+func (l scanLookup) filter(match func(*ecs.Actor, *ecs.Entity) bool) []*ecs.Entity {
+	var out []*ecs.Entity
+	l.w.EntitiesManager().ForEach(func(ent *ecs.Entity) {
+		if act, _ := ent.Get("Actor").(*ecs.Actor); act != nil && match(act, ent) {
+			out = append(out, ent)
+		}
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func (l scanLookup) FindByID(id string) (*ecs.Entity, bool) {
+	found := l.filter(func(act *ecs.Actor, _ *ecs.Entity) bool { return act.ID == id })
+	if len(found) == 0 {
+		return nil, false
+	}
+	return found[0], true
+}
+
+func (l scanLookup) FindByTemplate(template string) []*ecs.Entity {
+	return l.filter(func(act *ecs.Actor, _ *ecs.Entity) bool { return actor.FromTemplate(act.ID, template) })
+}
+
+func (l scanLookup) FindByArchetype(archetype string) []*ecs.Entity {
+	return l.filter(func(act *ecs.Actor, _ *ecs.Entity) bool { return act.Archetype == archetype })
+}
+
+func (l scanLookup) FindByTag(tag string) []*ecs.Entity {
+	return l.filter(func(_ *ecs.Actor, ent *ecs.Entity) bool { return ent.HasTag(tag) })
+}
+
+// neighbors calls fn for every entity with a Position within r of (x, y),
+// other than e.
+func (s *System) neighbors(w *ecs.World, e *ecs.Entity, x, y, r float64, fn func(*ecs.Entity, *ecs.Position)) {
+	s.mu.RLock()
+	index := s.index
+	s.mu.RUnlock()
+	if index == nil {
+		ecs.NewQuery[*ecs.Position](w).Each(func(o *ecs.Entity, op *ecs.Position) {
+			if o != e && (op.X-x)*(op.X-x)+(op.Y-y)*(op.Y-y) <= r*r {
+				fn(o, op)
+			}
+		})
+		return
+	}
+	s.buf = index.Radius(x, y, r, s.buf[:0])
+	for _, o := range s.buf {
+		if op, ok := o.Get("Position").(*ecs.Position); ok && o != e {
+			fn(o, op)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package ai
+
+import (
+	"testing"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/systems/actor"
+)
+
+// targetScene spawns actors along the x axis around a scout at the origin,
+// registering them with reg. Health is out of 100; 0 leaves it out.
+type targetScene struct {
+	w      *ecs.World
+	reg    *actor.Registry
+	byID   map[string]*ecs.Entity
+	seeker *ecs.Entity
+}
+
+func newTargetScene() *targetScene {
+	sc := &targetScene{w: ecs.NewWorld(), reg: actor.NewRegistry(), byID: make(map[string]*ecs.Entity)}
+	sc.w.Clock().Step = frame
+	sc.seeker = sc.spawn("scout", "enemy", 0, 10)
+	sc.spawn("player", "player", 500, 100)
+	sc.spawn("dark-elf-ship-commander", "enemy", 300, 100, "boss")
+	sc.spawn("dark-elf-ship-001", "enemy", 200, 40)
+	sc.spawn("dark-elf-ship-002", "enemy", 50, 60)
+	sc.spawn("drone", "enemy", 10, 0)
+	sc.w.SetParent(sc.seeker, sc.spawn("mothership", "carrier", 1000, 100))
+	return sc
+}
+
+func (sc *targetScene) spawn(id, archetype string, x, health float64, tags ...string) *ecs.Entity {
+	e := sc.w.NewEntity()
+	act := &ecs.Actor{ID: id, Archetype: archetype}
+	e.Add(act)
+	e.Add(&ecs.Position{X: x})
+	if health > 0 {
+		e.Add(&ecs.Health{Current: health, Max: 100})
+	}
+	for _, tag := range tags {
+		e.Add(ecs.Tag(tag))
+	}
+	sc.reg.Add(act, e)
+	sc.byID[id] = e
+	return e
+}
+
+// name returns the actor ID of e, or "" for nil.
+func name(e *ecs.Entity) string {
+	if e == nil {
+		return ""
+	}
+	return e.Get("Actor").(*ecs.Actor).ID
+}
+
+func mustParse(t *testing.T, s string) Selector {
+	t.Helper()
+	sel, err := ParseSelector(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sel
+}
+
+func TestTargetResolution(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     string
+	}{
+		{"player", "player"},
+		{"dark-elf-ship", "dark-elf-ship-001"}, // no such ID; the commander is not from the template
+		{"id:dark-elf-ship", ""},
+		{"template:dark-elf-ship", "dark-elf-ship-001"},
+		{"archetype:enemy", "dark-elf-ship-commander"}, // the scout itself is skipped
+		{"tag:boss", "dark-elf-ship-commander"},
+		{"tag:Actor", ""}, // component names are not tags
+		{"nearest:archetype:enemy", "drone"},
+		{"nearest:template:dark-elf-ship", "dark-elf-ship-002"},
+		{"weakest:archetype:enemy", "dark-elf-ship-001"}, // not the weaker scout, nor the drone without health
+		{"self.parent", "mothership"},
+		{"ghost", ""},
+	}
+	lookups := map[string]func(sc *targetScene) ActorLookup{
+		"scan":     func(*targetScene) ActorLookup { return nil },
+		"registry": func(sc *targetScene) ActorLookup { return sc.reg },
+	}
+	for lookupName, lookup := range lookups {
+		for _, tt := range tests {
+			sc := newTargetScene()
+			s := &System{}
+			if l := lookup(sc); l != nil {
+				s.SetActorLookup(l)
+			}
+			if got := name(s.target(sc.w, sc.seeker, mustParse(t, tt.selector))); got != tt.want {
+				t.Errorf("%s %q: expected %q, got %q", lookupName, tt.selector, tt.want, got)
+			}
+		}
+	}
+}
+
+func TestTargetCacheExpires(t *testing.T) {
+	sc := newTargetScene()
+	s := &System{}
+	nearest, ghost := mustParse(t, "nearest:archetype:enemy"), mustParse(t, "ghost")
+
+	sc.w.Advance(frame)
+	if got := name(s.target(sc.w, sc.seeker, nearest)); got != "drone" {
+		t.Fatalf("expected the drone, got %q", got)
+	}
+	sc.w.Flush() // attach the cache
+	if got := s.target(sc.w, sc.seeker, ghost); got != nil {
+		t.Fatalf("expected no ghost, got %q", name(got))
+	}
+
+	sc.byID["dark-elf-ship-002"].Get("Position").(*ecs.Position).X = 1
+	sc.spawn("ghost", "spirit", 0, 0)
+	for i := 0; i < 4; i++ {
+		sc.w.Advance(frame)
+		if got := name(s.target(sc.w, sc.seeker, nearest)); got != "drone" {
+			t.Fatalf("frame %d: expected the cached drone, got %q", i, got)
+		}
+		if got := s.target(sc.w, sc.seeker, ghost); got != nil {
+			t.Fatalf("frame %d: expected the cached miss, got %q", i, name(got))
+		}
+	}
+
+	sc.w.Advance(frame) // retargetInterval has passed
+	if got := name(s.target(sc.w, sc.seeker, nearest)); got != "dark-elf-ship-002" {
+		t.Fatalf("expected the closer ship once the cache expired, got %q", got)
+	}
+	if got := name(s.target(sc.w, sc.seeker, ghost)); got != "ghost" {
+		t.Fatalf("expected the new ghost once the cache expired, got %q", got)
+	}
+}
+
+func TestTargetDropsDeadTargets(t *testing.T) {
+	sc := newTargetScene()
+	s := &System{}
+	s.SetActorLookup(sc.reg)
+	nearest := mustParse(t, "nearest:archetype:enemy")
+
+	sc.w.Advance(frame)
+	if got := name(s.target(sc.w, sc.seeker, nearest)); got != "drone" {
+		t.Fatalf("expected the drone, got %q", got)
+	}
+	sc.w.Flush()
+
+	// Still registered, but dead: the cache and the lookup must both skip it.
+	sc.w.RemoveEntity(sc.byID["drone"])
+	if got := name(s.target(sc.w, sc.seeker, nearest)); got != "dark-elf-ship-002" {
+		t.Fatalf("expected the next nearest ship in the same frame, got %q", got)
+	}
+}
@@ -16,8 +16,8 @@ import (
 | DEBUG WINDOW STRUCTURE                        |
 *───────────────────────────────────────────────*/
 
-// DebugWindow displays a real-time list of AI-composed entities
-// and their currently bound AI actions.
+// DebugWindow displays a real-time list of AI-composed entities,
+// their currently bound AI actions and state machine states.
 type DebugWindow struct {
 	component *window.Component
 	content   *ComposerDebugContent
@@ -85,6 +85,15 @@ func (w *DebugWindow) Hide() {
 	}
 }
 
+// Toggle flips visibility; the window is created on the next Ensure if
+// it was never shown.
+func (w *DebugWindow) Toggle() {
+	w.visible = !w.visible
+	if w.component != nil {
+		w.component.Visible = w.visible
+	}
+}
+
 /*───────────────────────────────────────────────*
 | CONTENT RENDERER                              |
 *───────────────────────────────────────────────*/
@@ -143,6 +152,9 @@ func (c *ComposerDebugContent) Refresh(world *ecs.World, composer *System) {
 		}
 
 		lines = append(lines, fmt.Sprintf("[%3d] %-18s (%d actions)", entity.ID, act.ID, len(ctrl.Actions)))
+		if st, ok := composer.states[id]; ok {
+			lines = append(lines, fmt.Sprintf("   state: %s (%s)", st.To, st.Machine))
+		}
 		for _, a := range ctrl.Actions {
 			lines = append(lines, fmt.Sprintf("   • %s [%s]", a.Name, a.Type))
 		}
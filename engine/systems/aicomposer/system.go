@@ -15,13 +15,22 @@ import (
  *───────────────────────────────────────────────*/
 
 // System automatically binds AIControllers to entities with Actor.AIRefs.
-// It listens for data reloads and clears its internal cache when needed.
+// New actors are queued through world lifecycle hooks, so only freshly
+// spawned entities are inspected. It listens for data reloads and clears
+// its internal cache when needed.
 type System struct {
 	data       *dataSys.System
 	ai         *ai.System
 	mu         sync.RWMutex
 	processed  map[ecs.EntityID]bool // cache of already composed entities
 	reloadFlag bool                  // true when ai.json is reloaded
+
+	world   *ecs.World    // world the lifecycle hooks are bound to
+	pending []*ecs.Entity // entities awaiting composition (main thread only)
+	unbind  []func()
+
+	bus    *events.TypedBus                            // bus the state listener is subscribed to
+	states map[ecs.EntityID]events.AIStateChangedEvent // last state machine transition per entity
 }
 
 /*───────────────────────────────────────────────*
@@ -37,6 +46,7 @@ func NewSystem(data *dataSys.System, ai *ai.System) *System {
 		data:      data,
 		ai:        ai,
 		processed: make(map[ecs.EntityID]bool),
+		states:    make(map[ecs.EntityID]events.AIStateChangedEvent),
 	}
 }
 
@@ -44,16 +54,15 @@ func NewSystem(data *dataSys.System, ai *ai.System) *System {
  | ECS UPDATE LOOP                               |
  *───────────────────────────────────────────────*/
 
-// Update scans the ECS world for new entities with Actor.AIRefs and
-// attaches AIController components generated from the AI catalog.
+// Update attaches AIController components generated from the AI catalog to
+// actors queued since the previous frame.
 func (s *System) Update(w *ecs.World) {
 	if w == nil || s.data == nil || s.ai == nil {
 		return
 	}
 
-	manager := w.EntitiesManager()
-	if manager == nil {
-		return
+	if s.world != w {
+		s.bind(w)
 	}
 
 	s.mu.Lock()
@@ -64,37 +73,93 @@ func (s *System) Update(w *ecs.World) {
 		fmt.Println("[AICOMPOSER] Data reload detected — resetting AI bindings")
 		s.processed = make(map[ecs.EntityID]bool)
 		s.reloadFlag = false
+		s.pending = s.pending[:0]
+		ecs.NewQuery[*ecs.Actor](w).Each(func(e *ecs.Entity, _ *ecs.Actor) {
+			s.pending = append(s.pending, e)
+		})
 	}
 
-	manager.ForEach(func(e *ecs.Entity) {
-		id := e.ID
-		if s.processed[id] {
-			return
-		}
-
-		actor, _ := e.Get("Actor").(*ecs.Actor)
-		if actor == nil || len(actor.AIRefs) == 0 {
-			s.processed[id] = true
-			return
-		}
+	pending := s.pending
+	s.pending = nil
+	for _, e := range pending {
+		s.compose(e)
+	}
+}
 
-		// Skip if already has a controller
-		if _, ok := e.Get("AIController").(*ecs.AIController); ok {
-			s.processed[id] = true
-			return
-		}
+// compose builds and attaches a controller for a single queued entity.
+func (s *System) compose(e *ecs.Entity) {
+	if !e.Alive() {
+		return
+	}
+	id := e.ID
+	if s.processed[id] {
+		return
+	}
 
-		// Build controller
-		ctrl := s.ai.BuildControllerFromRefs(actor.AIRefs)
-		if ctrl == nil {
-			return
-		}
+	actor, _ := e.Get("Actor").(*ecs.Actor)
+	if actor == nil || len(actor.AIRefs) == 0 {
+		s.processed[id] = true
+		return
+	}
 
-		e.AddNamed("AIController", ctrl)
+	// Skip if already has a controller
+	if _, ok := e.Get("AIController").(*ecs.AIController); ok {
 		s.processed[id] = true
-		fmt.Printf("[AICOMPOSER] Bound %d AI actions to %q (entity %d)\n",
-			len(ctrl.Actions), actor.ID, e.ID)
-	})
+		return
+	}
+
+	// Build controller
+	ctrl := s.ai.BuildControllerFromRefs(actor.AIRefs)
+	if ctrl == nil {
+		return
+	}
+
+	e.AddNamed("AIController", ctrl)
+	s.processed[id] = true
+	fmt.Printf("[AICOMPOSER] Bound %d AI actions to %q (entity %d)\n",
+		len(ctrl.Actions), actor.ID, e.ID)
+}
+
+// bind subscribes to the lifecycle notifications that can require a
+// (re)composition: new actors, dropped controllers, and destroyed entities.
+func (s *System) bind(w *ecs.World) {
+	for _, unsubscribe := range s.unbind {
+		unsubscribe()
+	}
+	s.world = w
+	s.pending = nil
+	s.unbind = []func(){
+		w.OnComponentAdded("Actor", func(e *ecs.Entity, _ ecs.Component) {
+			s.pending = append(s.pending, e)
+		}),
+		w.OnComponentRemoved("AIController", func(e *ecs.Entity, _ ecs.Component) {
+			s.mu.Lock()
+			delete(s.processed, e.ID)
+			s.mu.Unlock()
+			if e.Alive() {
+				s.pending = append(s.pending, e)
+			}
+		}),
+		w.OnEntityDestroyed(func(e *ecs.Entity) {
+			s.mu.Lock()
+			delete(s.processed, e.ID)
+			delete(s.states, e.ID)
+			s.mu.Unlock()
+		}),
+	}
+
+	// Bus subscriptions cannot be dropped; subscribe once per bus.
+	if bus, ok := w.EventBus.(*events.TypedBus); ok && bus != nil && bus != s.bus {
+		s.bus = bus
+		events.Subscribe(bus, s.onStateChanged)
+	}
+}
+
+// onStateChanged records state machine transitions for the debug window.
+func (s *System) onStateChanged(e events.AIStateChangedEvent) {
+	s.mu.Lock()
+	s.states[ecs.EntityID(e.EntityID)] = e
+	s.mu.Unlock()
 }
 
 /*───────────────────────────────────────────────*
@@ -123,4 +188,3 @@ func (s *System) OnDataReload(e events.DataReloaded) {
  *───────────────────────────────────────────────*/
 
 func (s *System) Draw(_ *ecs.World, _ *ecs.World) {}
-
@@ -4,6 +4,7 @@ import (
 	"image/color"
 	"math"
 	"math/rand"
+	"time"
 
 	"rp-go/engine/ecs"
 	"rp-go/engine/platform"
@@ -18,6 +19,7 @@ type star struct {
 // System draws a parallax starfield in the background layer.
 type System struct {
 	stars []star
+	seed  int64
 }
 
 // This system renders in the background layer.
@@ -25,6 +27,24 @@ func (s *System) Layer() ecs.DrawLayer { return ecs.LayerBackground }
 
 func (s *System) Update(*ecs.World) {}
 
+// Access is empty: the starfield only reads the world while drawing.
+func (s *System) Access() ecs.Access { return ecs.Access{} }
+
+// SetSeed fixes the star placement seed; the field is regenerated on the
+// next Draw.
+func (s *System) SetSeed(seed int64) {
+	s.seed = seed
+	s.stars = nil
+}
+
+// Seed returns the star placement seed, choosing one if none was set.
+func (s *System) Seed() int64 {
+	if s.seed == 0 {
+		s.seed = time.Now().UnixNano()
+	}
+	return s.seed
+}
+
 // Lazy initialize stars once
 func (s *System) ensureStars(screen *platform.Image) {
 	if len(s.stars) > 0 {
@@ -34,13 +54,14 @@ func (s *System) ensureStars(screen *platform.Image) {
 	width, height := bounds.Dx(), bounds.Dy()
 
 	numStars := (width * height) / 2000 // density factor
+	rng := rand.New(rand.NewSource(s.Seed()))
 
 	s.stars = make([]star, numStars)
 	for i := range s.stars {
 		s.stars[i] = star{
-			X:          rand.Float64() * float64(width),
-			Y:          rand.Float64() * float64(height),
-			Brightness: uint8(155 + rand.Intn(100)), // 155–255
+			X:          rng.Float64() * float64(width),
+			Y:          rng.Float64() * float64(height),
+			Brightness: uint8(155 + rng.Intn(100)), // 155–255
 		}
 	}
 }
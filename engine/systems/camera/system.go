@@ -35,6 +35,9 @@ func (c Config) normalized() Config {
 	return c
 }
 
+// followLerp is the fraction of the gap to the target closed per reference tick.
+const followLerp = 0.1
+
 type System struct {
 	cfg        Config
 	subscribed bool
@@ -44,6 +47,14 @@ func NewSystem(cfg Config) *System {
 	return &System{cfg: cfg.normalized()}
 }
 
+// Access declares the components the camera touches for the scheduler.
+func (s *System) Access() ecs.Access {
+	return ecs.Access{
+		Reads:  []string{"Position", "CameraTarget"},
+		Writes: []string{"Camera"},
+	}
+}
+
 func (s *System) Update(w *ecs.World) {
 	if w == nil {
 		return
@@ -66,6 +77,16 @@ func (s *System) Update(w *ecs.World) {
 		})
 	}
 
+	// Drop stale explicit targets; otherwise they take precedence over
+	// the first CameraTarget-tagged entity.
+	if cam != nil && cam.Target != nil {
+		if !cam.Target.Alive() {
+			cam.Target = nil
+		} else if pos, ok := cam.Target.Get("Position").(*ecs.Position); ok {
+			target = pos
+		}
+	}
+
 	if cam == nil || target == nil {
 		return
 	}
@@ -125,15 +146,18 @@ func (s *System) Update(w *ecs.World) {
 		snapToTarget = true
 	}
 
-	// Smooth follow
-	cam.X += (target.X - cam.X) * 0.1
-	cam.Y += (target.Y - cam.Y) * 0.1
+	// Smooth follow, scaled by simulated time so it converges at the same
+	// rate regardless of frame rate.
+	steps := w.Clock().StepScale()
+	follow := stepLerp(followLerp, steps)
+	cam.X = SmoothApproach(cam.X, target.X, follow)
+	cam.Y = SmoothApproach(cam.Y, target.Y, follow)
 
 	// Smooth zoom unless we explicitly snapped to the new target this frame.
 	if snapToTarget || s.cfg.ZoomLerp <= 0 {
 		cam.Scale = cam.TargetScale
 	} else {
-		cam.Scale += (cam.TargetScale - cam.Scale) * math.Min(1, s.cfg.ZoomLerp)
+		cam.Scale += (cam.TargetScale - cam.Scale) * stepLerp(math.Min(1, s.cfg.ZoomLerp), steps)
 		if math.Abs(cam.Scale-cam.TargetScale) < 1e-4 {
 			cam.Scale = cam.TargetScale
 		}
@@ -142,6 +166,15 @@ func (s *System) Update(w *ecs.World) {
 	cam.Rotation = 0
 }
 
+// stepLerp converts a per-reference-tick lerp factor into the factor for
+// the given number of reference ticks.
+func stepLerp(perTick, steps float64) float64 {
+	if steps <= 0 {
+		return 0
+	}
+	return 1 - math.Pow(1-perTick, steps)
+}
+
 func clamp(v, min, max float64) float64 {
 	if v < min {
 		return min
@@ -0,0 +1,238 @@
+package collision
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/events"
+	"rp-go/engine/systems/spatial"
+)
+
+func place(c *ecs.Collider, x, y float64) *body {
+	b := &body{c: c, pos: &ecs.Position{X: x, Y: y}}
+	b.place()
+	return b
+}
+
+func box(hw, hh float64) *ecs.Collider {
+	return &ecs.Collider{Shape: ecs.ShapeBox, HalfW: hw, HalfH: hh}
+}
+
+func circle(r float64) *ecs.Collider {
+	return &ecs.Collider{Shape: ecs.ShapeCircle, Radius: r}
+}
+
+func TestOverlapShapes(t *testing.T) {
+	triangle := &ecs.Collider{Shape: ecs.ShapePolygon, Points: []ecs.Vec2{{X: 0, Y: -10}, {X: 10, Y: 10}, {X: -10, Y: 10}}}
+	cases := []struct {
+		name   string
+		a, b   *body
+		hit    bool
+		nx, ny float64
+		depth  float64
+	}{
+		{"circles", place(circle(10), 0, 0), place(circle(10), 15, 0), true, 1, 0, 5},
+		{"circles touching", place(circle(10), 0, 0), place(circle(10), 20, 0), false, 0, 0, 0},
+		{"boxes", place(box(10, 10), 0, 0), place(box(10, 10), 0, -16), true, 0, -1, 4},
+		{"box and circle side", place(box(10, 10), 0, 0), place(circle(5), 13, 0), true, 1, 0, 2},
+		// The circle lies diagonally off the corner, out of reach.
+		{"box and circle past corner", place(box(10, 10), 0, 0), place(circle(5), 14, 14), false, 0, 0, 0},
+		{"circle and box", place(circle(5), -13, 0), place(box(10, 10), 0, 0), true, 1, 0, 2},
+		{"triangle and box", place(triangle, 0, 0), place(box(5, 5), 0, 13), true, 0, 1, 2},
+		{"triangle clear of box corner", place(triangle, 0, 0), place(box(3, 3), 9, -6), false, 0, 0, 0},
+	}
+	for _, tc := range cases {
+		nx, ny, depth, ok := overlap(tc.a, tc.b)
+		if ok != tc.hit {
+			t.Errorf("%s: overlap = %v, want %v", tc.name, ok, tc.hit)
+			continue
+		}
+		if ok && (math.Abs(nx-tc.nx) > 1e-9 || math.Abs(ny-tc.ny) > 1e-9 || math.Abs(depth-tc.depth) > 1e-9) {
+			t.Errorf("%s: got normal (%.2f,%.2f) depth %.2f, want (%.2f,%.2f) %.2f", tc.name, nx, ny, depth, tc.nx, tc.ny, tc.depth)
+		}
+	}
+}
+
+// harness runs the collision system once per fixed step and records the
+// events it queues.
+type harness struct {
+	w   *ecs.World
+	bus *events.TypedBus
+	log []string
+}
+
+func newHarness(sys *System) *harness {
+	h := &harness{w: ecs.NewWorld(), bus: events.NewBus()}
+	h.w.EventBus = h.bus
+	h.w.AddSystem(sys)
+	events.Subscribe(h.bus, func(e events.CollisionEvent) { h.log = append(h.log, fmt.Sprintf("hit %d-%d", e.A, e.B)) })
+	events.Subscribe(h.bus, func(e events.CollisionEndedEvent) { h.log = append(h.log, fmt.Sprintf("end %d-%d", e.A, e.B)) })
+	events.Subscribe(h.bus, func(e events.TriggerEnteredEvent) {
+		h.log = append(h.log, fmt.Sprintf("enter %d<-%d", e.Trigger, e.Other))
+	})
+	events.Subscribe(h.bus, func(e events.TriggerExitedEvent) {
+		h.log = append(h.log, fmt.Sprintf("exit %d<-%d", e.Trigger, e.Other))
+	})
+	return h
+}
+
+func (h *harness) step() {
+	h.w.Advance(ecs.DefaultStep)
+	h.bus.Flush()
+}
+
+func (h *harness) spawn(x, y float64, c *ecs.Collider) (*ecs.Entity, *ecs.Position, *ecs.Velocity) {
+	e := h.w.NewEntity()
+	pos, vel := &ecs.Position{X: x, Y: y}, &ecs.Velocity{}
+	e.Add(pos)
+	e.Add(vel)
+	e.Add(c)
+	return e, pos, vel
+}
+
+func TestSystemSlidesAlongStaticCollider(t *testing.T) {
+	h := newHarness(NewSystem(nil))
+	planet, _, _ := h.spawn(0, 0, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 50, Static: true})
+	ship, pos, vel := h.spawn(-60, 0, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 15, Response: ecs.ResponseSlide})
+
+	// Fly into the planet at an angle: the push-out keeps the ship on the
+	// surface and the slide keeps only the tangential speed.
+	vel.VX, vel.VY = 4, 3
+	pos.X += 8
+	h.step()
+	if d := math.Hypot(pos.X, pos.Y); math.Abs(d-65) > 1e-9 {
+		t.Fatalf("expected the ship pushed to the surface, got distance %.3f", d)
+	}
+	nx, ny := pos.X/65, pos.Y/65
+	if into := vel.VX*nx + vel.VY*ny; math.Abs(into) > 1e-9 {
+		t.Fatalf("expected no speed into the planet, got %.3f", into)
+	}
+
+	pos.X, pos.Y = -200, 0
+	h.step()
+	want := []string{
+		fmt.Sprintf("hit %d-%d", planet.ID, ship.ID),
+		fmt.Sprintf("end %d-%d", planet.ID, ship.ID),
+	}
+	if fmt.Sprint(h.log) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, h.log)
+	}
+}
+
+func TestSystemResponses(t *testing.T) {
+	h := newHarness(NewSystem(nil))
+	h.spawn(0, 0, &ecs.Collider{Shape: ecs.ShapeBox, HalfW: 10, HalfH: 100, Static: true})
+	_, stopPos, stopVel := h.spawn(18, 0, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 10, Response: ecs.ResponseStop})
+	_, bouncePos, bounceVel := h.spawn(-18, 50, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 10, Response: ecs.ResponseBounce, Bounce: 0.5})
+	_, _, noneVel := h.spawn(0, -60, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 10})
+	stopVel.VX, bounceVel.VX, noneVel.VX = -2, 4, 3
+	h.step()
+
+	if stopPos.X != 20 || stopVel.VX != 0 {
+		t.Fatalf("expected stop at the wall with no speed, got x=%.1f vx=%.1f", stopPos.X, stopVel.VX)
+	}
+	if bouncePos.X != -20 || bounceVel.VX != -2 {
+		t.Fatalf("expected bounce off the wall at half speed, got x=%.1f vx=%.1f", bouncePos.X, bounceVel.VX)
+	}
+	if noneVel.VX != 3 {
+		t.Fatalf("expected ResponseNone to be left alone, got vx=%.1f", noneVel.VX)
+	}
+}
+
+func TestSystemSharesPushBetweenMovers(t *testing.T) {
+	h := newHarness(NewSystem(nil))
+	_, a, _ := h.spawn(0, 0, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 10, Response: ecs.ResponseSlide})
+	_, b, _ := h.spawn(16, 0, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 10, Response: ecs.ResponseSlide})
+	h.step()
+	if a.X != -2 || b.X != 18 {
+		t.Fatalf("expected each mover pushed half the overlap, got %.1f and %.1f", a.X, b.X)
+	}
+}
+
+func TestSystemTriggersAndLayers(t *testing.T) {
+	h := newHarness(NewSystem(nil))
+	zone, _, _ := h.spawn(0, 0, &ecs.Collider{Shape: ecs.ShapeBox, HalfW: 50, HalfH: 50, Trigger: true})
+	ship, pos, _ := h.spawn(100, 0, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 10, Response: ecs.ResponseSlide})
+	// On layer 2 and colliding only with layer 2: invisible to both.
+	h.spawn(0, 0, &ecs.Collider{Shape: ecs.ShapeCircle, Radius: 10, Layer: 2, Mask: 2, Response: ecs.ResponseSlide})
+
+	h.step()
+	pos.X = 30
+	h.step()
+	h.step()
+	if pos.X != 30 {
+		t.Fatalf("expected the trigger not to push, got x=%.1f", pos.X)
+	}
+	pos.X = 100
+	h.step()
+
+	want := []string{
+		fmt.Sprintf("enter %d<-%d", zone.ID, ship.ID),
+		fmt.Sprintf("exit %d<-%d", zone.ID, ship.ID),
+	}
+	if fmt.Sprint(h.log) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, h.log)
+	}
+}
+
+func TestBroadPhaseMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	w := ecs.NewWorld()
+	index := spatial.NewIndex(64)
+	for i := 0; i < 300; i++ {
+		e := w.NewEntity()
+		pos := &ecs.Position{X: rng.Float64() * 1500, Y: rng.Float64() * 1500}
+		e.Add(pos)
+		c := circle(5 + rng.Float64()*30)
+		switch i % 3 {
+		case 1:
+			c = box(5+rng.Float64()*30, 5+rng.Float64()*30)
+		case 2:
+			c.Layer, c.Mask = 2, 2
+		}
+		if i%100 == 0 {
+			c = box(600+rng.Float64()*200, 20) // wider than LargeExtent
+		}
+		e.Add(c)
+		index.Move(e, pos.X, pos.Y)
+	}
+
+	pairs := func(ix *spatial.Index) string {
+		s := NewSystem(ix)
+		s.byID = make(map[ecs.EntityID]int)
+		s.gather(w)
+		s.broadPhase()
+		return fmt.Sprint(s.pairs)
+	}
+	indexed, brute := pairs(index), pairs(nil)
+	if indexed != brute {
+		t.Fatalf("indexed broad phase found\n%s\nbrute force found\n%s", indexed, brute)
+	}
+}
+
+func BenchmarkSystem(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			w := ecs.NewWorld()
+			sys := spatial.NewSystem(spatial.DefaultCellSize)
+			rng := rand.New(rand.NewSource(1))
+			side := math.Sqrt(float64(n)) * 64
+			for i := 0; i < n; i++ {
+				e := w.NewEntity()
+				e.Add(&ecs.Position{X: rng.Float64() * side, Y: rng.Float64() * side})
+				e.Add(&ecs.Velocity{})
+				e.Add(&ecs.Collider{Shape: ecs.ShapeCircle, Radius: 16, Response: ecs.ResponseSlide})
+			}
+			sys.Update(w)
+			w.AddSystem(NewSystem(sys.Index()))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				w.Advance(time.Second / ecs.ReferenceRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package collision
+
+import (
+	"math"
+
+	"rp-go/engine/ecs"
+)
+
+/*───────────────────────────────────────────────*
+ | NARROW PHASE                                  |
+ *───────────────────────────────────────────────*/
+
+// body is a collider placed in the world for the current pass.
+type body struct {
+	e   *ecs.Entity
+	c   *ecs.Collider
+	pos *ecs.Position
+	vel *ecs.Velocity // nil for entities that do not move
+
+	cx, cy                 float64    // shape center
+	verts                  []ecs.Vec2 // world vertices of boxes and polygons
+	minX, minY, maxX, maxY float64
+	extent                 float64 // Collider.Extent
+}
+
+// place recomputes the body's world geometry from its Position.
+func (b *body) place() {
+	b.cx, b.cy = b.pos.X+b.c.Offset.X, b.pos.Y+b.c.Offset.Y
+	b.minX, b.minY, b.maxX, b.maxY = b.c.Bounds(b.pos.X, b.pos.Y)
+	b.verts = b.verts[:0]
+	if !b.circle() {
+		b.verts = b.c.Outline(b.pos.X, b.pos.Y, b.verts)
+	}
+}
+
+func (b *body) circle() bool { return b.c.Shape == ecs.ShapeCircle }
+
+func (b *body) large() bool { return b.extent > LargeExtent }
+
+func (b *body) boxesOverlap(o *body) bool {
+	return b.minX < o.maxX && o.minX < b.maxX && b.minY < o.maxY && o.minY < b.maxY
+}
+
+// overlap tests a against b with the separating axis theorem. It returns
+// the unit normal pointing from a to b and the penetration depth; shapes
+// that merely touch do not overlap.
+func overlap(a, b *body) (nx, ny, depth float64, ok bool) {
+	switch {
+	case a.circle() && b.circle():
+		return circles(a, b)
+	case a.circle():
+		nx, ny, depth, ok = polygonCircle(b, a)
+		return -nx, -ny, depth, ok
+	case b.circle():
+		return polygonCircle(a, b)
+	}
+	return polygons(a, b)
+}
+
+func circles(a, b *body) (nx, ny, depth float64, ok bool) {
+	dx, dy := b.cx-a.cx, b.cy-a.cy
+	dist := math.Hypot(dx, dy)
+	depth = a.c.Radius + b.c.Radius - dist
+	if depth <= 0 {
+		return 0, 0, 0, false
+	}
+	if dist == 0 {
+		return 1, 0, depth, true // concentric: any direction separates them
+	}
+	return dx / dist, dy / dist, depth, true
+}
+
+// axisTest keeps the axis of least overlap seen so far; try reports false
+// as soon as an axis separates the shapes.
+type axisTest struct {
+	nx, ny, depth float64
+}
+
+func (t *axisTest) try(ax, ay, minA, maxA, minB, maxB float64) bool {
+	d := math.Min(maxA, maxB) - math.Max(minA, minB)
+	if d <= 0 {
+		return false
+	}
+	if d < t.depth {
+		t.nx, t.ny, t.depth = ax, ay, d
+	}
+	return true
+}
+
+// oriented returns the best axis flipped to point from a's center to b's.
+func (t *axisTest) oriented(ax, ay, bx, by float64) (float64, float64, float64, bool) {
+	if (bx-ax)*t.nx+(by-ay)*t.ny < 0 {
+		return -t.nx, -t.ny, t.depth, true
+	}
+	return t.nx, t.ny, t.depth, true
+}
+
+func polygons(a, b *body) (nx, ny, depth float64, ok bool) {
+	t := axisTest{depth: math.Inf(1)}
+	for _, poly := range [][]ecs.Vec2{a.verts, b.verts} {
+		for i := range poly {
+			ax, ay, unit := edgeNormal(poly, i)
+			if !unit {
+				continue
+			}
+			minA, maxA := project(a.verts, ax, ay)
+			minB, maxB := project(b.verts, ax, ay)
+			if !t.try(ax, ay, minA, maxA, minB, maxB) {
+				return 0, 0, 0, false
+			}
+		}
+	}
+	if math.IsInf(t.depth, 1) {
+		return 0, 0, 0, false
+	}
+	ax, ay := centroid(a.verts)
+	bx, by := centroid(b.verts)
+	return t.oriented(ax, ay, bx, by)
+}
+
+func polygonCircle(p, c *body) (nx, ny, depth float64, ok bool) {
+	t := axisTest{depth: math.Inf(1)}
+	r := c.c.Radius
+	test := func(ax, ay float64) bool {
+		minP, maxP := project(p.verts, ax, ay)
+		center := c.cx*ax + c.cy*ay
+		return t.try(ax, ay, minP, maxP, center-r, center+r)
+	}
+	for i := range p.verts {
+		if ax, ay, unit := edgeNormal(p.verts, i); unit && !test(ax, ay) {
+			return 0, 0, 0, false
+		}
+	}
+	// The axis through the vertex closest to the center catches circles
+	// past a corner.
+	closest, best := -1, math.Inf(1)
+	for i, v := range p.verts {
+		if d := math.Hypot(v.X-c.cx, v.Y-c.cy); d < best {
+			closest, best = i, d
+		}
+	}
+	if closest >= 0 && best > 0 {
+		v := p.verts[closest]
+		if !test((c.cx-v.X)/best, (c.cy-v.Y)/best) {
+			return 0, 0, 0, false
+		}
+	}
+	if math.IsInf(t.depth, 1) {
+		return 0, 0, 0, false
+	}
+	px, py := centroid(p.verts)
+	return t.oriented(px, py, c.cx, c.cy)
+}
+
+// edgeNormal returns the unit normal of the edge leaving vertex i.
+func edgeNormal(poly []ecs.Vec2, i int) (float64, float64, bool) {
+	a, b := poly[i], poly[(i+1)%len(poly)]
+	ex, ey := b.X-a.X, b.Y-a.Y
+	l := math.Hypot(ex, ey)
+	if l == 0 {
+		return 0, 0, false
+	}
+	return -ey / l, ex / l, true
+}
+
+func project(poly []ecs.Vec2, ax, ay float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range poly {
+		d := v.X*ax + v.Y*ay
+		lo, hi = math.Min(lo, d), math.Max(hi, d)
+	}
+	return lo, hi
+}
+
+func centroid(poly []ecs.Vec2) (x, y float64) {
+	for _, v := range poly {
+		x += v.X
+		y += v.Y
+	}
+	n := float64(len(poly))
+	return x / n, y / n
+}
@@ -0,0 +1,280 @@
+package collision
+
+import (
+	"sort"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/events"
+	"rp-go/engine/systems/spatial"
+)
+
+/*───────────────────────────────────────────────*
+ | COLLISION SYSTEM                              |
+ *───────────────────────────────────────────────*/
+
+// System finds overlapping colliders after movement, pushes solid ones
+// apart according to their Response and queues contact and trigger events
+// on the world's TypedBus when overlaps begin and end.
+//
+// The broad phase asks the spatial index for entities near each collider;
+// colliders wider than LargeExtent are instead tested against every
+// collider, so one huge shape does not widen every query.
+type System struct {
+	Index *spatial.Index // broad phase; without it every pair is tested
+
+	bodies   []body
+	byID     map[ecs.EntityID]int
+	large    []int
+	pairs    []pair
+	contacts map[pair]contact // overlaps found last pass
+	current  map[pair]contact
+	buf      []*ecs.Entity
+}
+
+// LargeExtent is the collider extent past which a collider skips the
+// spatial index.
+const LargeExtent = 512
+
+// pair is two entities in ID order.
+type pair struct{ a, b ecs.EntityID }
+
+type contact struct {
+	solid   bool
+	trigger ecs.EntityID // trigger side of a trigger overlap
+	other   ecs.EntityID
+
+	nx, ny, depth float64 // solid contacts, as first found
+}
+
+// NewSystem returns a collision system using index for its broad phase
+// (nil to test every pair).
+func NewSystem(index *spatial.Index) *System {
+	return &System{Index: index}
+}
+
+// Access declares the components collision touches for the scheduler.
+func (s *System) Access() ecs.Access {
+	return ecs.Access{
+		Reads:  []string{"Collider"},
+		Writes: []string{"Position", "Velocity", "SpatialIndex"},
+	}
+}
+
+func (s *System) Update(w *ecs.World) {
+	if w == nil {
+		return
+	}
+	if w.Clock().StepScale() == 0 {
+		return // idle pass or paused
+	}
+	if s.byID == nil {
+		s.byID = make(map[ecs.EntityID]int)
+		s.contacts = make(map[pair]contact)
+		s.current = make(map[pair]contact)
+	}
+
+	s.gather(w)
+	s.broadPhase()
+
+	clear(s.current)
+	for _, p := range s.pairs {
+		a, b := &s.bodies[s.byID[p.a]], &s.bodies[s.byID[p.b]]
+		if a.c.Trigger && b.c.Trigger {
+			continue
+		}
+		nx, ny, depth, ok := overlap(a, b)
+		if !ok {
+			continue
+		}
+		switch {
+		case a.c.Trigger:
+			s.current[p] = contact{trigger: a.e.ID, other: b.e.ID}
+		case b.c.Trigger:
+			s.current[p] = contact{trigger: b.e.ID, other: a.e.ID}
+		default:
+			s.current[p] = contact{solid: true, nx: nx, ny: ny, depth: depth}
+			s.respond(a, b, nx, ny, depth)
+		}
+	}
+	if bus, ok := w.EventBus.(*events.TypedBus); ok {
+		s.publish(bus)
+	}
+	s.contacts, s.current = s.current, s.contacts
+}
+
+// gather places every collider on an entity with a Position.
+func (s *System) gather(w *ecs.World) {
+	s.bodies = s.bodies[:0]
+	ecs.NewQuery2[*ecs.Position, *ecs.Collider](w).Each(func(e *ecs.Entity, pos *ecs.Position, c *ecs.Collider) {
+		vel, _ := e.Get("Velocity").(*ecs.Velocity)
+		s.bodies = append(s.bodies, body{e: e, c: c, pos: pos, vel: vel})
+	})
+	sort.Slice(s.bodies, func(i, j int) bool { return s.bodies[i].e.ID < s.bodies[j].e.ID })
+
+	clear(s.byID)
+	s.large = s.large[:0]
+	for i := range s.bodies {
+		b := &s.bodies[i]
+		b.place()
+		b.extent = b.c.Extent()
+		s.byID[b.e.ID] = i
+		if s.Index != nil && b.large() {
+			s.large = append(s.large, i)
+		}
+	}
+}
+
+// broadPhase lists, in ID order, the pairs whose bounding boxes overlap
+// and whose layers interact.
+func (s *System) broadPhase() {
+	s.pairs = s.pairs[:0]
+	consider := func(a, b *body) {
+		if a.e.ID > b.e.ID {
+			a, b = b, a
+		}
+		if a.boxesOverlap(b) && a.c.Interacts(b.c) {
+			s.pairs = append(s.pairs, pair{a.e.ID, b.e.ID})
+		}
+	}
+
+	if s.Index == nil {
+		for i := range s.bodies {
+			for j := i + 1; j < len(s.bodies); j++ {
+				consider(&s.bodies[i], &s.bodies[j])
+			}
+		}
+		return
+	}
+
+	// Every small collider's position lies within reach of its box.
+	var reach float64
+	for i := range s.bodies {
+		if b := &s.bodies[i]; !b.large() && b.extent > reach {
+			reach = b.extent
+		}
+	}
+	for i := range s.bodies {
+		a := &s.bodies[i]
+		if a.large() {
+			continue
+		}
+		s.buf = s.Index.Rect(a.minX-reach, a.minY-reach, a.maxX+reach, a.maxY+reach, s.buf[:0])
+		for _, o := range s.buf {
+			j, ok := s.byID[o.ID]
+			if !ok || o.ID <= a.e.ID || s.bodies[j].large() {
+				continue
+			}
+			consider(a, &s.bodies[j])
+		}
+	}
+	for _, i := range s.large {
+		for j := range s.bodies {
+			if j == i || (s.bodies[j].large() && j < i) {
+				continue // large pairs are considered from their first body
+			}
+			consider(&s.bodies[i], &s.bodies[j])
+		}
+	}
+	sort.Slice(s.pairs, func(i, j int) bool {
+		if s.pairs[i].a != s.pairs[j].a {
+			return s.pairs[i].a < s.pairs[j].a
+		}
+		return s.pairs[i].b < s.pairs[j].b
+	})
+}
+
+// publish queues an event for every overlap that began or ended this pass,
+// in pair order.
+func (s *System) publish(bus *events.TypedBus) {
+	var changed []pair
+	for p := range s.current {
+		if _, ok := s.contacts[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	for p := range s.contacts {
+		if _, ok := s.current[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		if changed[i].a != changed[j].a {
+			return changed[i].a < changed[j].a
+		}
+		return changed[i].b < changed[j].b
+	})
+	for _, p := range changed {
+		if c, began := s.current[p]; began {
+			if c.solid {
+				events.Queue(bus, events.CollisionEvent{
+					A: int(p.a), B: int(p.b),
+					NormalX: c.nx, NormalY: c.ny,
+					Depth: c.depth,
+				})
+			} else {
+				events.Queue(bus, events.TriggerEnteredEvent{Trigger: int(c.trigger), Other: int(c.other)})
+			}
+			continue
+		}
+		if c := s.contacts[p]; c.solid {
+			events.Queue(bus, events.CollisionEndedEvent{A: int(p.a), B: int(p.b)})
+		} else {
+			events.Queue(bus, events.TriggerExitedEvent{Trigger: int(c.trigger), Other: int(c.other)})
+		}
+	}
+}
+
+/*───────────────────────────────────────────────*
+ | RESPONSE                                      |
+ *───────────────────────────────────────────────*/
+
+// movable reports whether the body is pushed out of solid overlaps.
+func (b *body) movable() bool {
+	return !b.c.Static && !b.c.Trigger && b.c.Response != ecs.ResponseNone
+}
+
+// respond separates a and b along the normal (a → b). Two movable bodies
+// share the push; a movable body hitting a fixed one takes all of it.
+func (s *System) respond(a, b *body, nx, ny, depth float64) {
+	ma, mb := a.movable(), b.movable()
+	share := 1.0
+	if ma && mb {
+		share = 0.5
+	}
+	if ma {
+		s.push(a, -nx, -ny, depth*share)
+	}
+	if mb {
+		s.push(b, nx, ny, depth*share)
+	}
+}
+
+// push moves b by dist along the unit vector (nx, ny), pointing away from
+// what it hit, and adjusts its velocity for its Response.
+func (s *System) push(b *body, nx, ny, dist float64) {
+	b.pos.X += nx * dist
+	b.pos.Y += ny * dist
+	b.place()
+	if s.Index != nil {
+		s.Index.Move(b.e, b.pos.X, b.pos.Y)
+	}
+	if b.vel == nil {
+		return
+	}
+	into := b.vel.VX*nx + b.vel.VY*ny // negative while moving into the obstacle
+	switch b.c.Response {
+	case ecs.ResponseStop:
+		b.vel.VX, b.vel.VY = 0, 0
+	case ecs.ResponseSlide:
+		if into < 0 {
+			b.vel.VX -= into * nx
+			b.vel.VY -= into * ny
+		}
+	case ecs.ResponseBounce:
+		if into < 0 {
+			k := 1 + b.c.Bounce
+			b.vel.VX -= k * into * nx
+			b.vel.VY -= k * into * ny
+		}
+	}
+}
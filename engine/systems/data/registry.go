@@ -0,0 +1,335 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"rp-go/engine/data"
+)
+
+/*───────────────────────────────────────────────*
+| DATA KINDS                                    |
+*───────────────────────────────────────────────*/
+
+// Kind describes one family of data files: where they live, how to decode
+// them into T and who to tell when they change.
+type Kind[T any] struct {
+	Name     string                               // logical type, e.g. "actor_db"; used as DataReloaded.Type
+	Patterns []string                             // filepath globs; every match is decoded together, in order
+	Decode   func(files []data.Source) (T, error) // builds the value from all matching files
+	OnReload func(value T)                        // optional; runs after each successful load
+
+	// Sources, when set, replaces globbing Patterns for reading (e.g. to add
+	// mod layers from archives). Patterns still decide what is watched.
+	Sources func() ([]data.Source, error)
+}
+
+// Handle gives typed access to a registered kind's current value.
+type Handle[T any] struct {
+	kind Kind[T]
+
+	mu    sync.RWMutex
+	value T
+	files []string
+}
+
+// Get returns the last successfully decoded value.
+func (h *Handle[T]) Get() T {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.value
+}
+
+// Files lists the paths the current value was decoded from.
+func (h *Handle[T]) Files() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return append([]string(nil), h.files...)
+}
+
+func (h *Handle[T]) name() string       { return h.kind.Name }
+func (h *Handle[T]) patterns() []string { return h.kind.Patterns }
+
+// load reads every matching file and swaps in the decoded value. On any
+// error the previous value stays. It reports false when nothing matched.
+func (h *Handle[T]) load() (bool, error) {
+	sources, err := h.read()
+	if err != nil {
+		return true, err
+	}
+	if len(sources) == 0 {
+		return false, nil
+	}
+
+	value, err := h.kind.Decode(sources)
+	if err != nil {
+		return true, err
+	}
+	h.mu.Lock()
+	h.value = value
+	h.files = h.files[:0]
+	for _, src := range sources {
+		h.files = append(h.files, src.Path)
+	}
+	h.mu.Unlock()
+
+	if h.kind.OnReload != nil {
+		h.kind.OnReload(value)
+	}
+	return true, nil
+}
+
+func (h *Handle[T]) read() ([]data.Source, error) {
+	if h.kind.Sources != nil {
+		return h.kind.Sources()
+	}
+	var sources []data.Source
+	for _, path := range matchAll(h.kind.Patterns) {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, data.Source{Path: path, Data: raw})
+	}
+	return sources, nil
+}
+
+// entry is the type-erased view of a Handle the registry works with.
+type entry interface {
+	name() string
+	patterns() []string
+	load() (bool, error)
+}
+
+/*───────────────────────────────────────────────*
+| REGISTRY                                      |
+*───────────────────────────────────────────────*/
+
+// DefaultDebounce is how long a file must stay quiet before it reloads, so
+// an editor's write-rename-chmod burst triggers a single reload.
+const DefaultDebounce = 150 * time.Millisecond
+
+// Registry owns the registered data kinds and the watcher feeding them.
+type Registry struct {
+	Debounce time.Duration
+
+	mu      sync.Mutex
+	entries []entry
+	ready   map[string]bool // debounced paths awaiting Pending
+	watcher Watcher
+	done    chan struct{}
+}
+
+// NewRegistry returns an empty registry; call Start to begin watching.
+func NewRegistry() *Registry {
+	return &Registry{Debounce: DefaultDebounce, ready: make(map[string]bool)}
+}
+
+// Register adds a kind and returns its typed handle. Nothing is read until
+// LoadAll or Reload.
+func Register[T any](r *Registry, k Kind[T]) *Handle[T] {
+	h := &Handle[T]{kind: k}
+	r.mu.Lock()
+	r.entries = append(r.entries, h)
+	watching := r.watcher != nil
+	r.mu.Unlock()
+	if watching {
+		r.watchDirs(h)
+	}
+	return h
+}
+
+// Kinds lists registered kind names in registration order.
+func (r *Registry) Kinds() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, len(r.entries))
+	for i, e := range r.entries {
+		names[i] = e.name()
+	}
+	return names
+}
+
+// LoadAll loads every kind in registration order. Kinds with no matching
+// files are skipped; errors are joined.
+func (r *Registry) LoadAll() error {
+	var errs []error
+	for _, name := range r.Kinds() {
+		if err := r.Reload(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reload reloads one kind by name.
+func (r *Registry) Reload(name string) error {
+	e := r.find(name)
+	if e == nil {
+		return fmt.Errorf("unknown data kind %q", name)
+	}
+	_, err := e.load()
+	return err
+}
+
+// KindsFor returns the kinds whose patterns match path.
+func (r *Registry) KindsFor(path string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var names []string
+	for _, e := range r.entries {
+		if matchesAny(e.patterns(), path) {
+			names = append(names, e.name())
+		}
+	}
+	return names
+}
+
+func (r *Registry) find(name string) entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.entries {
+		if e.name() == name {
+			return e
+		}
+	}
+	return nil
+}
+
+/*───────────────────────────────────────────────*
+| WATCHING                                      |
+*───────────────────────────────────────────────*/
+
+// Start watches the directories of every registered pattern with w (or
+// NewWatcher when nil). New files matching a pattern are picked up too.
+func (r *Registry) Start(w Watcher) {
+	if w == nil {
+		w = NewWatcher()
+	}
+	r.mu.Lock()
+	if r.watcher != nil {
+		r.mu.Unlock()
+		return
+	}
+	r.watcher = w
+	done := make(chan struct{})
+	r.done = done
+	entries := append([]entry(nil), r.entries...)
+	r.mu.Unlock()
+
+	for _, e := range entries {
+		r.watchDirs(e)
+	}
+	go r.debounce(w.Events(), done)
+}
+
+// Stop shuts the watcher down.
+func (r *Registry) Stop() {
+	r.mu.Lock()
+	w, done := r.watcher, r.done
+	r.watcher, r.done = nil, nil
+	r.mu.Unlock()
+	if w != nil {
+		close(done)
+		w.Close()
+	}
+}
+
+// Pending drains the paths that changed and settled since the last call,
+// sorted. Call it from the main loop; reloading stays on that goroutine.
+func (r *Registry) Pending() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.ready) == 0 {
+		return nil
+	}
+	paths := make([]string, 0, len(r.ready))
+	for p := range r.ready {
+		paths = append(paths, p)
+	}
+	r.ready = make(map[string]bool)
+	sort.Strings(paths)
+	return paths
+}
+
+func (r *Registry) watchDirs(e entry) {
+	r.mu.Lock()
+	w := r.watcher
+	r.mu.Unlock()
+	if w == nil {
+		return
+	}
+	for _, pattern := range e.patterns() {
+		dir := filepath.Dir(pattern)
+		if err := w.Add(dir); err != nil {
+			fmt.Printf("[HOTRELOAD] Cannot watch %s: %v\n", dir, err)
+			continue
+		}
+		fmt.Printf("[HOTRELOAD] Watching %s for %s\n", dir, e.name())
+	}
+}
+
+// debounce collects watcher events and releases them once no event has
+// arrived for r.Debounce.
+func (r *Registry) debounce(events <-chan string, done <-chan struct{}) {
+	burst := make(map[string]bool)
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	for {
+		select {
+		case path, ok := <-events:
+			if !ok {
+				return
+			}
+			if len(r.KindsFor(path)) == 0 {
+				continue
+			}
+			burst[path] = true
+			timer.Reset(r.Debounce)
+		case <-timer.C:
+			r.mu.Lock()
+			for p := range burst {
+				r.ready[p] = true
+			}
+			r.mu.Unlock()
+			burst = make(map[string]bool)
+		case <-done:
+			return
+		}
+	}
+}
+
+/*───────────────────────────────────────────────*
+| PATTERN HELPERS                               |
+*───────────────────────────────────────────────*/
+
+// matchAll expands patterns in order, without duplicates.
+func matchAll(patterns []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		for _, m := range matches {
+			if !seen[m] {
+				seen[m] = true
+				out = append(out, m)
+			}
+		}
+	}
+	return out
+}
+
+func matchesAny(patterns []string, path string) bool {
+	clean := filepath.Clean(path)
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(filepath.Clean(pattern), clean); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"rp-go/engine/data"
+)
+
+func TestRegistryPicksUpNewPacksAfterDebounce(t *testing.T) {
+	watchers := map[string]func() Watcher{
+		"poll":   func() Watcher { return NewPollWatcher(10 * time.Millisecond) },
+		"native": func() Watcher { w, _ := newNativeWatcher(); return w },
+	}
+	for name, newWatcher := range watchers {
+		w := newWatcher()
+		if w == nil {
+			continue
+		}
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			write := func(file, body string) {
+				if err := os.WriteFile(filepath.Join(dir, file), []byte(body), 0o644); err != nil {
+					t.Fatalf("write %s: %v", file, err)
+				}
+			}
+			write("actors.json", `{"actors":[{"name":"base"}]}`)
+
+			r := NewRegistry()
+			r.Debounce = 30 * time.Millisecond
+			actors := Register(r, Kind[[]string]{
+				Name:     "actor_db",
+				Patterns: []string{filepath.Join(dir, "actors.json"), filepath.Join(dir, data.ActorPackPattern)},
+				Decode: func(files []data.Source) ([]string, error) {
+					db, err := data.ParseActorPacks(files)
+					var names []string
+					for _, tpl := range db.Actors {
+						names = append(names, tpl.Name)
+					}
+					return names, err
+				},
+			})
+			if err := r.LoadAll(); err != nil {
+				t.Fatalf("initial load: %v", err)
+			}
+			r.Start(w)
+			defer r.Stop()
+
+			// A burst of writes to a brand-new pack settles into one path.
+			for i := 0; i < 3; i++ {
+				write("actors_extra.json", `{"actors":[{"name":"child","extends":"base"}]}`)
+			}
+			write("notes.txt", "ignored")
+
+			var pending []string
+			deadline := time.Now().Add(2 * time.Second)
+			for len(pending) == 0 && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+				pending = r.Pending()
+			}
+			if len(pending) != 1 || !strings.HasSuffix(pending[0], "actors_extra.json") {
+				t.Fatalf("expected one debounced pack path, got %v", pending)
+			}
+			for _, kind := range r.KindsFor(pending[0]) {
+				if err := r.Reload(kind); err != nil {
+					t.Fatalf("reload %s: %v", kind, err)
+				}
+			}
+			if got := strings.Join(actors.Get(), ","); got != "base,child" {
+				t.Fatalf("expected pack merged into actor_db, got %q", got)
+			}
+		})
+	}
+}
@@ -1,15 +1,17 @@
 package data
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sync"
-	"time"
 
 	"rp-go/engine/data"
 	"rp-go/engine/ecs"
 	"rp-go/engine/events"
+	"rp-go/engine/locale"
+	"rp-go/engine/mods"
 )
 
 /*───────────────────────────────────────────────*
@@ -18,62 +20,188 @@ import (
 
 // System manages engine-wide configuration, JSON databases, and live reloads.
 type System struct {
-	Config    data.RenderConfig    // Render config
-	Actors    data.ActorDatabase   // Actor definitions
-	AICatalog data.AIActionCatalog // AI behavior definitions
-
-	reloadMgr  *HotReloadManager
+	Config    data.RenderConfig       // Render config
+	Actors    data.ActorDatabase      // Actor definitions
+	AICatalog data.AIActionCatalog    // AI behavior definitions
+	Terrain   map[string]data.Terrain // Tile maps by name (terrain_<name>.json)
+
+	lint       data.LintOptions // registries files are validated against; see SetLint
+	registry   *Registry
+	mods       *mods.Set
 	subscriber *DataSubscriber
-	lastUpdate time.Time
 	mu         sync.RWMutex
 }
 
-// NewSystem initializes the DataSystem and registers all engine data files.
+// NewSystem discovers mod packs, registers the engine data kinds and loads
+// them. Files are not watched for changes until Watch is called.
 func NewSystem() *System {
+	set, err := mods.Discover(mods.DefaultRoot)
+	if err != nil {
+		fmt.Printf("[MODS] %v; running without mods\n", err)
+	}
+	return NewSystemWithMods(set)
+}
+
+// NewSystemWithMods is NewSystem with an explicit pack set (nil for none).
+func NewSystemWithMods(set *mods.Set) *System {
 	s := &System{
-		reloadMgr:  NewHotReloadManager(),
+		registry:   NewRegistry(),
+		mods:       set,
 		subscriber: NewDataSubscriber(),
 	}
-	s.RegisterDataFile("render_config", "engine/data/render_config.json")
-	s.RegisterDataFile("actor_db", "engine/data/actors.json")
-	s.RegisterDataFile("ai_catalog", "engine/data/ai.json")
+	for _, pack := range set.Active() {
+		fmt.Printf("[MODS] Loaded %s %s (%s)\n", pack.ID, pack.Version, pack.Path)
+	}
+	for _, d := range set.Disabled() {
+		fmt.Printf("[MODS] Skipped %s: %s\n", d.Path, d.Reason)
+	}
+	s.registerKinds()
+	if err := s.registry.LoadAll(); err != nil {
+		fmt.Printf("[DATA] Initial load failed: %v\n", err)
+	}
+	if code := os.Getenv(locale.LocaleEnv); code != "" {
+		if err := locale.Default.SetLocale(code); err != nil {
+			fmt.Printf("[LOCALE] %v; using %s\n", err, locale.Default.Locale())
+		}
+	}
 	return s
 }
 
+// baseDataDir holds the base game's data files.
+const baseDataDir = "engine/data"
+
+// registerKinds declares the engine's own data files (including the
+// per-locale string tables and terrain maps), each layered with the
+// matching files of every active mod pack. The catalog comes before actors
+// so ai_refs are checked against the fresh catalog. Decoders run with s.mu
+// held.
+func (s *System) registerKinds() {
+	layered := func(patterns ...string) ([]string, func() ([]data.Source, error)) {
+		return s.mods.WatchPatterns(baseDataDir, patterns...), func() ([]data.Source, error) {
+			return s.mods.Sources(baseDataDir, patterns...)
+		}
+	}
+
+	patterns, sources := layered("render_config.json")
+	Register(s.registry, Kind[data.RenderConfig]{
+		Name:     "render_config",
+		Patterns: patterns,
+		Sources:  sources,
+		Decode: func(files []data.Source) (data.RenderConfig, error) {
+			cfg, problems := data.ValidateRenderLayers(files)
+			return cfg, problems.Err()
+		},
+		OnReload: func(cfg data.RenderConfig) { s.Config = cfg },
+	})
+	patterns, sources = layered("ai.json")
+	Register(s.registry, Kind[data.AIActionCatalog]{
+		Name:     "ai_catalog",
+		Patterns: patterns,
+		Sources:  sources,
+		Decode: func(files []data.Source) (data.AIActionCatalog, error) {
+			cat, problems := data.ValidateAILayers(files, s.lint)
+			return cat, problems.Err()
+		},
+		OnReload: func(cat data.AIActionCatalog) { s.AICatalog = cat },
+	})
+	patterns, sources = layered("actors.json", data.ActorPackPattern)
+	Register(s.registry, Kind[data.ActorDatabase]{
+		Name:     "actor_db",
+		Patterns: patterns,
+		Sources:  sources,
+		Decode: func(files []data.Source) (data.ActorDatabase, error) {
+			opts := s.lint
+			if len(s.AICatalog.Actions) > 0 {
+				opts.Actions = s.AICatalog.ActionNames()
+			}
+			db, problems := data.ValidateActorPacks(files, opts)
+			return db, problems.Err()
+		},
+		OnReload: func(db data.ActorDatabase) { s.Actors = db },
+	})
+	patterns, sources = layered(data.StringsPattern)
+	Register(s.registry, Kind[map[string]data.StringTable]{
+		Name:     "strings",
+		Patterns: patterns,
+		Sources:  sources,
+		Decode: func(files []data.Source) (map[string]data.StringTable, error) {
+			tables, problems := data.ValidateStringLayers(files)
+			return tables, problems.Err()
+		},
+		OnReload: func(tables map[string]data.StringTable) { locale.Default.SetTables(tables) },
+	})
+	patterns, sources = layered(data.TerrainPattern)
+	Register(s.registry, Kind[map[string]data.Terrain]{
+		Name:     "terrain",
+		Patterns: patterns,
+		Sources:  sources,
+		Decode: func(files []data.Source) (map[string]data.Terrain, error) {
+			maps, problems := data.ValidateTerrainLayers(files)
+			return maps, problems.Err()
+		},
+		OnReload: func(maps map[string]data.Terrain) { s.Terrain = maps },
+	})
+}
+
 /*───────────────────────────────────────────────*
 | LIFECYCLE                                     |
 *───────────────────────────────────────────────*/
 
-func (s *System) Update(world *ecs.World) {
-	if s.reloadMgr == nil {
-		s.reloadMgr = NewHotReloadManager()
-	}
+// SetLint sets the registries data files are validated against (behavior
+// types, component factories) and re-validates what NewSystem loaded
+// before they were known. Kinds that now fail keep their data and are
+// reported like a rejected reload; later reloads are checked as well.
+func (s *System) SetLint(opts data.LintOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	now := time.Now()
-	if now.Sub(s.lastUpdate) < 500*time.Millisecond {
-		return
+	s.lint = opts
+	for _, kind := range s.registry.Kinds() {
+		if err := s.registry.Reload(kind); err != nil {
+			s.reportFailure(nil, kind, baseDataDir, err)
+		}
 	}
-	s.lastUpdate = now
+}
+
+// Watch starts hot reloading: engine/data and directory packs are watched
+// and changed files reload on the next Update. Call Stop to release the
+// watcher.
+func (s *System) Watch() { s.registry.Start(nil) }
 
-	changed := s.reloadMgr.CheckChanges()
-	if len(changed) == 0 {
+// Stop stops watching data files. Loaded data stays in place.
+func (s *System) Stop() { s.registry.Stop() }
+
+// Update reloads every kind whose files settled since the last frame.
+func (s *System) Update(world *ecs.World) {
+	paths := s.registry.Pending()
+	if len(paths) == 0 {
 		s.ensureLoaded()
 		return
 	}
 
-	for _, path := range changed {
-		s.reloadFile(world, path)
+	// Several saved packs of one kind reload it once.
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		for _, kind := range s.registry.KindsFor(path) {
+			if !seen[kind] {
+				seen[kind] = true
+				s.reloadKind(world, kind, path)
+			}
+		}
 	}
 }
 
 // ReloadAll forces full reload of all known data and emits global event.
+// Kinds that fail validation keep their previous data and are reported.
 func (s *System) ReloadAll(world *ecs.World) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.Config = data.LoadRenderConfig("engine/data/render_config.json")
-	s.Actors = data.LoadActorDatabase("engine/data/actors.json")
-	s.AICatalog = data.LoadAICatalog("engine/data/ai.json")
+	for _, kind := range s.registry.Kinds() {
+		if err := s.registry.Reload(kind); err != nil {
+			s.reportFailure(world, kind, "engine/data", err)
+		}
+	}
 
 	fmt.Println("[DATA] Reloaded all configuration, actors, and AI catalog")
 
@@ -88,34 +216,19 @@ func (s *System) ReloadAll(world *ecs.World) {
 | RELOAD LOGIC                                  |
 *───────────────────────────────────────────────*/
 
-func (s *System) reloadFile(world *ecs.World, path string) {
+// reloadKind reloads one kind after path changed. On failure the previous
+// data stays in place.
+func (s *System) reloadKind(world *ecs.World, kind, path string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	base := filepath.Base(path)
-	var evt events.DataReloaded
-
-	switch base {
-	case "render_config.json":
-		s.Config = data.LoadRenderConfig(path)
-		fmt.Println("[DATA] Reloaded render_config")
-		evt = events.DataReloaded{Path: path, Type: "render_config"}
-
-	case "actors.json":
-		s.Actors = data.LoadActorDatabase(path)
-		fmt.Println("[DATA] Reloaded actor_db")
-		evt = events.DataReloaded{Path: path, Type: "actor_db"}
-
-	case "ai.json":
-		s.AICatalog = data.LoadAICatalog(path)
-		fmt.Println("[DATA] Reloaded ai_catalog")
-		evt = events.DataReloaded{Path: path, Type: "ai_catalog"}
-
-	default:
-		fmt.Printf("[DATA] Reloaded generic file: %s\n", path)
-		evt = events.DataReloaded{Path: path, Type: "generic"}
+	if err := s.registry.Reload(kind); err != nil {
+		s.reportFailure(world, kind, path, err)
+		return
 	}
+	fmt.Printf("[DATA] Reloaded %s (%s)\n", kind, path)
 
+	evt := events.DataReloaded{Path: path, Type: kind}
 	if world != nil && world.EventBus != nil {
 		if bus, ok := world.EventBus.(*events.TypedBus); ok {
 			events.Queue(bus, evt)
@@ -124,6 +237,30 @@ func (s *System) reloadFile(world *ecs.World, path string) {
 	go s.subscriber.Notify(evt)
 }
 
+// reportFailure logs a rejected reload and publishes it for the dev console.
+func (s *System) reportFailure(world *ecs.World, kind, path string, err error) {
+	lines := []string{err.Error()}
+	var problems data.Problems
+	if errors.As(err, &problems) {
+		lines = lines[:0]
+		for _, p := range problems {
+			lines = append(lines, p.String())
+		}
+	}
+
+	fmt.Printf("[DATA] Rejected %s (%d problems); keeping previous data\n", path, len(lines))
+	for _, line := range lines {
+		fmt.Printf("[DATA]   %s\n", line)
+	}
+
+	if world == nil {
+		return
+	}
+	if bus, ok := world.EventBus.(*events.TypedBus); ok {
+		events.Queue(bus, events.DataReloadFailed{Path: path, Type: kind, Problems: lines})
+	}
+}
+
 // ensureLoaded guarantees all data sets are loaded once at startup.
 func (s *System) ensureLoaded() {
 	s.mu.Lock()
@@ -144,10 +281,12 @@ func (s *System) ensureLoaded() {
 | MANAGEMENT                                    |
 *───────────────────────────────────────────────*/
 
-func (s *System) RegisterDataFile(kind string, path string) {
-	s.reloadMgr.Watch(path)
-	fmt.Printf("[DATA] Registered %s for hot reload: %s\n", kind, path)
-}
+// Mods returns the active mod packs (nil when none were found).
+func (s *System) Mods() *mods.Set { return s.mods }
+
+// Registry exposes the data registry so other packages can add kinds.
+// Their decoders and callbacks run on the game loop during Update.
+func (s *System) Registry() *Registry { return s.registry }
 
 func (s *System) Subscriber() *DataSubscriber { return s.subscriber }
 
@@ -166,50 +305,13 @@ func (s *System) ActorDatabase() data.ActorDatabase {
 		if len(tpl.AIRefs) > 0 {
 			copyTpl.AIRefs = append([]string(nil), tpl.AIRefs...)
 		}
+		if len(tpl.Components) > 0 {
+			copyTpl.Components = make(map[string]json.RawMessage, len(tpl.Components))
+			for name, raw := range tpl.Components {
+				copyTpl.Components[name] = append(json.RawMessage(nil), raw...)
+			}
+		}
 		db.Actors[i] = copyTpl
 	}
 	return db
 }
-
-/*───────────────────────────────────────────────*
-| HOT RELOAD MANAGER                            |
-*───────────────────────────────────────────────*/
-
-type HotReloadManager struct {
-	mu    sync.RWMutex
-	files map[string]time.Time
-}
-
-func NewHotReloadManager() *HotReloadManager {
-	return &HotReloadManager{files: make(map[string]time.Time)}
-}
-
-func (h *HotReloadManager) Watch(path string) {
-	info, err := os.Stat(path)
-	if err != nil {
-		fmt.Printf("[HOTRELOAD] Missing %s (will monitor when available)\n", path)
-		return
-	}
-	h.mu.Lock()
-	defer h.mu.Unlock()
-	h.files[path] = info.ModTime()
-	fmt.Printf("[HOTRELOAD] Watching %s\n", path)
-}
-
-func (h *HotReloadManager) CheckChanges() []string {
-	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	var changed []string
-	for path, lastMod := range h.files {
-		info, err := os.Stat(path)
-		if err != nil {
-			continue
-		}
-		if info.ModTime().After(lastMod) {
-			h.files[path] = info.ModTime()
-			changed = append(changed, path)
-		}
-	}
-	return changed
-}
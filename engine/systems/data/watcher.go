@@ -0,0 +1,31 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+/*───────────────────────────────────────────────*
+| FILE WATCHER                                  |
+*───────────────────────────────────────────────*/
+
+// Watcher reports files created, written or removed in watched directories.
+type Watcher interface {
+	Add(dir string) error
+	Events() <-chan string // changed file paths
+	Close() error
+}
+
+// DefaultPollInterval is the scan period of the polling fallback.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// NewWatcher returns the platform's native watcher, falling back to polling
+// when it is unavailable.
+func NewWatcher() Watcher {
+	w, err := newNativeWatcher()
+	if err == nil {
+		return w
+	}
+	fmt.Printf("[HOTRELOAD] Native watcher unavailable (%v); polling every %v\n", err, DefaultPollInterval)
+	return NewPollWatcher(DefaultPollInterval)
+}
@@ -0,0 +1,115 @@
+//go:build linux
+
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+/*───────────────────────────────────────────────*
+| INOTIFY WATCHER                               |
+*───────────────────────────────────────────────*/
+
+const inotifyMask = syscall.IN_CLOSE_WRITE | syscall.IN_MOVED_TO | syscall.IN_CREATE |
+	syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MODIFY
+
+// inotifyWatcher reports changes via Linux inotify. The descriptor is
+// non-blocking and wrapped in an *os.File so Close wakes the reader.
+type inotifyWatcher struct {
+	file   *os.File
+	mu     sync.Mutex
+	dirs   map[int32]string
+	events chan string
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newNativeWatcher() (Watcher, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_NONBLOCK | syscall.IN_CLOEXEC)
+	if err != nil {
+		return nil, err
+	}
+	w := &inotifyWatcher{
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		dirs:   make(map[int32]string),
+		events: make(chan string, 64),
+		done:   make(chan struct{}),
+	}
+	go w.loop()
+	return w, nil
+}
+
+func (w *inotifyWatcher) Add(dir string) error {
+	dir = filepath.Clean(dir)
+	conn, err := w.file.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var wd int
+	var addErr error
+	if err := conn.Control(func(fd uintptr) {
+		wd, addErr = syscall.InotifyAddWatch(int(fd), dir, inotifyMask)
+	}); err != nil {
+		return err
+	}
+	if addErr != nil {
+		return &os.PathError{Op: "inotify_add_watch", Path: dir, Err: addErr}
+	}
+	w.mu.Lock()
+	w.dirs[int32(wd)] = dir
+	w.mu.Unlock()
+	return nil
+}
+
+func (w *inotifyWatcher) Events() <-chan string { return w.events }
+
+func (w *inotifyWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.done)
+		err = w.file.Close()
+	})
+	return err
+}
+
+func (w *inotifyWatcher) loop() {
+	defer close(w.events)
+	buf := make([]byte, 64*(syscall.SizeofInotifyEvent+syscall.NAME_MAX+1))
+	for {
+		n, err := w.file.Read(buf)
+		if err != nil {
+			return
+		}
+		for off := 0; off+syscall.SizeofInotifyEvent <= n; {
+			ev := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			nameStart := off + syscall.SizeofInotifyEvent
+			nameEnd := nameStart + int(ev.Len)
+			off = nameEnd
+			if ev.Len == 0 || ev.Mask&syscall.IN_ISDIR != 0 {
+				continue
+			}
+			name := string(buf[nameStart:nameEnd])
+			for i := 0; i < len(name); i++ {
+				if name[i] == 0 {
+					name = name[:i]
+					break
+				}
+			}
+			w.mu.Lock()
+			dir, ok := w.dirs[ev.Wd]
+			w.mu.Unlock()
+			if !ok {
+				continue
+			}
+			select {
+			case w.events <- filepath.Join(dir, name):
+			case <-w.done:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package data
+
+import "errors"
+
+func newNativeWatcher() (Watcher, error) {
+	return nil, errors.New("no native file watcher on this platform")
+}
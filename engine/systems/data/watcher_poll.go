@@ -0,0 +1,124 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+/*───────────────────────────────────────────────*
+| POLLING WATCHER                               |
+*───────────────────────────────────────────────*/
+
+// PollWatcher scans watched directories on an interval and compares file
+// modification times and sizes. It works everywhere, just less promptly.
+type PollWatcher struct {
+	mu     sync.Mutex
+	dirs   map[string]map[string]fileStamp
+	events chan string
+	done   chan struct{}
+	once   sync.Once
+}
+
+type fileStamp struct {
+	mod  time.Time
+	size int64
+}
+
+// NewPollWatcher starts a watcher scanning every interval.
+func NewPollWatcher(interval time.Duration) *PollWatcher {
+	w := &PollWatcher{
+		dirs:   make(map[string]map[string]fileStamp),
+		events: make(chan string, 64),
+		done:   make(chan struct{}),
+	}
+	go w.loop(interval)
+	return w
+}
+
+// Add starts watching dir. Files already present are not reported.
+func (w *PollWatcher) Add(dir string) error {
+	dir = filepath.Clean(dir)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, ok := w.dirs[dir]; ok {
+		return nil
+	}
+	stamps, err := scanDir(dir)
+	if err != nil {
+		return err
+	}
+	w.dirs[dir] = stamps
+	return nil
+}
+
+func (w *PollWatcher) Events() <-chan string { return w.events }
+
+func (w *PollWatcher) Close() error {
+	w.once.Do(func() { close(w.done) })
+	return nil
+}
+
+func (w *PollWatcher) loop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			for _, path := range w.scan() {
+				select {
+				case w.events <- path:
+				case <-w.done:
+					return
+				}
+			}
+		}
+	}
+}
+
+// scan diffs every directory against its last snapshot.
+func (w *PollWatcher) scan() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var changed []string
+	for dir, old := range w.dirs {
+		now, err := scanDir(dir)
+		if err != nil {
+			continue
+		}
+		for path, stamp := range now {
+			if prev, ok := old[path]; !ok || prev != stamp {
+				changed = append(changed, path)
+			}
+		}
+		for path := range old {
+			if _, ok := now[path]; !ok {
+				changed = append(changed, path)
+			}
+		}
+		w.dirs[dir] = now
+	}
+	return changed
+}
+
+func scanDir(dir string) (map[string]fileStamp, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	stamps := make(map[string]fileStamp, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		stamps[filepath.Join(dir, e.Name())] = fileStamp{info.ModTime(), info.Size()}
+	}
+	return stamps, nil
+}
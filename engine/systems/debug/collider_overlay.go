@@ -0,0 +1,86 @@
+package debug
+
+import (
+	"image/color"
+	"math"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/events"
+	"rp-go/engine/platform"
+)
+
+/*───────────────────────────────────────────────*
+ | COLLIDER OVERLAY                              |
+ *───────────────────────────────────────────────*/
+
+// ColliderOverlay outlines every collider in world space: solid colliders
+// in green, static ones in grey and triggers in amber. It shows and hides
+// with the debug overlay.
+type ColliderOverlay struct {
+	bus      *events.TypedBus
+	disabled bool
+	outline  []ecs.Vec2
+}
+
+var (
+	colliderColor = color.RGBA{120, 255, 140, 200}
+	staticColor   = color.RGBA{180, 180, 200, 200}
+	triggerColor  = color.RGBA{255, 190, 60, 200}
+)
+
+// outlineDotSpacing is the screen distance between the dots of an edge.
+const outlineDotSpacing = 3
+
+func (o *ColliderOverlay) Layer() ecs.DrawLayer { return ecs.LayerForeground }
+
+// Update binds the debug toggle on first use.
+func (o *ColliderOverlay) Update(w *ecs.World) {
+	if w == nil || o.bus != nil {
+		return
+	}
+	o.bus, _ = w.EventBus.(*events.TypedBus)
+	if o.bus != nil {
+		events.Subscribe(o.bus, func(e events.DebugToggleEvent) {
+			o.disabled = !e.Enabled
+		})
+	}
+}
+
+// Draw traces each collider's outline as a dotted line at its
+// interpolated position.
+func (o *ColliderOverlay) Draw(w *ecs.World, screen *platform.Image) {
+	if o.disabled || w == nil || screen == nil {
+		return
+	}
+	_, cam := ecs.NewQuery[*ecs.Camera](w).First()
+	if cam == nil {
+		return
+	}
+	alpha := w.Clock().Alpha()
+	camX, camY := cam.Interpolated(alpha)
+	bounds := screen.Bounds()
+	halfW := float64(bounds.Dx()) / 2
+	halfH := float64(bounds.Dy()) / 2
+
+	ecs.NewQuery2[*ecs.Position, *ecs.Collider](w).Each(func(_ *ecs.Entity, pos *ecs.Position, c *ecs.Collider) {
+		clr := colliderColor
+		switch {
+		case c.Trigger:
+			clr = triggerColor
+		case c.Static:
+			clr = staticColor
+		}
+		x, y := pos.Interpolated(alpha)
+		o.outline = c.Outline(x, y, o.outline[:0])
+		for i, a := range o.outline {
+			b := o.outline[(i+1)%len(o.outline)]
+			ax, ay := (a.X-camX)*cam.Scale+halfW, (a.Y-camY)*cam.Scale+halfH
+			bx, by := (b.X-camX)*cam.Scale+halfW, (b.Y-camY)*cam.Scale+halfH
+			steps := max(1, int(math.Hypot(bx-ax, by-ay)/outlineDotSpacing))
+			for s := 0; s < steps; s++ {
+				t := float64(s) / float64(steps)
+				screen.FillRect(int(ax+(bx-ax)*t), int(ay+(by-ay)*t), 1, 1, clr)
+			}
+		}
+	})
+}
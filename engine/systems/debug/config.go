@@ -7,6 +7,7 @@ type Config struct {
 	ViewportHeight int
 
 	StatsWidth    int
+	ProfilerWidth int
 	EntitiesWidth int
 	MaxEntities   int
 }
@@ -25,6 +26,9 @@ func (c *Config) normalize() {
 	if c.StatsWidth <= 0 {
 		c.StatsWidth = 260
 	}
+	if c.ProfilerWidth <= 0 {
+		c.ProfilerWidth = 300
+	}
 	if c.EntitiesWidth <= 0 {
 		c.EntitiesWidth = 360
 	}
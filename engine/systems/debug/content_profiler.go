@@ -0,0 +1,121 @@
+package debug
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image/color"
+	"time"
+
+	"golang.org/x/image/font/basicfont"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/platform"
+	"rp-go/engine/ui/window"
+)
+
+// frameBudget is the 60 FPS frame time the chart marks as a guide line.
+const frameBudget = time.Second / 60
+
+// profilerPalette colors systems consistently across frames.
+var profilerPalette = []color.RGBA{
+	{230, 110, 90, 230},
+	{90, 180, 230, 230},
+	{140, 210, 110, 230},
+	{230, 190, 80, 230},
+	{180, 120, 230, 230},
+	{90, 220, 190, 230},
+	{230, 130, 190, 230},
+	{170, 170, 170, 230},
+}
+
+// ProfilerContent renders a stacked per-system frame-time chart above a
+// list of the slowest systems.
+type ProfilerContent struct {
+	frames     []ecs.FrameProfile
+	lines      []string
+	chartH     int
+	lineHeight int
+	topN       int
+}
+
+// Refresh snapshots the world's profiler history.
+func (c *ProfilerContent) Refresh(world *ecs.World) {
+	c.frames = nil
+	if world == nil || world.Profiler() == nil {
+		c.lines = []string{"Profiler not attached"}
+		return
+	}
+	prof := world.Profiler()
+	c.frames = prof.Frames()
+
+	lines := make([]string, 0, c.topN+1)
+	if last, ok := prof.Latest(); ok {
+		lines = append(lines, fmt.Sprintf("Frame %d: %s (%d samples)", last.Frame, fmtMillis(last.Duration), len(last.Samples)))
+	}
+	for i, st := range prof.Summary() {
+		if i >= c.topN {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%-18.18s %s avg %s max %4.0f a", st.System, st.Kind[:1], fmtMillis(st.Average), st.AvgAllocs))
+	}
+	c.lines = lines
+}
+
+// Draw renders the chart (one column per frame, newest on the right) and
+// the summary text into the window's content area.
+func (c *ProfilerContent) Draw(_ *ecs.World, canvas *platform.Image, bounds window.Bounds) {
+	if canvas == nil {
+		return
+	}
+
+	if len(c.frames) > 0 {
+		c.drawChart(canvas, bounds)
+	}
+
+	baseline := bounds.Y + c.chartH + 16
+	for _, line := range c.lines {
+		platform.DrawText(canvas, line, basicfont.Face7x13, bounds.X, baseline, color.RGBA{210, 230, 255, 255})
+		baseline += c.lineHeight
+	}
+}
+
+func (c *ProfilerContent) drawChart(canvas *platform.Image, bounds window.Bounds) {
+	// Scale so two frame budgets fit the chart height.
+	scale := float64(c.chartH) / float64(2*frameBudget)
+	bottom := bounds.Y + c.chartH
+	barW := bounds.Width / len(c.frames)
+	if barW < 1 {
+		barW = 1
+	}
+	x := bounds.X + bounds.Width - barW*len(c.frames)
+
+	canvas.FillRect(bounds.X, bounds.Y, bounds.Width, c.chartH, color.RGBA{20, 26, 38, 200})
+	for _, f := range c.frames {
+		y := bottom
+		for _, s := range f.Samples {
+			h := int(float64(s.Duration) * scale)
+			if h < 1 {
+				continue
+			}
+			if y-h < bounds.Y {
+				h = y - bounds.Y
+			}
+			canvas.FillRect(x, y-h, barW, h, systemColor(s.System))
+			y -= h
+		}
+		x += barW
+	}
+
+	budgetY := bottom - int(float64(frameBudget)*scale)
+	canvas.FillRect(bounds.X, budgetY, bounds.Width, 1, color.RGBA{255, 80, 80, 220})
+}
+
+func systemColor(name string) color.RGBA {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return profilerPalette[h.Sum32()%uint32(len(profilerPalette))]
+}
+
+func fmtMillis(d time.Duration) string {
+	return fmt.Sprintf("%5.2fms", float64(d)/float64(time.Millisecond))
+}
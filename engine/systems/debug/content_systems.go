@@ -12,7 +12,8 @@ import (
 	"rp-go/engine/ui/window"
 )
 
-// SystemInspectorContent lists all registered ECS systems with their layers.
+// SystemInspectorContent lists all registered ECS systems with their layers
+// and the scheduler's computed execution stages.
 type SystemInspectorContent struct {
 	lines          []string
 	lineHeight     int
@@ -82,6 +83,15 @@ func (c *SystemInspectorContent) Refresh(world *ecs.World) {
 		lines = append(lines, fmt.Sprintf("[%s] %s", layerName, e.Name))
 	}
 
+	// Computed scheduler stages (∥ marks stages that run concurrently).
+	schedule := world.ScheduleSummary()
+	lines = append(lines,
+		"",
+		fmt.Sprintf("Stages: %d", len(schedule)),
+		"──────────────────────────────",
+	)
+	lines = append(lines, schedule...)
+
 	c.lines = lines
 }
 
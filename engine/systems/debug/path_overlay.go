@@ -0,0 +1,81 @@
+package debug
+
+import (
+	"image/color"
+	"math"
+
+	"rp-go/engine/ecs"
+	"rp-go/engine/events"
+	"rp-go/engine/platform"
+)
+
+/*───────────────────────────────────────────────*
+ | AI PATH OVERLAY                               |
+ *───────────────────────────────────────────────*/
+
+// PathOverlay draws the paths AI entities follow around terrain, in world
+// space above the entities. It shows and hides with the debug overlay.
+type PathOverlay struct {
+	bus      *events.TypedBus
+	disabled bool
+}
+
+var (
+	pathColor     = color.RGBA{120, 220, 255, 200}
+	waypointColor = color.RGBA{255, 220, 80, 230}
+)
+
+// pathDotSpacing is the screen distance between the dots of a segment.
+const pathDotSpacing = 6
+
+func (o *PathOverlay) Layer() ecs.DrawLayer { return ecs.LayerForeground }
+
+// Update binds the debug toggle on first use.
+func (o *PathOverlay) Update(w *ecs.World) {
+	if w == nil || o.bus != nil {
+		return
+	}
+	o.bus, _ = w.EventBus.(*events.TypedBus)
+	if o.bus != nil {
+		events.Subscribe(o.bus, func(e events.DebugToggleEvent) {
+			o.disabled = !e.Enabled
+		})
+	}
+}
+
+// Draw renders the remaining part of every NavPath as a dotted line from the
+// entity through its waypoints.
+func (o *PathOverlay) Draw(w *ecs.World, screen *platform.Image) {
+	if o.disabled || w == nil || screen == nil {
+		return
+	}
+	_, cam := ecs.NewQuery[*ecs.Camera](w).First()
+	if cam == nil {
+		return
+	}
+	alpha := w.Clock().Alpha()
+	camX, camY := cam.Interpolated(alpha)
+	bounds := screen.Bounds()
+	halfW := float64(bounds.Dx()) / 2
+	halfH := float64(bounds.Dy()) / 2
+	toScreen := func(x, y float64) (float64, float64) {
+		return (x-camX)*cam.Scale + halfW, (y-camY)*cam.Scale + halfH
+	}
+
+	ecs.NewQuery2[*ecs.Position, *ecs.NavPath](w).Each(func(_ *ecs.Entity, pos *ecs.Position, path *ecs.NavPath) {
+		if path.Index >= len(path.Points) {
+			return
+		}
+		px, py := toScreen(pos.Interpolated(alpha))
+		for _, wp := range path.Points[path.Index:] {
+			x, y := toScreen(wp.X, wp.Y)
+			steps := int(math.Hypot(x-px, y-py) / pathDotSpacing)
+			for i := 1; i < steps; i++ {
+				t := float64(i) / float64(steps)
+				screen.FillRect(int(px+(x-px)*t), int(py+(y-py)*t), 2, 2, pathColor)
+			}
+			screen.FillRect(int(x)-2, int(y)-2, 4, 4, waypointColor)
+			px, py = x, y
+		}
+	})
+}
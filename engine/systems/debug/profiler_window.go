@@ -0,0 +1,91 @@
+package debug
+
+import (
+	"image/color"
+	"rp-go/engine/ecs"
+	"rp-go/engine/ui/window"
+)
+
+// profilerFrames is how much history the window's profiler keeps (~2s).
+const profilerFrames = 120
+
+// ProfilerWindow charts per-system frame times next to the stats window.
+// It starts hidden; while shown it attaches a profiler to the world, so
+// system calls are only measured when someone is looking.
+type ProfilerWindow struct {
+	component *window.Component
+	content   *ProfilerContent
+	cfg       Config
+	attached  bool // the world's profiler is ours to detach
+}
+
+// NewProfilerWindow creates the profiler window.
+func NewProfilerWindow(cfg Config) *ProfilerWindow {
+	return &ProfilerWindow{
+		cfg:     cfg,
+		content: &ProfilerContent{chartH: 80, lineHeight: 16, topN: 6},
+	}
+}
+
+// Ensure creates the window entity once and adds it to the ECS world.
+func (p *ProfilerWindow) Ensure(world *ecs.World) {
+	if p.component != nil {
+		return
+	}
+
+	entity := world.NewEntity()
+	comp := window.NewComponent("debug.profiler", "Profiler", window.Bounds{
+		X:      p.cfg.Margin*2 + p.cfg.StatsWidth,
+		Y:      p.cfg.Margin,
+		Width:  p.cfg.ProfilerWidth,
+		Height: 26 + 12*2 + p.content.chartH + 8 + (p.content.topN+1)*p.content.lineHeight,
+	}, p.content)
+	comp.Layer = ecs.LayerDebug
+	comp.Order = 15
+	comp.Padding = 12
+	comp.TitleBarHeight = 26
+	comp.Background = color.RGBA{12, 16, 24, 220}
+	comp.Border = color.RGBA{90, 130, 200, 200}
+	comp.TitleBar = color.RGBA{30, 50, 90, 230}
+	comp.TitleColor = color.RGBA{230, 240, 255, 255}
+	comp.Movable = true
+	comp.Closable = true
+	comp.Visible = false // opened from the toolbar
+
+	entity.Add(comp)
+	p.component = comp
+}
+
+// Update attaches or detaches the profiler to follow the window's
+// visibility and refreshes the chart.
+func (p *ProfilerWindow) Update(world *ecs.World) {
+	if p.component == nil {
+		return
+	}
+	if !p.component.Visible {
+		p.detach(world)
+		return
+	}
+	if world.Profiler() == nil {
+		world.SetProfiler(ecs.NewProfiler(profilerFrames))
+		p.attached = true
+	}
+	p.content.Refresh(world)
+}
+
+// Hide conceals the window and stops profiling.
+func (p *ProfilerWindow) Hide(world *ecs.World) {
+	if p.component != nil {
+		p.component.Visible = false
+	}
+	p.detach(world)
+}
+
+// detach removes the profiler the window attached, leaving one attached
+// elsewhere (the profile console command) in place.
+func (p *ProfilerWindow) detach(world *ecs.World) {
+	if p.attached && world != nil {
+		world.SetProfiler(nil)
+	}
+	p.attached = false
+}
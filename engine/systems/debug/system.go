@@ -3,6 +3,7 @@ package debug
 import (
 	"rp-go/engine/ecs"
 	"rp-go/engine/events"
+	"rp-go/engine/ui/window"
 
 	"rp-go/engine/systems/aicomposer"
 )
@@ -20,6 +21,7 @@ type System struct {
 	enabled bool
 
 	stats     *StatsWindow
+	profiler  *ProfilerWindow
 	entities  *EntitiesWindow
 	systems   *SystemWindow
 	toolbar   *ToolbarWindow
@@ -70,6 +72,9 @@ func (s *System) Update(world *ecs.World) {
 	if s.stats != nil {
 		s.stats.Update(world)
 	}
+	if s.profiler != nil {
+		s.profiler.Update(world)
+	}
 	if s.entities != nil {
 		s.entities.Update(world)
 	}
@@ -89,6 +94,9 @@ func (s *System) ensureWindows(world *ecs.World) {
 	if s.stats == nil {
 		s.stats = NewStatsWindow(s.cfg)
 	}
+	if s.profiler == nil {
+		s.profiler = NewProfilerWindow(s.cfg)
+	}
 	if s.entities == nil {
 		s.entities = NewEntitiesWindow(s.cfg)
 	}
@@ -103,6 +111,7 @@ func (s *System) ensureWindows(world *ecs.World) {
 	}
 
 	s.stats.Ensure(world)
+	s.profiler.Ensure(world)
 	s.entities.Ensure(world)
 	s.systems.Ensure(world)
 	s.toolbar.Ensure(world)
@@ -128,10 +137,15 @@ func (s *System) initSubscriptions() {
 		s.enabled = e.Enabled
 	})
 
+	// Toolbar buttons flip single windows
+	events.Subscribe(s.bus, func(e events.DebugToggleWindowEvent) {
+		s.toggleWindow(e.ID)
+	})
+
 	// Hide relevant windows when closed manually
 	events.Subscribe(s.bus, func(e events.WindowClosedEvent) {
 		switch e.ID {
-		case "debug.stats", "debug.entities", "debug.systems", "debug.aicomposer":
+		case "debug.stats", "debug.profiler", "debug.entities", "debug.systems", "debug.aicomposer":
 			s.enabled = false
 		}
 	})
@@ -162,10 +176,43 @@ func (s *System) Toggle(world *ecs.World) {
 	}
 }
 
+// toggleWindow flips the visibility of the debug window with the given ID.
+func (s *System) toggleWindow(id string) {
+	var comp *window.Component
+	switch id {
+	case "debug.stats":
+		if s.stats != nil {
+			comp = s.stats.component
+		}
+	case "debug.profiler":
+		if s.profiler != nil {
+			comp = s.profiler.component
+		}
+	case "debug.entities":
+		if s.entities != nil {
+			comp = s.entities.component
+		}
+	case "debug.systems":
+		if s.systems != nil {
+			comp = s.systems.component
+		}
+	case "debug.aicomposer":
+		if s.composer != nil {
+			s.composer.Toggle()
+		}
+	}
+	if comp != nil {
+		comp.Visible = !comp.Visible
+	}
+}
+
 func (s *System) hideAll(world *ecs.World) {
 	if s.stats != nil {
 		s.stats.Hide(world)
 	}
+	if s.profiler != nil {
+		s.profiler.Hide(world)
+	}
 	if s.entities != nil {
 		s.entities.Hide(world)
 	}
@@ -51,6 +51,9 @@ func (t *ToolbarWindow) Ensure(world *ecs.World) {
 		button.New("Stats", func() {
 			events.Queue(t.bus, events.DebugToggleWindowEvent{ID: "debug.stats"})
 		}),
+		button.New("Profiler", func() {
+			events.Queue(t.bus, events.DebugToggleWindowEvent{ID: "debug.profiler"})
+		}),
 		button.New("Entities", func() {
 			events.Queue(t.bus, events.DebugToggleWindowEvent{ID: "debug.entities"})
 		}),
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"rp-go/engine/ecs"
+	"rp-go/engine/locale"
 )
 
 func (s *ConsoleState) ExecuteCommand(w *ecs.World, input string) {
@@ -15,12 +16,15 @@ func (s *ConsoleState) ExecuteCommand(w *ecs.World, input string) {
 		return
 	}
 	s.PushHistory(command)
+	if s.OnCommand != nil {
+		s.OnCommand(command)
+	}
 
 	fields := strings.Fields(command)
 
 	switch strings.ToLower(fields[0]) {
 	case "help":
-		s.Log("Commands: help, spawn <template> [x y], remove <actorID>, move <actorID> <x y>, list")
+		s.Log("Commands: help, spawn <template> [x y], remove <actorID>, move <actorID> <x y>, list, profile [path], mods, locale [code]")
 	case "spawn":
 		s.HandleSpawn(w, fields)
 	case "remove", "rm":
@@ -29,11 +33,29 @@ func (s *ConsoleState) ExecuteCommand(w *ecs.World, input string) {
 		s.HandleMove(w, fields)
 	case "list":
 		s.HandleList(w)
+	case "profile":
+		s.HandleProfile(w, fields)
+	case "mods":
+		s.HandleMods()
+	case "locale":
+		s.HandleLocale(fields)
 	default:
 		s.Log(fmt.Sprintf("Unknown command: %s", fields[0]))
 	}
 }
 
+// runPending executes commands queued through System.Enqueue.
+func (s *ConsoleState) runPending(w *ecs.World) {
+	if len(s.pending) == 0 {
+		return
+	}
+	queued := s.pending
+	s.pending = nil
+	for _, command := range queued {
+		s.ExecuteCommand(w, command)
+	}
+}
+
 func (s *ConsoleState) HandleSpawn(w *ecs.World, fields []string) {
 	if len(fields) < 2 {
 		s.Log("Usage: spawn <template> [x y]")
@@ -117,6 +139,7 @@ func (s *ConsoleState) HandleMove(w *ecs.World, fields []string) {
 		target.Add(pos)
 	}
 	pos.X, pos.Y = x, y
+	pos.Snap()
 
 	s.Log(fmt.Sprintf("Moved %s to (%.1f, %.1f)", fields[1], x, y))
 }
@@ -132,6 +155,81 @@ func (s *ConsoleState) HandleList(w *ecs.World) {
 	}
 }
 
+// profileFrames is the history kept by a profiler the console attaches.
+const profileFrames = 120
+
+// HandleProfile exports the profiler's frame history as a Chrome trace.
+// Profiling is off by default, so the first call attaches a profiler and a
+// later call exports what it recorded.
+func (s *ConsoleState) HandleProfile(w *ecs.World, fields []string) {
+	prof := w.Profiler()
+	if prof == nil {
+		w.SetProfiler(ecs.NewProfiler(profileFrames))
+		s.Log("Profiler attached; run profile again to export the recorded frames.")
+		return
+	}
+	path := "profile.json"
+	if len(fields) >= 2 {
+		path = fields[1]
+	}
+	if err := prof.ExportChromeTrace(path); err != nil {
+		s.Log(fmt.Sprintf("Profile export failed: %v", err))
+		return
+	}
+	s.Log(fmt.Sprintf("Wrote %d frames to %s", len(prof.Frames()), path))
+}
+
+// HandleMods lists active packs in load order, with the templates each one
+// defines, followed by any skipped packs.
+func (s *ConsoleState) HandleMods() {
+	active := s.Mods.Active()
+	disabled := s.Mods.Disabled()
+	if len(active) == 0 && len(disabled) == 0 {
+		s.Log("No mods loaded.")
+		return
+	}
+
+	if s.Creator == nil && s.CreatorFactory != nil {
+		s.Creator = s.CreatorFactory()
+	}
+	layered, _ := s.Creator.(interface{ TemplatesFrom(layer string) []string })
+
+	for i, pack := range active {
+		line := fmt.Sprintf("%d. %s %s (%s)", i+1, pack.ID, pack.Version, pack.Path)
+		if layered != nil {
+			if names := layered.TemplatesFrom(pack.ID); len(names) > 0 {
+				line += " actors: " + strings.Join(names, ", ")
+			}
+		}
+		s.Log(line)
+	}
+	for _, d := range disabled {
+		s.Log(fmt.Sprintf("skipped %s: %s", d.Path, d.Reason))
+	}
+}
+
+// HandleLocale switches the UI language, or lists the loaded string tables
+// when no code is given.
+func (s *ConsoleState) HandleLocale(fields []string) {
+	if len(fields) < 2 {
+		var codes []string
+		for _, t := range locale.Default.Locales() {
+			codes = append(codes, fmt.Sprintf("%s (%s)", t.Locale, t.Name))
+		}
+		if len(codes) == 0 {
+			s.Log("No string tables loaded.")
+			return
+		}
+		s.Log(fmt.Sprintf("Locale: %s. Available: %s", locale.Default.Locale(), strings.Join(codes, ", ")))
+		return
+	}
+	if err := locale.Default.SetLocale(fields[1]); err != nil {
+		s.Log(err.Error())
+		return
+	}
+	s.Log(fmt.Sprintf("Locale set to %s", fields[1]))
+}
+
 func (s *ConsoleState) listTemplates() []string {
 	creator := s.Creator
 	if creator == nil && s.CreatorFactory != nil {
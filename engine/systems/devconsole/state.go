@@ -4,6 +4,7 @@ import (
 	"sync/atomic"
 
 	"rp-go/engine/ecs"
+	"rp-go/engine/mods"
 	"rp-go/engine/systems/actor"
 	"rp-go/engine/ui/window"
 )
@@ -19,6 +20,7 @@ type ConsoleState struct {
 	Registry       *actor.Registry // reference to ECS actor registry
 	Creator        ActorSpawner    // interface for spawning
 	CreatorFactory func() ActorSpawner
+	Mods           *mods.Set // active content packs, for the mods command
 	Open           bool
 	JustOpened     bool
 	CursorTick     int
@@ -27,6 +29,10 @@ type ConsoleState struct {
 	HistoryIdx     int
 	LogMessages    []string
 
+	// OnCommand, when set, observes every executed command (replay recording).
+	OnCommand func(command string)
+	pending   []string
+
 	windowEntity    *ecs.Entity
 	windowComponent *window.Component
 	windowContent   *consoleWindowContent
@@ -1,8 +1,11 @@
 package devconsole
 
 import (
+	"fmt"
+
 	"rp-go/engine/ecs"
 	"rp-go/engine/events"
+	"rp-go/engine/mods"
 	"rp-go/engine/systems/actor"
 )
 
@@ -57,11 +60,37 @@ func (s *System) Update(w *ecs.World) {
 		return
 	}
 	s.state.ensureWindow(w, s.cfg)
+	s.state.runPending(w)
 	s.state.UpdateInput(w)
 	s.state.syncWindowVisibility()
 	s.state.applyLayout(s.cfg)
 }
 
+// Enqueue schedules a command to run on the next Update, whether or not the
+// console is open. Replays use it to feed recorded commands back in.
+func (s *System) Enqueue(command string) {
+	if s == nil || s.state == nil {
+		return
+	}
+	s.state.pending = append(s.state.pending, command)
+}
+
+// SetCommandObserver installs fn to observe every executed command.
+func (s *System) SetCommandObserver(fn func(command string)) {
+	if s == nil || s.state == nil {
+		return
+	}
+	s.state.OnCommand = fn
+}
+
+// SetMods gives the mods command the active pack set.
+func (s *System) SetMods(set *mods.Set) {
+	if s == nil || s.state == nil {
+		return
+	}
+	s.state.Mods = set
+}
+
 // OnDataReload resets the cached actor creator when actor data changes.
 func (s *System) OnDataReload(e events.DataReloaded) {
 	if s == nil || s.state == nil {
@@ -72,3 +101,14 @@ func (s *System) OnDataReload(e events.DataReloaded) {
 		s.state.ResetCreator()
 	}
 }
+
+// OnDataReloadFailed lists validation problems of a rejected data file.
+func (s *System) OnDataReloadFailed(e events.DataReloadFailed) {
+	if s == nil || s.state == nil {
+		return
+	}
+	s.state.Log(fmt.Sprintf("Reload of %s rejected; previous data kept:", e.Path))
+	for _, problem := range e.Problems {
+		s.state.Log("  " + problem)
+	}
+}
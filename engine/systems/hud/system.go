@@ -1,12 +1,12 @@
 package hud
 
 import (
-	"fmt"
 	"image/color"
 
 	"golang.org/x/image/font/basicfont"
 
 	"rp-go/engine/ecs"
+	"rp-go/engine/locale"
 	"rp-go/engine/platform"
 	"rp-go/engine/ui/window"
 )
@@ -28,6 +28,14 @@ func NewSystem() *System {
 	}
 }
 
+// Access declares the components the HUD touches for the scheduler.
+func (s *System) Access() ecs.Access {
+	return ecs.Access{
+		Reads:  []string{"Actor", "Position", "Velocity"},
+		Writes: []string{"Window"},
+	}
+}
+
 // Update ensures the HUD window exists and refreshes its dynamic content.
 func (s *System) Update(world *ecs.World) {
 	if world == nil {
@@ -41,7 +49,8 @@ func (s *System) Update(world *ecs.World) {
 		}
 	}
 
-	if s.windowComponent == nil || s.windowEntity == nil || !s.windowEntity.Has("Window") {
+	pending := s.windowComponent != nil && s.windowEntity == nil
+	if !pending && (s.windowComponent == nil || !s.windowEntity.Has("Window")) {
 		s.attachWindow(world)
 	}
 
@@ -72,7 +81,6 @@ func (s *System) attachWindow(world *ecs.World) {
 	if world == nil {
 		return
 	}
-	entity := world.NewEntity()
 	bounds := window.Bounds{X: 16, Y: 16, Width: 320, Height: 0}
 	component := window.NewComponent("hud.pilot", "Pilot HUD", bounds, s.content)
 	component.Order = 10
@@ -82,9 +90,13 @@ func (s *System) attachWindow(world *ecs.World) {
 	component.Border = color.RGBA{120, 160, 255, 180}
 	component.TitleBar = color.RGBA{25, 40, 92, 220}
 	component.TitleColor = color.RGBA{235, 244, 255, 255}
-	entity.Add(component)
 
-	s.windowEntity = entity
+	// Spawned through the command buffer: the HUD may run concurrently with
+	// other systems, so it must not touch the entity list directly.
+	world.Commands().Spawn(func(entity *ecs.Entity) {
+		entity.Add(component)
+		s.windowEntity = entity
+	})
 	s.windowComponent = component
 }
 
@@ -96,11 +108,11 @@ type pilotHUDContent struct {
 
 func (c *pilotHUDContent) Refresh(world *ecs.World) {
 	lines := []string{
-		"Controls:",
-		"  Move: WASD / Arrow Keys",
-		"  Zoom: Mouse Wheel or +/-",
-		"  Reset Zoom: 0",
-		"  Toggle Console: F12",
+		locale.T("hud.controls"),
+		locale.T("hud.controls.move"),
+		locale.T("hud.controls.zoom"),
+		locale.T("hud.controls.reset_zoom"),
+		locale.T("hud.controls.console"),
 	}
 
 	var position *ecs.Position
@@ -119,10 +131,10 @@ func (c *pilotHUDContent) Refresh(world *ecs.World) {
 	}
 
 	if position != nil {
-		lines = append(lines, fmt.Sprintf("Position: (%.0f, %.0f)", position.X, position.Y))
+		lines = append(lines, locale.T("hud.position", position.X, position.Y))
 	}
 	if velocity != nil {
-		lines = append(lines, fmt.Sprintf("Velocity: (%.1f, %.1f)", velocity.VX, velocity.VY))
+		lines = append(lines, locale.T("hud.velocity", velocity.VX, velocity.VY))
 	}
 
 	c.lines = lines
@@ -5,29 +5,31 @@ import (
 
 	"rp-go/engine/ecs"
 	inputmgr "rp-go/engine/input"
-	"rp-go/engine/systems/devconsole"
 )
 
 // System processes player input from keyboard and gamepad,
 // updating Velocity and Sprite components accordingly.
 type System struct{}
 
+// Access declares the components input touches for the scheduler.
+func (s *System) Access() ecs.Access {
+	return ecs.Access{
+		Reads:  []string{"PlayerInput"},
+		Writes: []string{"Velocity", "Sprite"},
+	}
+}
+
 // Default movement speed in world units per frame.
 const moveSpeed = 3.0
 
 // Update polls input devices and applies movement to entities
-// with PlayerInput and Velocity components. When the developer
-// console is open, all player input is ignored.
+// with PlayerInput and Velocity components. It runs in the simulation
+// phase, which the game pauses while the developer console is open.
 func (s *System) Update(w *ecs.World) {
 	if w == nil {
 		return
 	}
 
-	// Skip input when the developer console is open.
-	if devconsole.IsOpen() {
-		return
-	}
-
 	manager := w.EntitiesManager()
 	if manager == nil {
 		return
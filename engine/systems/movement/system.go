@@ -5,32 +5,46 @@ import (
 
 	"rp-go/engine/ecs"
 	"rp-go/engine/events"
+	"rp-go/engine/systems/spatial"
 )
 
 // System updates entity positions based on velocity and
-// rotates sprites to face their direction of travel.
-type System struct{}
+// rotates sprites to face their direction of travel. Displacement is scaled
+// by the world clock so motion is independent of the frame rate.
+type System struct {
+	Index *spatial.Index // refiled as entities move; optional
+}
+
+// Access declares the components movement touches for the scheduler.
+func (s *System) Access() ecs.Access {
+	return ecs.Access{
+		Reads:  []string{"Velocity"},
+		Writes: []string{"Position", "Sprite", "SpatialIndex"},
+	}
+}
 
 func (s *System) Update(w *ecs.World) {
-	manager := w.EntitiesManager()
-	if manager == nil {
+	if w == nil {
 		return
 	}
-	manager.ForEach(func(e *ecs.Entity) {
-		pos, hasPos := e.Get("Position").(*ecs.Position)
-		vel, hasVel := e.Get("Velocity").(*ecs.Velocity)
-		if !hasPos || !hasVel {
-			return
-		}
+	scale := w.Clock().StepScale()
+	if scale == 0 {
+		return // idle pass or paused
+	}
+	bus, _ := w.EventBus.(*events.TypedBus)
 
+	ecs.NewQuery2[*ecs.Position, *ecs.Velocity](w).Each(func(e *ecs.Entity, pos *ecs.Position, vel *ecs.Velocity) {
 		// Skip stationary entities.
 		if vel.VX == 0 && vel.VY == 0 {
 			return
 		}
 
 		// Move entity.
-		pos.X += vel.VX
-		pos.Y += vel.VY
+		pos.X += vel.VX * scale
+		pos.Y += vel.VY * scale
+		if s.Index != nil {
+			s.Index.Move(e, pos.X, pos.Y)
+		}
 
 		// Rotate sprite toward movement direction.
 		if spr, ok := e.Get("Sprite").(*ecs.Sprite); ok {
@@ -39,7 +53,7 @@ func (s *System) Update(w *ecs.World) {
 		}
 
 		// Publish movement event safely.
-		if bus, ok := w.EventBus.(*events.TypedBus); ok && bus != nil {
+		if bus != nil {
 			events.Queue(bus, events.EntityMovedEvent{
 				EntityID: int(e.ID),
 				X:        pos.X,
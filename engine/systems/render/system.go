@@ -7,6 +7,8 @@ import (
 	"rp-go/engine/platform"
 )
 
+// System draws every sprite whose scaled, rotated bounds reach the screen,
+// in the order NewQuery2[*Position, *Sprite] visits them.
 type System struct{}
 
 // Ensure this system only runs in the world pass
@@ -14,29 +16,29 @@ func (s *System) Layer() ecs.DrawLayer { return ecs.LayerWorld }
 
 func (s *System) Update(*ecs.World) {}
 
+// Access is empty: sprites are only read while drawing.
+func (s *System) Access() ecs.Access { return ecs.Access{} }
+
 // Draw renders all entities with Position + Sprite components using the active Camera.
 func (s *System) Draw(w *ecs.World, screen *platform.Image) {
 	if w == nil || screen == nil {
 		return
 	}
-	manager := w.EntitiesManager()
-	if manager == nil {
-		return
-	}
-	_, comp := manager.FirstComponent("Camera")
-	cam, _ := comp.(*ecs.Camera)
+	_, cam := ecs.NewQuery[*ecs.Camera](w).First()
 	if cam == nil {
 		return
 	}
 
+	// Blend between the last two simulation steps for smooth motion.
+	alpha := w.Clock().Alpha()
+	camX, camY := cam.Interpolated(alpha)
+
 	bounds := screen.Bounds()
-	halfW := float64(bounds.Dx()) / 2
-	halfH := float64(bounds.Dy()) / 2
+	screenW, screenH := float64(bounds.Dx()), float64(bounds.Dy())
+	halfW, halfH := screenW/2, screenH/2
 
-	manager.ForEach(func(e *ecs.Entity) {
-		pos, ok1 := e.Get("Position").(*ecs.Position)
-		sprite, ok2 := e.Get("Sprite").(*ecs.Sprite)
-		if !ok1 || !ok2 || sprite.Image == nil {
+	ecs.NewQuery2[*ecs.Position, *ecs.Sprite](w).Each(func(e *ecs.Entity, pos *ecs.Position, sprite *ecs.Sprite) {
+		if sprite.Image == nil {
 			return
 		}
 
@@ -55,8 +57,37 @@ func (s *System) Draw(w *ecs.World, screen *platform.Image) {
 			effectiveScale = math.Max(1, math.Round(cam.Scale))
 		}
 
+		// Attached entities inherit their hierarchy's scale.
+		if wt, ok := e.Get("WorldTransform").(*ecs.WorldTransform); ok && wt.Scale > 0 {
+			entityScale *= wt.Scale
+		}
+
 		totalScale := math.Max(0.01, effectiveScale*entityScale)
 
+		// Screen position of the sprite's center
+		x, y := pos.Interpolated(alpha)
+		drawX := (x - camX) * effectiveScale
+		drawY := (y - camY) * effectiveScale
+
+		if sprite.PixelPerfect {
+			drawX = math.Round(drawX)
+			drawY = math.Round(drawY)
+		}
+
+		finalX := drawX + halfW
+		finalY := drawY + halfH
+
+		if sprite.PixelPerfect {
+			finalX = math.Round(finalX)
+			finalY = math.Round(finalY)
+		}
+
+		// Cull sprites off screen at any rotation.
+		reach := math.Hypot(imgW, imgH) / 2 * totalScale
+		if finalX+reach < 0 || finalX-reach > screenW || finalY+reach < 0 || finalY-reach > screenH {
+			return
+		}
+
 		op := platform.NewDrawImageOptions()
 		op.SetFilter(platform.FilterNearest)
 
@@ -74,22 +105,6 @@ func (s *System) Draw(w *ecs.World, screen *platform.Image) {
 		op.Rotate(sprite.Rotation)
 
 		// Translate to world position (centered on entity)
-		drawX := (pos.X - cam.X) * effectiveScale
-		drawY := (pos.Y - cam.Y) * effectiveScale
-
-		if sprite.PixelPerfect {
-			drawX = math.Round(drawX)
-			drawY = math.Round(drawY)
-		}
-
-		finalX := drawX + halfW
-		finalY := drawY + halfH
-
-		if sprite.PixelPerfect {
-			finalX = math.Round(finalX)
-			finalY = math.Round(finalY)
-		}
-
 		op.Translate(finalX, finalY)
 
 		screen.DrawImage(sprite.Image, op)
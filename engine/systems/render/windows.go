@@ -22,6 +22,9 @@ func NewWindowRenderer(layer ecs.DrawLayer) *WindowRenderer {
 func (r *WindowRenderer) Layer() ecs.DrawLayer { return r.layer }
 func (r *WindowRenderer) Update(*ecs.World)    {}
 
+// Access is empty: windows are only read while drawing.
+func (r *WindowRenderer) Access() ecs.Access { return ecs.Access{} }
+
 // Draw renders all visible windows for the configured layer.
 func (r *WindowRenderer) Draw(world *ecs.World, screen *platform.Image) {
 	if world == nil || screen == nil {
@@ -57,7 +60,7 @@ func drawWindow(world *ecs.World, screen *platform.Image, comp *window.Component
 	borderColor := colorOrDefault(comp.Border, color.RGBA{180, 210, 255, 120})
 	drawBorder(canvas, b.Width, b.Height, borderColor)
 
-	if titleBarHeight > 0 && comp.Title != "" {
+	if title := comp.DisplayTitle(); titleBarHeight > 0 && title != "" {
 		textColor := colorOrDefault(comp.TitleColor, color.White)
 		textX := comp.Padding
 		if textX <= 0 {
@@ -67,7 +70,7 @@ func drawWindow(world *ecs.World, screen *platform.Image, comp *window.Component
 		if baseline < 12 {
 			baseline = 12
 		}
-		platform.DrawText(canvas, comp.Title, basicfont.Face7x13, textX, baseline, textColor)
+		platform.DrawText(canvas, title, basicfont.Face7x13, textX, baseline, textColor)
 	}
 
 	if !comp.Minimized && comp.Content != nil {
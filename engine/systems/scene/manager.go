@@ -63,6 +63,18 @@ func (m *Manager) QueueScene(scene ecs.Scene) {
 	m.next = scene
 }
 
+// Current returns the active scene, or nil before the first transition.
+func (m *Manager) Current() ecs.Scene {
+	return m.current
+}
+
+// InScene returns a run condition that holds while the named scene is active.
+func (m *Manager) InScene(name string) ecs.RunCondition {
+	return func(*ecs.World) bool {
+		return m.current != nil && m.current.Name() == name
+	}
+}
+
 /*───────────────────────────────────────────────*
  | UTILITIES                                     |
  *───────────────────────────────────────────────*/
@@ -0,0 +1,269 @@
+package spatial
+
+import (
+	"math"
+	"sort"
+
+	"rp-go/engine/ecs"
+)
+
+/*───────────────────────────────────────────────*
+ | SPATIAL HASH                                  |
+ *───────────────────────────────────────────────*/
+
+// Index is a spatial hash over entity positions. Space is cut into square
+// cells; each entity is filed under the cell holding its position, so
+// queries only visit the cells they overlap. Results come back in entity
+// ID order (Nearest: by distance, then ID), so they are the same on every
+// run.
+type Index struct {
+	cellSize float64
+	cells    map[cell][]*entry
+	entries  map[ecs.EntityID]*entry
+}
+
+type cell struct{ c, r int }
+
+type entry struct {
+	e    *ecs.Entity
+	x, y float64
+	at   cell
+	slot int // position in cells[at]
+}
+
+// DefaultCellSize suits ship-sized entities and the usual query radii.
+const DefaultCellSize = 128
+
+// NewIndex returns an empty index. Cells are cellSize world units wide;
+// pick roughly the most common query radius.
+func NewIndex(cellSize float64) *Index {
+	if cellSize <= 0 {
+		cellSize = DefaultCellSize
+	}
+	return &Index{
+		cellSize: cellSize,
+		cells:    make(map[cell][]*entry),
+		entries:  make(map[ecs.EntityID]*entry),
+	}
+}
+
+// Len returns the number of indexed entities.
+func (ix *Index) Len() int { return len(ix.entries) }
+
+// Reset drops every entity.
+func (ix *Index) Reset() {
+	clear(ix.cells)
+	clear(ix.entries)
+}
+
+// Move files e at (x, y), adding it if it is not indexed yet.
+func (ix *Index) Move(e *ecs.Entity, x, y float64) {
+	if e == nil {
+		return
+	}
+	at := ix.cellOf(x, y)
+	en, ok := ix.entries[e.ID]
+	if !ok {
+		en = &entry{e: e}
+		ix.entries[e.ID] = en
+	} else if en.at == at {
+		en.x, en.y = x, y
+		return
+	} else {
+		ix.unlink(en)
+	}
+	en.x, en.y, en.at = x, y, at
+	en.slot = len(ix.cells[at])
+	ix.cells[at] = append(ix.cells[at], en)
+}
+
+// Remove drops e from the index.
+func (ix *Index) Remove(e *ecs.Entity) {
+	if e == nil {
+		return
+	}
+	if en, ok := ix.entries[e.ID]; ok {
+		ix.unlink(en)
+		delete(ix.entries, e.ID)
+	}
+}
+
+// Position returns where e is filed.
+func (ix *Index) Position(e *ecs.Entity) (x, y float64, ok bool) {
+	if e == nil {
+		return 0, 0, false
+	}
+	en, ok := ix.entries[e.ID]
+	if !ok {
+		return 0, 0, false
+	}
+	return en.x, en.y, true
+}
+
+func (ix *Index) unlink(en *entry) {
+	list := ix.cells[en.at]
+	last := len(list) - 1
+	list[en.slot] = list[last]
+	list[en.slot].slot = en.slot
+	list[last] = nil
+	if last == 0 {
+		delete(ix.cells, en.at)
+		return
+	}
+	ix.cells[en.at] = list[:last]
+}
+
+func (ix *Index) cellOf(x, y float64) cell {
+	return cell{int(math.Floor(x / ix.cellSize)), int(math.Floor(y / ix.cellSize))}
+}
+
+/*───────────────────────────────────────────────*
+ | QUERIES                                       |
+ *───────────────────────────────────────────────*/
+
+// Radius appends to dst the entities within r of (x, y).
+func (ix *Index) Radius(x, y, r float64, dst []*ecs.Entity) []*ecs.Entity {
+	start := len(dst)
+	r2 := r * r
+	ix.visit(x-r, y-r, x+r, y+r, func(en *entry) {
+		if dx, dy := en.x-x, en.y-y; dx*dx+dy*dy <= r2 {
+			dst = append(dst, en.e)
+		}
+	})
+	sortByID(dst[start:])
+	return dst
+}
+
+// Rect appends to dst the entities inside the rectangle, edges included.
+func (ix *Index) Rect(minX, minY, maxX, maxY float64, dst []*ecs.Entity) []*ecs.Entity {
+	start := len(dst)
+	ix.visit(minX, minY, maxX, maxY, func(en *entry) {
+		if en.x >= minX && en.x <= maxX && en.y >= minY && en.y <= maxY {
+			dst = append(dst, en.e)
+		}
+	})
+	sortByID(dst[start:])
+	return dst
+}
+
+// visit calls fn for the entries of every cell overlapping the rectangle.
+// Huge rectangles walk the occupied cells instead of the covered ones.
+func (ix *Index) visit(minX, minY, maxX, maxY float64, fn func(*entry)) {
+	lo, hi := ix.cellOf(minX, minY), ix.cellOf(maxX, maxY)
+	if covered := float64(hi.c-lo.c+1) * float64(hi.r-lo.r+1); covered > float64(len(ix.cells)) {
+		for at, list := range ix.cells {
+			if at.c >= lo.c && at.c <= hi.c && at.r >= lo.r && at.r <= hi.r {
+				for _, en := range list {
+					fn(en)
+				}
+			}
+		}
+		return
+	}
+	for r := lo.r; r <= hi.r; r++ {
+		for c := lo.c; c <= hi.c; c++ {
+			for _, en := range ix.cells[cell{c, r}] {
+				fn(en)
+			}
+		}
+	}
+}
+
+// Nearest appends to dst up to k entities closest to (x, y), nearest
+// first, skipping those keep rejects (keep may be nil). Rings of cells are
+// searched outward until no unvisited cell can hold a closer entity.
+func (ix *Index) Nearest(x, y float64, k int, keep func(*ecs.Entity) bool, dst []*ecs.Entity) []*ecs.Entity {
+	if k <= 0 || len(ix.entries) == 0 {
+		return dst
+	}
+	var found []candidate
+	consider := func(en *entry) {
+		if keep == nil || keep(en.e) {
+			dx, dy := en.x-x, en.y-y
+			found = append(found, candidate{en.e, dx*dx + dy*dy})
+		}
+	}
+
+	center := ix.cellOf(x, y)
+	seen := 0
+	for ring := 0; seen < len(ix.entries); ring++ {
+		if 8*ring > len(ix.cells) {
+			// The rings now outnumber the occupied cells; finish with a
+			// scan of everything not visited yet.
+			for _, en := range ix.entries {
+				if chebyshev(en.at, center) >= ring {
+					consider(en)
+				}
+			}
+			break
+		}
+		ix.ring(center, ring, func(en *entry) {
+			seen++
+			consider(en)
+		})
+		// Unvisited cells lie at least ring cells away.
+		if len(found) >= k {
+			sortCandidates(found)
+			reach := float64(ring) * ix.cellSize
+			if found[k-1].d2 <= reach*reach {
+				break
+			}
+		}
+	}
+
+	sortCandidates(found)
+	for i := 0; i < len(found) && i < k; i++ {
+		dst = append(dst, found[i].e)
+	}
+	return dst
+}
+
+type candidate struct {
+	e  *ecs.Entity
+	d2 float64
+}
+
+// ring calls fn for the entries of the cells exactly n steps from center.
+func (ix *Index) ring(center cell, n int, fn func(*entry)) {
+	emit := func(c, r int) {
+		for _, en := range ix.cells[cell{c, r}] {
+			fn(en)
+		}
+	}
+	if n == 0 {
+		emit(center.c, center.r)
+		return
+	}
+	for c := center.c - n; c <= center.c+n; c++ {
+		emit(c, center.r-n)
+		emit(c, center.r+n)
+	}
+	for r := center.r - n + 1; r <= center.r+n-1; r++ {
+		emit(center.c-n, r)
+		emit(center.c+n, r)
+	}
+}
+
+func chebyshev(a, b cell) int {
+	dc, dr := a.c-b.c, a.r-b.r
+	if dc < 0 {
+		dc = -dc
+	}
+	if dr < 0 {
+		dr = -dr
+	}
+	return max(dc, dr)
+}
+
+func sortByID(list []*ecs.Entity) {
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+}
+
+func sortCandidates(list []candidate) {
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].d2 != list[j].d2 {
+			return list[i].d2 < list[j].d2
+		}
+		return list[i].e.ID < list[j].e.ID
+	})
+}
@@ -0,0 +1,223 @@
+package spatial
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"rp-go/engine/ecs"
+)
+
+// scatter adds n entities with positions spread over a side×side square.
+func scatter(w *ecs.World, ix *Index, n int, side float64, rng *rand.Rand) []*ecs.Entity {
+	out := make([]*ecs.Entity, n)
+	for i := range out {
+		e := w.NewEntity()
+		pos := &ecs.Position{X: rng.Float64() * side, Y: rng.Float64() * side}
+		e.Add(pos)
+		ix.Move(e, pos.X, pos.Y)
+		out[i] = e
+	}
+	return out
+}
+
+func ids(list []*ecs.Entity) []ecs.EntityID {
+	out := make([]ecs.EntityID, len(list))
+	for i, e := range list {
+		out[i] = e.ID
+	}
+	return out
+}
+
+func TestIndexQueriesMatchBruteForce(t *testing.T) {
+	w := ecs.NewWorld()
+	ix := NewIndex(64)
+	rng := rand.New(rand.NewSource(7))
+	all := scatter(w, ix, 500, 2000, rng)
+	// Move some entities around, remove others.
+	for i, e := range all {
+		switch i % 5 {
+		case 0:
+			pos := e.Get("Position").(*ecs.Position)
+			pos.X, pos.Y = rng.Float64()*2000-500, rng.Float64()*2000
+			ix.Move(e, pos.X, pos.Y)
+		case 1:
+			ix.Remove(e)
+		}
+	}
+	live := func(e *ecs.Entity) bool { _, _, ok := ix.Position(e); return ok }
+	dist2 := func(e *ecs.Entity, x, y float64) float64 {
+		p := e.Get("Position").(*ecs.Position)
+		return (p.X-x)*(p.X-x) + (p.Y-y)*(p.Y-y)
+	}
+
+	for q := 0; q < 50; q++ {
+		x, y, r := rng.Float64()*2000, rng.Float64()*2000, rng.Float64()*300
+
+		var want []*ecs.Entity
+		for _, e := range all {
+			if live(e) && dist2(e, x, y) <= r*r {
+				want = append(want, e)
+			}
+		}
+		if got := ix.Radius(x, y, r, nil); fmt.Sprint(ids(got)) != fmt.Sprint(ids(want)) {
+			t.Fatalf("Radius(%.0f,%.0f,%.0f) = %v, want %v", x, y, r, ids(got), ids(want))
+		}
+
+		want = want[:0]
+		for _, e := range all {
+			p := e.Get("Position").(*ecs.Position)
+			if live(e) && p.X >= x-r && p.X <= x+r && p.Y >= y && p.Y <= y+2*r {
+				want = append(want, e)
+			}
+		}
+		if got := ix.Rect(x-r, y, x+r, y+2*r, nil); fmt.Sprint(ids(got)) != fmt.Sprint(ids(want)) {
+			t.Fatalf("Rect = %v, want %v", ids(got), ids(want))
+		}
+
+		even := func(e *ecs.Entity) bool { return e.ID%2 == 0 }
+		want = want[:0]
+		for _, e := range all {
+			if live(e) && even(e) {
+				want = append(want, e)
+			}
+		}
+		sort.SliceStable(want, func(i, j int) bool { return dist2(want[i], x, y) < dist2(want[j], x, y) })
+		k := 1 + q%8
+		if got := ix.Nearest(x, y, k, even, nil); fmt.Sprint(ids(got)) != fmt.Sprint(ids(want[:k])) {
+			t.Fatalf("Nearest(k=%d) = %v, want %v", k, ids(got), ids(want[:k]))
+		}
+	}
+}
+
+func TestIndexNearestFindsDistantEntities(t *testing.T) {
+	w := ecs.NewWorld()
+	ix := NewIndex(10)
+	near, far := w.NewEntity(), w.NewEntity()
+	ix.Move(near, 0, 0)
+	ix.Move(far, 1e6, -1e6)
+
+	got := ix.Nearest(1e6, 1e6, 2, nil, nil)
+	if len(got) != 2 || got[0] != near || got[1] != far {
+		t.Fatalf("expected [near far], got %v", ids(got))
+	}
+	if got := ix.Nearest(0, 0, 3, func(e *ecs.Entity) bool { return e != near }, nil); len(got) != 1 || got[0] != far {
+		t.Fatalf("expected the filter to leave only far, got %v", ids(got))
+	}
+}
+
+func TestSystemTracksPositions(t *testing.T) {
+	w := ecs.NewWorld()
+	sys := NewSystem(32)
+	e := w.NewEntity()
+	e.Add(&ecs.Position{X: 10, Y: 10})
+	sys.Update(w)
+
+	if got := sys.Index().Radius(10, 10, 1, nil); len(got) != 1 || got[0] != e {
+		t.Fatalf("expected entity from the Position hook, got %v", ids(got))
+	}
+
+	// A scene teleports the entity without going through movement.
+	pos := e.Get("Position").(*ecs.Position)
+	pos.X, pos.Y = 500, 500
+	sys.Update(w)
+	if x, y, _ := sys.Index().Position(e); x != 500 || y != 500 {
+		t.Fatalf("expected Update to refile the entity, got %.0f,%.0f", x, y)
+	}
+
+	w.Commands().Destroy(e)
+	w.Flush()
+	if sys.Index().Len() != 0 {
+		t.Fatalf("expected destroyed entity to leave the index")
+	}
+}
+
+/*───────────────────────────────────────────────*
+ | BENCHMARKS                                    |
+ *───────────────────────────────────────────────*/
+
+// Entities are spread at a constant density, so each radius query sees
+// about the same number of neighbors at either size.
+func benchWorld(b *testing.B, n int) (*Index, []*ecs.Entity, float64) {
+	b.Helper()
+	w := ecs.NewWorld()
+	ix := NewIndex(DefaultCellSize)
+	side := math.Sqrt(float64(n)) * 64
+	return ix, scatter(w, ix, n, side, rand.New(rand.NewSource(1))), side
+}
+
+func BenchmarkRadius(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		b.Run(fmt.Sprintf("index/%d", n), func(b *testing.B) {
+			ix, all, _ := benchWorld(b, n)
+			var buf []*ecs.Entity
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				x, y, _ := ix.Position(all[i%n])
+				buf = ix.Radius(x, y, 160, buf[:0])
+			}
+		})
+		b.Run(fmt.Sprintf("scan/%d", n), func(b *testing.B) {
+			ix, all, _ := benchWorld(b, n)
+			var buf []*ecs.Entity
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				x, y, _ := ix.Position(all[i%n])
+				buf = buf[:0]
+				for _, e := range all {
+					ex, ey, _ := ix.Position(e)
+					if (ex-x)*(ex-x)+(ey-y)*(ey-y) <= 160*160 {
+						buf = append(buf, e)
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNearest(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			ix, all, _ := benchWorld(b, n)
+			var buf []*ecs.Entity
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				x, y, _ := ix.Position(all[i%n])
+				buf = ix.Nearest(x, y, 5, nil, buf[:0])
+			}
+		})
+	}
+}
+
+func BenchmarkRect(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			ix, _, side := benchWorld(b, n)
+			var buf []*ecs.Entity
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// A 640×360 view panning across the field.
+				x := math.Mod(float64(i)*37, side)
+				buf = ix.Rect(x, side/2, x+640, side/2+360, buf[:0])
+			}
+		})
+	}
+}
+
+// BenchmarkMove moves every entity a few units, as one movement pass does.
+func BenchmarkMove(b *testing.B) {
+	for _, n := range []int{1000, 10000} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			ix, all, _ := benchWorld(b, n)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, e := range all {
+					x, y, _ := ix.Position(e)
+					ix.Move(e, x+3, y-2)
+				}
+			}
+		})
+	}
+}
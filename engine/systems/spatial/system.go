@@ -0,0 +1,74 @@
+package spatial
+
+import "rp-go/engine/ecs"
+
+/*───────────────────────────────────────────────*
+ | SPATIAL SYSTEM                                |
+ *───────────────────────────────────────────────*/
+
+// System keeps an Index of every entity with a Position. Entities enter
+// and leave through world lifecycle hooks; movement.System files them as it
+// moves them, and Update catches positions changed anywhere else (scenes,
+// transforms, teleports) once per frame.
+type System struct {
+	index  *Index
+	world  *ecs.World
+	unbind []func()
+}
+
+// NewSystem returns a system maintaining an index with the given cell size
+// (0 for DefaultCellSize).
+func NewSystem(cellSize float64) *System {
+	return &System{index: NewIndex(cellSize)}
+}
+
+// Index exposes the index for other systems to query.
+func (s *System) Index() *Index {
+	return s.index
+}
+
+// Access declares the components the system touches for the scheduler.
+// SpatialIndex stands for the index itself, so readers and movers are
+// never scheduled alongside it.
+func (s *System) Access() ecs.Access {
+	return ecs.Access{
+		Reads:  []string{"Position"},
+		Writes: []string{"SpatialIndex"},
+	}
+}
+
+// Update binds the index to the world on first use and refiles entities
+// whose Position no longer matches the index.
+func (s *System) Update(w *ecs.World) {
+	if w == nil {
+		return
+	}
+	if s.world != w {
+		s.bind(w)
+	}
+	ecs.NewQuery[*ecs.Position](w).Each(func(e *ecs.Entity, pos *ecs.Position) {
+		if x, y, ok := s.index.Position(e); !ok || x != pos.X || y != pos.Y {
+			s.index.Move(e, pos.X, pos.Y)
+		}
+	})
+}
+
+// bind subscribes the index to Position add/remove notifications. Existing
+// entities are replayed by the world, so binding late is safe.
+func (s *System) bind(w *ecs.World) {
+	for _, unsubscribe := range s.unbind {
+		unsubscribe()
+	}
+	s.index.Reset()
+	s.world = w
+	s.unbind = []func(){
+		w.OnComponentAdded("Position", func(e *ecs.Entity, c ecs.Component) {
+			if pos, ok := c.(*ecs.Position); ok {
+				s.index.Move(e, pos.X, pos.Y)
+			}
+		}),
+		w.OnComponentRemoved("Position", func(e *ecs.Entity, _ ecs.Component) {
+			s.index.Remove(e)
+		}),
+	}
+}
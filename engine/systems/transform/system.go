@@ -0,0 +1,24 @@
+package transform
+
+import "rp-go/engine/ecs"
+
+// System propagates parent transforms to attached children each step, so
+// turrets, engine glows and wingmen follow and rotate with their parent.
+// Register it in PhasePostUpdate, ahead of the camera, so both the camera and
+// render.System see the resolved child positions.
+type System struct{}
+
+// Access declares the components propagation touches for the scheduler.
+func (s *System) Access() ecs.Access {
+	return ecs.Access{
+		Reads:  []string{"Children", "Parent", "LocalTransform"},
+		Writes: []string{"Position", "Sprite", "WorldTransform"},
+	}
+}
+
+func (s *System) Update(w *ecs.World) {
+	if w == nil {
+		return
+	}
+	w.PropagateTransforms()
+}
@@ -10,9 +10,15 @@ import (
 )
 
 // System tracks and updates all window components in the ECS world.
-// It maintains a shared global registry for the renderer to read.
+// It maintains a shared global registry for the renderer to read. Window
+// components are tracked through world lifecycle hooks instead of scanning
+// every entity each frame.
 type System struct {
 	registry *Registry
+
+	world   *ecs.World
+	tracked []*window.Component
+	unbind  []func()
 }
 
 var globalRegistry = NewRegistry()
@@ -40,10 +46,13 @@ func (s *System) Update(world *ecs.World) {
 		s.registry = globalRegistry
 	}
 
+	if s.world != world {
+		s.bind(world)
+	}
+
 	s.registry.Reset()
 
-	for _, e := range world.Entities {
-		comp, _ := e.Get("Window").(*window.Component)
+	for _, comp := range s.tracked {
 		if comp == nil || !comp.Visible {
 			continue
 		}
@@ -57,6 +66,30 @@ func (s *System) Update(world *ecs.World) {
 	UpdateWindowInteractions(s.registry.All(), bus)
 }
 
+// bind subscribes to Window add/remove notifications for the world.
+func (s *System) bind(world *ecs.World) {
+	for _, unsubscribe := range s.unbind {
+		unsubscribe()
+	}
+	s.world = world
+	s.tracked = s.tracked[:0]
+	s.unbind = []func(){
+		world.OnComponentAdded("Window", func(_ *ecs.Entity, c ecs.Component) {
+			if comp, ok := c.(*window.Component); ok {
+				s.tracked = append(s.tracked, comp)
+			}
+		}),
+		world.OnComponentRemoved("Window", func(_ *ecs.Entity, c ecs.Component) {
+			for i, comp := range s.tracked {
+				if comp == c {
+					s.tracked = append(s.tracked[:i], s.tracked[i+1:]...)
+					return
+				}
+			}
+		}),
+	}
+}
+
 // Draw is intentionally empty; rendering is handled in render.WindowRenderer.
 func (s *System) Draw(*ecs.World, *platform.Image) {}
 
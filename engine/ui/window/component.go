@@ -4,6 +4,7 @@ import (
 	"image/color"
 
 	"rp-go/engine/ecs"
+	"rp-go/engine/locale"
 	"rp-go/engine/platform"
 )
 
@@ -124,6 +125,15 @@ func NewComponent(id, title string, bounds Bounds, content Content) *Component {
 // Name implements ecs.Component.
 func (c *Component) Name() string { return "Window" }
 
+// DisplayTitle returns the title in the active locale: the string
+// "window.<ID>" when the string tables define it, Title otherwise.
+func (c *Component) DisplayTitle() string {
+	if key := "window." + c.ID; c.ID != "" && locale.Default.Has(key) {
+		return locale.T(key)
+	}
+	return c.Title
+}
+
 /* -------------------------------------------------------------------------- */
 /*                               Helper Methods                               */
 /* -------------------------------------------------------------------------- */
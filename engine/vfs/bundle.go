@@ -0,0 +1,340 @@
+package vfs
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+/*───────────────────────────────────────────────*
+| CONTENT-ADDRESSED BUNDLES                     |
+*───────────────────────────────────────────────*/
+
+// BundleName is the packed asset bundle looked for in the game root
+// (built by cmd/assetpack).
+const BundleName = "assets.bundle"
+
+// A bundle file is laid out as
+//
+//	magic "RPGOBNDL" | uint32 version | uint32 manifest size | manifest JSON | blobs
+//
+// Blobs are stored once per content hash; files with identical content
+// share one blob. Integers are little-endian.
+const (
+	bundleMagic   = "RPGOBNDL"
+	bundleVersion = 1
+	bundleHeader  = len(bundleMagic) + 8
+)
+
+// Manifest lists a bundle's files by game-relative path.
+type Manifest struct {
+	Version int                      `json:"version"`
+	Files   map[string]ManifestEntry `json:"files"`
+}
+
+// ManifestEntry describes one bundled file.
+type ManifestEntry struct {
+	Hash   string `json:"hash"`   // hex SHA-256 of the content
+	Size   int64  `json:"size"`   // bytes
+	Type   string `json:"type"`   // "image", "data", "font" or "other"
+	Offset int64  `json:"offset"` // blob position after the manifest
+}
+
+// HashContent returns the hash a manifest records for raw.
+func HashContent(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// ContentType classifies an asset by extension for the manifest.
+func ContentType(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		return "image"
+	case ".json":
+		return "data"
+	case ".ttf", ".otf":
+		return "font"
+	}
+	return "other"
+}
+
+// WriteBundle packs names from fsys into w and returns the manifest it
+// wrote. Output is deterministic for the same inputs.
+func WriteBundle(w io.Writer, fsys fs.FS, names []string) (Manifest, error) {
+	names = append([]string(nil), names...)
+	sort.Strings(names)
+
+	m := Manifest{Version: bundleVersion, Files: make(map[string]ManifestEntry, len(names))}
+	var blobs [][]byte
+	offsets := make(map[string]int64)
+	var end int64
+	for _, name := range names {
+		raw, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return Manifest{}, err
+		}
+		hash := HashContent(raw)
+		off, ok := offsets[hash]
+		if !ok {
+			off = end
+			offsets[hash] = off
+			blobs = append(blobs, raw)
+			end += int64(len(raw))
+		}
+		m.Files[name] = ManifestEntry{Hash: hash, Size: int64(len(raw)), Type: ContentType(name), Offset: off}
+	}
+
+	manifest, err := json.Marshal(m)
+	if err != nil {
+		return Manifest{}, err
+	}
+	bw := bufio.NewWriter(w)
+	bw.WriteString(bundleMagic)
+	binary.Write(bw, binary.LittleEndian, uint32(bundleVersion))
+	binary.Write(bw, binary.LittleEndian, uint32(len(manifest)))
+	bw.Write(manifest)
+	for _, blob := range blobs {
+		bw.Write(blob)
+	}
+	return m, bw.Flush()
+}
+
+// Bundle is a read-only fs.FS over a bundle file.
+type Bundle struct {
+	r        io.ReaderAt
+	closer   io.Closer
+	base     int64 // offset of the first blob
+	manifest Manifest
+	dirs     map[string][]fs.DirEntry
+}
+
+// OpenBundle opens and indexes a bundle file. It stays open until Close.
+func OpenBundle(file string) (*Bundle, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	b, err := ReadBundle(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	b.closer = f
+	return b, nil
+}
+
+// ReadBundle indexes a bundle of size bytes read through r.
+func ReadBundle(r io.ReaderAt, size int64) (*Bundle, error) {
+	head := make([]byte, bundleHeader)
+	if _, err := r.ReadAt(head, 0); err != nil {
+		return nil, fmt.Errorf("reading bundle header: %w", err)
+	}
+	if string(head[:len(bundleMagic)]) != bundleMagic {
+		return nil, errors.New("not an asset bundle")
+	}
+	if v := binary.LittleEndian.Uint32(head[len(bundleMagic):]); v != bundleVersion {
+		return nil, fmt.Errorf("bundle version %d not supported", v)
+	}
+	n := int64(binary.LittleEndian.Uint32(head[len(bundleMagic)+4:]))
+	raw := make([]byte, n)
+	if _, err := r.ReadAt(raw, int64(bundleHeader)); err != nil {
+		return nil, fmt.Errorf("reading bundle manifest: %w", err)
+	}
+	b := &Bundle{r: r, base: int64(bundleHeader) + n}
+	if err := json.Unmarshal(raw, &b.manifest); err != nil {
+		return nil, fmt.Errorf("bundle manifest: %w", err)
+	}
+	for name, e := range b.manifest.Files {
+		if !fs.ValidPath(name) || e.Offset < 0 || b.base+e.Offset+e.Size > size {
+			return nil, fmt.Errorf("bundle entry %q is out of range", name)
+		}
+	}
+	b.index()
+	return b, nil
+}
+
+// Manifest returns the bundle's manifest.
+func (b *Bundle) Manifest() Manifest { return b.manifest }
+
+// Close releases the bundle file.
+func (b *Bundle) Close() error {
+	if b.closer == nil {
+		return nil
+	}
+	return b.closer.Close()
+}
+
+// Verify re-hashes every blob and reports entries whose content no longer
+// matches the manifest.
+func (b *Bundle) Verify() error {
+	var bad []string
+	for name, e := range b.manifest.Files {
+		raw, err := b.read(e)
+		if err != nil || HashContent(raw) != e.Hash {
+			bad = append(bad, name)
+		}
+	}
+	if len(bad) > 0 {
+		sort.Strings(bad)
+		return fmt.Errorf("corrupt bundle entries: %s", strings.Join(bad, ", "))
+	}
+	return nil
+}
+
+func (b *Bundle) read(e ManifestEntry) ([]byte, error) {
+	raw := make([]byte, e.Size)
+	_, err := b.r.ReadAt(raw, b.base+e.Offset)
+	return raw, err
+}
+
+// index builds the directory listing from the manifest paths.
+func (b *Bundle) index() {
+	b.dirs = map[string][]fs.DirEntry{".": nil}
+	seen := make(map[string]bool)
+	for name, e := range b.manifest.Files {
+		b.addEntry(path.Dir(name), bundleInfo{name: path.Base(name), size: e.Size}, seen)
+		for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+			b.addEntry(path.Dir(dir), bundleInfo{name: path.Base(dir), dir: true}, seen)
+		}
+	}
+	for _, entries := range b.dirs {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	}
+}
+
+func (b *Bundle) addEntry(dir string, info bundleInfo, seen map[string]bool) {
+	full := path.Join(dir, info.name)
+	if seen[full] {
+		return
+	}
+	seen[full] = true
+	b.dirs[dir] = append(b.dirs[dir], fs.FileInfoToDirEntry(info))
+	if info.dir {
+		if _, ok := b.dirs[full]; !ok {
+			b.dirs[full] = nil
+		}
+	}
+}
+
+/*───────────────────────────────────────────────*
+| FS INTERFACES                                 |
+*───────────────────────────────────────────────*/
+
+// Open implements fs.FS.
+func (b *Bundle) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if e, ok := b.manifest.Files[name]; ok {
+		info := bundleInfo{name: path.Base(name), size: e.Size}
+		return &bundleFile{info: info, r: io.NewSectionReader(b.r, b.base+e.Offset, e.Size)}, nil
+	}
+	if entries, ok := b.dirs[name]; ok {
+		return &bundleDir{info: bundleInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (b *Bundle) ReadFile(name string) ([]byte, error) {
+	e, ok := b.manifest.Files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+	return b.read(e)
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (b *Bundle) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, ok := b.dirs[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	return append([]fs.DirEntry(nil), entries...), nil
+}
+
+// Stat implements fs.StatFS.
+func (b *Bundle) Stat(name string) (fs.FileInfo, error) {
+	f, err := b.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return f.Stat()
+}
+
+type bundleInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (i bundleInfo) Name() string       { return i.name }
+func (i bundleInfo) Size() int64        { return i.size }
+func (i bundleInfo) ModTime() time.Time { return time.Time{} }
+func (i bundleInfo) IsDir() bool        { return i.dir }
+func (i bundleInfo) Sys() any           { return nil }
+func (i bundleInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+type bundleFile struct {
+	info bundleInfo
+	r    *io.SectionReader
+}
+
+func (f *bundleFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *bundleFile) Read(p []byte) (int, error) { return f.r.Read(p) }
+func (f *bundleFile) Close() error               { return nil }
+
+// Seek lets image decoders and http.FileServer rewind.
+func (f *bundleFile) Seek(offset int64, whence int) (int64, error) {
+	return f.r.Seek(offset, whence)
+}
+
+type bundleDir struct {
+	info    bundleInfo
+	entries []fs.DirEntry
+	pos     int
+}
+
+func (d *bundleDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *bundleDir) Close() error               { return nil }
+func (d *bundleDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+func (d *bundleDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.pos:]
+	if n <= 0 {
+		d.pos = len(d.entries)
+		return append([]fs.DirEntry(nil), rest...), nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.pos += n
+	return append([]fs.DirEntry(nil), rest[:n]...), nil
+}
@@ -0,0 +1,91 @@
+package vfs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+/*───────────────────────────────────────────────*
+| DEFAULT SEARCH PATH                           |
+*───────────────────────────────────────────────*/
+
+// RootEnv overrides where loose game files are looked up.
+const RootEnv = "RPGO_ROOT"
+
+// ArchiveName is the packed asset archive looked for in the game root.
+const ArchiveName = "assets.zip"
+
+// Default is the game's search path: the loose game root, then
+// BundleName and ArchiveName inside it, then whatever packages embed via
+// MountEmbedded.
+var Default = newDefault()
+
+func newDefault() *FS {
+	v := New()
+	root := Root()
+	if archive := filepath.Join(root, ArchiveName); fileExists(archive) {
+		if err := v.MountArchive(archive); err != nil {
+			fmt.Printf("[VFS] Cannot mount %s: %v\n", archive, err)
+		}
+	}
+	if bundle := filepath.Join(root, BundleName); fileExists(bundle) {
+		if err := v.MountBundle(bundle); err != nil {
+			fmt.Printf("[VFS] Cannot mount %s: %v\n", bundle, err)
+		}
+	}
+	v.MountDir(root)
+	return v
+}
+
+// MountEmbedded adds compiled-in defaults at prefix on the Default search
+// path, below the loose directory and archive. Packages call it from init.
+func MountEmbedded(prefix string, fsys fs.FS) {
+	Default.Mount(Mount{Name: "embedded:" + prefix, Prefix: prefix, FS: fsys, Priority: PriorityEmbedded})
+}
+
+// Root finds the directory holding loose game files: $RPGO_ROOT, else the
+// working directory or the executable's directory, whichever contains
+// assets/ or engine/data/. It falls back to the working directory.
+func Root() string {
+	if dir := os.Getenv(RootEnv); dir != "" {
+		return dir
+	}
+	wd, _ := os.Getwd()
+	candidates := []string{wd}
+	if exe, err := os.Executable(); err == nil {
+		candidates = append(candidates, filepath.Dir(exe))
+	}
+	for _, dir := range candidates {
+		if dir == "" {
+			continue
+		}
+		if dirExists(filepath.Join(dir, "assets")) || dirExists(filepath.Join(dir, "engine", "data")) {
+			return dir
+		}
+	}
+	if wd == "" {
+		return "."
+	}
+	return wd
+}
+
+// Open opens name on the Default search path.
+func Open(name string) (fs.File, error) { return Default.Open(name) }
+
+// ReadFile reads name from the Default search path.
+func ReadFile(name string) ([]byte, error) { return Default.ReadFile(name) }
+
+// Glob matches pattern across the Default search path.
+func Glob(pattern string) ([]string, error) { return fs.Glob(Default, pattern) }
+
+func fileExists(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && !info.IsDir()
+}
+
+func dirExists(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.IsDir()
+}
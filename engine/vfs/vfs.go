@@ -0,0 +1,310 @@
+// Package vfs resolves game-relative asset paths ("assets/entities/ship.png",
+// "engine/data/actors.json") through a search path of mounted file systems,
+// so the game runs from any directory and can ship as a single binary.
+//
+// Mounts are consulted highest priority first: a loose directory beats a
+// packed archive or bundle, which beats the defaults embedded in the binary.
+package vfs
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mount priorities; a higher priority is searched first.
+const (
+	PriorityEmbedded  = 0
+	PriorityArchive   = 10
+	PriorityDirectory = 20
+)
+
+// Mount places an fs.FS at Prefix in the virtual tree.
+type Mount struct {
+	Name     string // shown in logs, e.g. "embedded:engine/data"
+	Prefix   string // virtual directory the FS appears under ("" for the root)
+	FS       fs.FS
+	Priority int
+	Dir      string // on-disk root for directory mounts, "" otherwise
+
+	seq int
+}
+
+// FS is a union of mounts. It implements fs.FS, fs.ReadFileFS, fs.StatFS and
+// fs.ReadDirFS, so fs.Glob and fs.WalkDir see the merged tree.
+type FS struct {
+	mu     sync.RWMutex
+	mounts []Mount
+	seq    int
+}
+
+// New returns an empty FS.
+func New() *FS { return &FS{} }
+
+// Mount adds m to the search path. Among equal priorities the later mount
+// wins.
+func (v *FS) Mount(m Mount) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	m.Prefix = strings.Trim(path.Clean("/"+filepath.ToSlash(m.Prefix)), "/")
+	v.seq++
+	m.seq = v.seq
+	v.mounts = append(v.mounts, m)
+	sort.SliceStable(v.mounts, func(i, j int) bool {
+		if v.mounts[i].Priority != v.mounts[j].Priority {
+			return v.mounts[i].Priority > v.mounts[j].Priority
+		}
+		return v.mounts[i].seq > v.mounts[j].seq
+	})
+}
+
+// MountDir mounts a loose directory at the root.
+func (v *FS) MountDir(dir string) {
+	v.Mount(Mount{Name: "dir:" + dir, FS: os.DirFS(dir), Priority: PriorityDirectory, Dir: dir})
+}
+
+// MountArchive mounts a zip archive at the root. The archive stays open for
+// the life of the process.
+func (v *FS) MountArchive(file string) error {
+	zr, err := zip.OpenReader(file)
+	if err != nil {
+		return err
+	}
+	v.Mount(Mount{Name: "archive:" + file, FS: zr, Priority: PriorityArchive})
+	return nil
+}
+
+// MountBundle mounts a content-addressed bundle (see WriteBundle) at the
+// root, alongside archives. The bundle stays open for the life of the
+// process.
+func (v *FS) MountBundle(file string) error {
+	b, err := OpenBundle(file)
+	if err != nil {
+		return err
+	}
+	v.Mount(Mount{Name: "bundle:" + file, FS: b, Priority: PriorityArchive})
+	return nil
+}
+
+// Mounts lists the search path, highest priority first.
+func (v *FS) Mounts() []Mount {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return append([]Mount(nil), v.mounts...)
+}
+
+/*───────────────────────────────────────────────*
+| FS INTERFACES                                 |
+*───────────────────────────────────────────────*/
+
+// Open opens name from the highest-priority mount that has it.
+func (v *FS) Open(name string) (fs.File, error) {
+	name, err := clean("open", name)
+	if err != nil {
+		return nil, err
+	}
+	var firstErr error
+	for _, m := range v.Mounts() {
+		rel, ok := m.rel(name)
+		if !ok {
+			continue
+		}
+		f, err := m.FS.Open(rel)
+		if err == nil {
+			return f, nil
+		}
+		if firstErr == nil && !errors.Is(err, fs.ErrNotExist) {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile reads name from the highest-priority mount that has it.
+func (v *FS) ReadFile(name string) ([]byte, error) {
+	f, err := v.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// Stat describes name as the winning mount sees it.
+func (v *FS) Stat(name string) (fs.FileInfo, error) {
+	name, err := clean("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range v.Mounts() {
+		if rel, ok := m.rel(name); ok {
+			if info, err := fs.Stat(m.FS, rel); err == nil {
+				return info, nil
+			}
+		}
+	}
+	if v.isMountParent(name) {
+		return dirInfo(path.Base(name)), nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir merges the directory across mounts; for duplicate names the
+// highest-priority entry wins.
+func (v *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	name, err := clean("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]fs.DirEntry)
+	found := false
+	for _, m := range v.Mounts() {
+		if rel, ok := m.rel(name); ok {
+			entries, err := fs.ReadDir(m.FS, rel)
+			if err != nil {
+				continue
+			}
+			found = true
+			for _, e := range entries {
+				if _, dup := seen[e.Name()]; !dup {
+					seen[e.Name()] = e
+				}
+			}
+			continue
+		}
+		// A mount below name shows up as a directory entry.
+		if child, ok := childOf(name, m.Prefix); ok {
+			found = true
+			if _, dup := seen[child]; !dup {
+				seen[child] = fs.FileInfoToDirEntry(dirInfo(child))
+			}
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	out := make([]fs.DirEntry, 0, len(seen))
+	for _, e := range seen {
+		out = append(out, e)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+	return out, nil
+}
+
+// DiskPath returns where name lives on disk when a directory mount serves
+// it, so callers can watch the file for hot reload.
+func (v *FS) DiskPath(name string) (string, bool) {
+	name, err := clean("stat", name)
+	if err != nil {
+		return "", false
+	}
+	for _, m := range v.Mounts() {
+		rel, ok := m.rel(name)
+		if !ok {
+			continue
+		}
+		if _, err := fs.Stat(m.FS, rel); err != nil {
+			continue
+		}
+		if m.Dir == "" {
+			return "", false
+		}
+		return filepath.Join(m.Dir, filepath.FromSlash(rel)), true
+	}
+	return "", false
+}
+
+// Describe names the mount that serves name, or "" when none does.
+func (v *FS) Describe(name string) string {
+	name, err := clean("stat", name)
+	if err != nil {
+		return ""
+	}
+	for _, m := range v.Mounts() {
+		if rel, ok := m.rel(name); ok {
+			if _, err := fs.Stat(m.FS, rel); err == nil {
+				return m.Name
+			}
+		}
+	}
+	return ""
+}
+
+/*───────────────────────────────────────────────*
+| PATH HELPERS                                  |
+*───────────────────────────────────────────────*/
+
+// rel maps a virtual path into the mount, if it lies under the prefix.
+func (m Mount) rel(name string) (string, bool) {
+	switch {
+	case m.Prefix == "":
+		return name, true
+	case name == m.Prefix:
+		return ".", true
+	case strings.HasPrefix(name, m.Prefix+"/"):
+		return name[len(m.Prefix)+1:], true
+	}
+	return "", false
+}
+
+func (v *FS) isMountParent(name string) bool {
+	for _, m := range v.Mounts() {
+		if _, ok := childOf(name, m.Prefix); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// childOf reports the first path element of prefix below dir.
+func childOf(dir, prefix string) (string, bool) {
+	if prefix == "" {
+		return "", false
+	}
+	rest := prefix
+	if dir != "." {
+		if !strings.HasPrefix(prefix, dir+"/") {
+			return "", false
+		}
+		rest = prefix[len(dir)+1:]
+	}
+	if i := strings.IndexByte(rest, '/'); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest, true
+}
+
+// clean turns OS-style or "./"-prefixed paths into fs.FS paths.
+func clean(op, name string) (string, error) {
+	name = path.Clean(filepath.ToSlash(name))
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return name, nil
+}
+
+// dirInfo describes a synthetic directory (a mount point's parent).
+type dirInfo string
+
+func (d dirInfo) Name() string       { return string(d) }
+func (d dirInfo) Size() int64        { return 0 }
+func (d dirInfo) Mode() fs.FileMode  { return fs.ModeDir | 0o555 }
+func (d dirInfo) ModTime() time.Time { return time.Time{} }
+func (d dirInfo) IsDir() bool        { return true }
+func (d dirInfo) Sys() any           { return nil }
+
+func (m Mount) String() string {
+	return fmt.Sprintf("%s (priority %d)", m.Name, m.Priority)
+}
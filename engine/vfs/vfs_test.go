@@ -0,0 +1,89 @@
+package vfs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestSearchPathPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "engine", "data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "engine", "data", "ai.json"), []byte("loose"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := New()
+	v.MountDir(dir)
+	v.Mount(Mount{Name: "embedded", Prefix: "engine/data", Priority: PriorityEmbedded, FS: fstest.MapFS{
+		"ai.json":     {Data: []byte("embedded")},
+		"actors.json": {Data: []byte("embedded")},
+	}})
+
+	if got, _ := v.ReadFile("./engine/data/ai.json"); string(got) != "loose" {
+		t.Fatalf("expected loose file to beat embedded, got %q", got)
+	}
+	if got, _ := v.ReadFile("engine/data/actors.json"); string(got) != "embedded" {
+		t.Fatalf("expected embedded fallback, got %q", got)
+	}
+	matches, err := fs.Glob(v, "engine/data/*.json")
+	if err != nil || strings.Join(matches, ",") != "engine/data/actors.json,engine/data/ai.json" {
+		t.Fatalf("expected glob across mounts, got %v (%v)", matches, err)
+	}
+	if p, ok := v.DiskPath("engine/data/ai.json"); !ok || p != filepath.Join(dir, "engine", "data", "ai.json") {
+		t.Fatalf("expected disk path for loose file, got %q %v", p, ok)
+	}
+	if _, ok := v.DiskPath("engine/data/actors.json"); ok {
+		t.Fatalf("embedded file must not report a disk path")
+	}
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	src := fstest.MapFS{
+		"assets/entities/ship.png":  {Data: []byte("png")},
+		"assets/entities/ship2.png": {Data: []byte("png")},
+		"engine/data/ai.json":       {Data: []byte(`{"actions":[]}`)},
+	}
+	names := []string{"engine/data/ai.json", "assets/entities/ship.png", "assets/entities/ship2.png"}
+	file := filepath.Join(t.TempDir(), BundleName)
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := WriteBundle(f, src, names)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b := m.Files["assets/entities/ship.png"], m.Files["assets/entities/ship2.png"]; a.Offset != b.Offset || a.Type != "image" {
+		t.Fatalf("expected identical content to share a blob, got %+v %+v", a, b)
+	}
+
+	b, err := OpenBundle(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Close()
+	if err := b.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err := fstest.TestFS(b, names...); err != nil {
+		t.Fatal(err)
+	}
+
+	v := New()
+	v.Mount(Mount{Name: "embedded", Prefix: "engine/data", Priority: PriorityEmbedded, FS: fstest.MapFS{
+		"ai.json": {Data: []byte("embedded")},
+	}})
+	if err := v.MountBundle(file); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := v.ReadFile("engine/data/ai.json"); string(got) != `{"actions":[]}` {
+		t.Fatalf("expected bundle to beat embedded, got %q", got)
+	}
+}
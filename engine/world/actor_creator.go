@@ -1,7 +1,9 @@
 package world
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 
 	"rp-go/engine/data"
@@ -14,12 +16,14 @@ import (
  *───────────────────────────────────────────────*/
 
 // ActorCreator spawns ECS entities from JSON-defined templates (actors.json).
-// It assigns unique IDs and attaches core components (Position, Sprite, etc.)
-// but does NOT attach AI — that's handled later by the aicomposer system.
+// It assigns unique IDs and attaches the template's components through its
+// ComponentRegistry but does NOT attach AI — that's handled later by the
+// aicomposer system.
 type ActorCreator struct {
-	templates map[string]data.ActorTemplate
-	counters  map[string]int
-	mu        sync.Mutex
+	templates  map[string]data.ActorTemplate
+	components *ComponentRegistry
+	counters   map[string]int
+	mu         sync.Mutex
 }
 
 // NewActorCreator constructs a new creator from a loaded ActorDatabase.
@@ -29,11 +33,18 @@ func NewActorCreator(db data.ActorDatabase) *ActorCreator {
 		templates[tpl.Name] = tpl
 	}
 	return &ActorCreator{
-		templates: templates,
-		counters:  make(map[string]int, len(templates)),
+		templates:  templates,
+		components: DefaultComponents(),
+		counters:   make(map[string]int, len(templates)),
 	}
 }
 
+// Components exposes the factory registry so games can add their own
+// template components.
+func (c *ActorCreator) Components() *ComponentRegistry {
+	return c.components
+}
+
 /*───────────────────────────────────────────────*
  | ENTITY CREATION                               |
  *───────────────────────────────────────────────*/
@@ -62,15 +73,13 @@ func (c *ActorCreator) Spawn(w *ecs.World, template string, pos ecs.Position) (*
 	}
 	e.Add(actor)
 
-	// --- Transform Components ---
+	// --- Spawn Position ---
 	e.Add(&ecs.Position{X: pos.X, Y: pos.Y})
-	if tpl.Velocity != nil {
-		e.Add(&ecs.Velocity{VX: tpl.Velocity.VX, VY: tpl.Velocity.VY})
-	}
 
-	// --- Sprite Component ---
-	if tpl.Sprite.Image != "" {
-		e.Add(buildSprite(tpl.Sprite))
+	// --- Template Components (Sprite, Velocity, Health, ...) ---
+	if err := c.components.Attach(e, tpl.ComponentSpecs()); err != nil {
+		w.RemoveEntity(e)
+		return nil, fmt.Errorf("actor template %q: %w", template, err)
 	}
 
 	// --- AI References (handled by AIComposer) ---
@@ -92,8 +101,9 @@ func (c *ActorCreator) PreloadImages() {
 	}
 	paths := make([]string, 0, len(c.templates))
 	for _, tpl := range c.templates {
-		if tpl.Sprite.Image != "" {
-			paths = append(paths, tpl.Sprite.Image)
+		var st data.ActorSpriteTemplate
+		if raw, ok := tpl.ComponentSpecs()["Sprite"]; ok && json.Unmarshal(raw, &st) == nil && st.Image != "" {
+			paths = append(paths, st.Image)
 		}
 	}
 	if len(paths) > 0 {
@@ -117,6 +127,22 @@ func (c *ActorCreator) Templates() []string {
 	return names
 }
 
+// TemplatesFrom lists, sorted, the templates last defined by mod pack layer
+// ("" for the base game).
+func (c *ActorCreator) TemplatesFrom(layer string) []string {
+	if c == nil {
+		return nil
+	}
+	var names []string
+	for name, tpl := range c.templates {
+		if tpl.Layer == layer {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // nextID generates a unique, per-template instance ID.
 func (c *ActorCreator) nextID(template string) string {
 	c.mu.Lock()
@@ -130,6 +156,7 @@ func buildSprite(st data.ActorSpriteTemplate) *ecs.Sprite {
 	img := gfx.LoadImage(st.Image)
 	sprite := &ecs.Sprite{
 		Image:          img,
+		ImagePath:      st.Image,
 		Width:          st.Width,
 		Height:         st.Height,
 		Rotation:       st.Rotation,
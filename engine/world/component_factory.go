@@ -0,0 +1,266 @@
+package world
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"rp-go/engine/data"
+	"rp-go/engine/ecs"
+)
+
+/*───────────────────────────────────────────────*
+| COMPONENT FACTORIES                           |
+*───────────────────────────────────────────────*/
+
+// ComponentFactory decodes one template component spec and attaches the
+// result to e.
+type ComponentFactory func(e *ecs.Entity, raw json.RawMessage) error
+
+// ComponentRegistry maps template component names to their factories.
+type ComponentRegistry struct {
+	factories map[string]ComponentFactory
+}
+
+// NewComponentRegistry returns an empty registry.
+func NewComponentRegistry() *ComponentRegistry {
+	return &ComponentRegistry{factories: make(map[string]ComponentFactory)}
+}
+
+// builtinFactories builds the components named by data.BuiltinComponents.
+var builtinFactories = map[string]ComponentFactory{
+	"Sprite":       buildSpriteComponent,
+	"Velocity":     buildVelocityComponent,
+	"Health":       buildHealthComponent,
+	"PlayerInput":  decodeInto(func() ecs.Component { return &ecs.PlayerInput{Enabled: true} }),
+	"CameraTarget": decodeInto(func() ecs.Component { return &ecs.CameraTarget{} }),
+	"Tags":         buildTags,
+	"Collider":     buildColliderComponent,
+}
+
+// DefaultComponents returns a registry with the engine's built-in factories,
+// one per data.BuiltinComponents name.
+func DefaultComponents() *ComponentRegistry {
+	r := NewComponentRegistry()
+	for _, name := range data.BuiltinComponents() {
+		f, ok := builtinFactories[name]
+		if !ok {
+			panic(fmt.Errorf("built-in component %q has no factory", name))
+		}
+		r.Register(name, f)
+	}
+	return r
+}
+
+// Register installs (or replaces) the factory for name.
+func (r *ComponentRegistry) Register(name string, f ComponentFactory) {
+	r.factories[name] = f
+}
+
+// Has reports whether name has a factory.
+func (r *ComponentRegistry) Has(name string) bool {
+	_, ok := r.factories[name]
+	return ok
+}
+
+// Names lists the registered component names, sorted.
+func (r *ComponentRegistry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Attach builds every spec onto e in name order.
+func (r *ComponentRegistry) Attach(e *ecs.Entity, specs map[string]json.RawMessage) error {
+	names := make([]string, 0, len(specs))
+	for name := range specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f, ok := r.factories[name]
+		if !ok {
+			return fmt.Errorf("unknown component %q", name)
+		}
+		if err := f(e, specs[name]); err != nil {
+			return fmt.Errorf("component %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+/*───────────────────────────────────────────────*
+| BUILT-IN FACTORIES                            |
+*───────────────────────────────────────────────*/
+
+// decodeInto unmarshals the spec over a fresh component from newFn. Field
+// names match case-insensitively, so {"enabled": true} fills Enabled.
+func decodeInto(newFn func() ecs.Component) ComponentFactory {
+	return func(e *ecs.Entity, raw json.RawMessage) error {
+		c := newFn()
+		if len(raw) > 0 && string(raw) != "null" {
+			if err := json.Unmarshal(raw, c); err != nil {
+				return err
+			}
+		}
+		e.Add(c)
+		return nil
+	}
+}
+
+func buildSpriteComponent(e *ecs.Entity, raw json.RawMessage) error {
+	var st data.ActorSpriteTemplate
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return err
+	}
+	if st.Image == "" {
+		return fmt.Errorf("sprite has no image")
+	}
+	e.Add(buildSprite(st))
+	return nil
+}
+
+func buildVelocityComponent(e *ecs.Entity, raw json.RawMessage) error {
+	var v data.ActorVelocityPreset
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	e.Add(&ecs.Velocity{VX: v.VX, VY: v.VY})
+	return nil
+}
+
+// buildHealthComponent starts at full health unless "current" is given.
+func buildHealthComponent(e *ecs.Entity, raw json.RawMessage) error {
+	var spec struct {
+		Current *float64 `json:"current"`
+		Max     float64  `json:"max"`
+	}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return err
+	}
+	if spec.Max <= 0 {
+		return fmt.Errorf("health needs a positive max")
+	}
+	hp := &ecs.Health{Current: spec.Max, Max: spec.Max}
+	if spec.Current != nil {
+		hp.Current = *spec.Current
+	}
+	e.Add(hp)
+	return nil
+}
+
+// buildTags adds one ecs.Tag per listed name. Tags are kept apart from
+// components, so any name is allowed, even one matching a component.
+func buildTags(e *ecs.Entity, raw json.RawMessage) error {
+	var tags []string
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if tag == "" {
+			return fmt.Errorf("empty tag name")
+		}
+		e.Add(ecs.Tag(tag))
+	}
+	return nil
+}
+
+var colliderShapes = map[string]ecs.ColliderShape{
+	"circle":  ecs.ShapeCircle,
+	"box":     ecs.ShapeBox,
+	"polygon": ecs.ShapePolygon,
+}
+
+var collisionResponses = map[string]ecs.CollisionResponse{
+	"":       ecs.ResponseNone,
+	"none":   ecs.ResponseNone,
+	"stop":   ecs.ResponseStop,
+	"slide":  ecs.ResponseSlide,
+	"bounce": ecs.ResponseBounce,
+}
+
+// buildColliderComponent reads a collider spec such as
+//
+//	{"shape": "circle", "radius": 28, "response": "slide"}
+//	{"shape": "box", "half_w": 40, "half_h": 12, "static": true}
+//	{"shape": "polygon", "points": [{"x": 0, "y": -20}, {"x": 16, "y": 12}, {"x": -16, "y": 12}]}
+//
+// "layer" and "mask" are bit masks (default layer 1, every layer).
+func buildColliderComponent(e *ecs.Entity, raw json.RawMessage) error {
+	var spec struct {
+		Shape    string     `json:"shape"`
+		Radius   float64    `json:"radius"`
+		HalfW    float64    `json:"half_w"`
+		HalfH    float64    `json:"half_h"`
+		Points   []ecs.Vec2 `json:"points"`
+		OffsetX  float64    `json:"offset_x"`
+		OffsetY  float64    `json:"offset_y"`
+		Layer    uint32     `json:"layer"`
+		Mask     uint32     `json:"mask"`
+		Trigger  bool       `json:"trigger"`
+		Static   bool       `json:"static"`
+		Response string     `json:"response"`
+		Bounce   float64    `json:"bounce"`
+	}
+	if err := json.Unmarshal(raw, &spec); err != nil {
+		return err
+	}
+	shape, ok := colliderShapes[spec.Shape]
+	if !ok {
+		return fmt.Errorf("unknown collider shape %q (circle, box or polygon)", spec.Shape)
+	}
+	response, ok := collisionResponses[spec.Response]
+	if !ok {
+		return fmt.Errorf("unknown collision response %q (none, stop, slide or bounce)", spec.Response)
+	}
+	switch {
+	case shape == ecs.ShapeCircle && spec.Radius <= 0:
+		return fmt.Errorf("circle collider needs a positive radius")
+	case shape == ecs.ShapeBox && (spec.HalfW <= 0 || spec.HalfH <= 0):
+		return fmt.Errorf("box collider needs positive half_w and half_h")
+	case shape == ecs.ShapePolygon && !convex(spec.Points):
+		return fmt.Errorf("polygon collider needs at least 3 points forming a convex shape")
+	case spec.Bounce < 0 || spec.Bounce > 1:
+		return fmt.Errorf("bounce must be between 0 and 1")
+	}
+	e.Add(&ecs.Collider{
+		Shape:    shape,
+		Radius:   spec.Radius,
+		HalfW:    spec.HalfW,
+		HalfH:    spec.HalfH,
+		Points:   spec.Points,
+		Offset:   ecs.Vec2{X: spec.OffsetX, Y: spec.OffsetY},
+		Layer:    spec.Layer,
+		Mask:     spec.Mask,
+		Trigger:  spec.Trigger,
+		Static:   spec.Static,
+		Response: response,
+		Bounce:   spec.Bounce,
+	})
+	return nil
+}
+
+// convex reports whether points, taken in order, turn the same way at
+// every vertex.
+func convex(points []ecs.Vec2) bool {
+	if len(points) < 3 {
+		return false
+	}
+	var sign float64
+	for i := range points {
+		a, b, c := points[i], points[(i+1)%len(points)], points[(i+2)%len(points)]
+		cross := (b.X-a.X)*(c.Y-b.Y) - (b.Y-a.Y)*(c.X-b.X)
+		if cross == 0 {
+			continue
+		}
+		if sign != 0 && math.Signbit(cross) != math.Signbit(sign) {
+			return false
+		}
+		sign = cross
+	}
+	return sign != 0
+}